@@ -1,5 +1,7 @@
 package api
 
+// ChatCompletionContextConfig is the config of the unbounded chat completion context,
+// which sends all messages to the model.
 type ChatCompletionContextConfig struct{}
 
 func (c *ChatCompletionContextConfig) ToConfig() (map[string]interface{}, error) {
@@ -9,3 +11,43 @@ func (c *ChatCompletionContextConfig) ToConfig() (map[string]interface{}, error)
 func (c *ChatCompletionContextConfig) FromConfig(config map[string]interface{}) error {
 	return fromConfig(c, config)
 }
+
+type BufferedChatCompletionContextConfig struct {
+	BufferSize int `json:"buffer_size"`
+}
+
+func (c *BufferedChatCompletionContextConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *BufferedChatCompletionContextConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
+
+type HeadAndTailChatCompletionContextConfig struct {
+	HeadSize int `json:"head_size"`
+	TailSize int `json:"tail_size"`
+}
+
+func (c *HeadAndTailChatCompletionContextConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *HeadAndTailChatCompletionContextConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
+
+type TokenLimitedChatCompletionContextConfig struct {
+	// ModelClient counts the tokens of the messages
+	ModelClient *Component `json:"model_client"`
+	// TokenLimit defaults to the remaining tokens of the context window of the model
+	TokenLimit *int `json:"token_limit,omitempty"`
+}
+
+func (c *TokenLimitedChatCompletionContextConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *TokenLimitedChatCompletionContextConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
@@ -133,3 +133,77 @@ func (c *OllamaClientConfiguration) ToConfig() (map[string]interface{}, error) {
 func (c *OllamaClientConfiguration) FromConfig(config map[string]interface{}) error {
 	return fromConfig(c, config)
 }
+
+type GeminiSafetySetting struct {
+	Category  string `json:"category"`
+	Threshold string `json:"threshold"`
+}
+
+type GeminiClientConfig struct {
+	BaseClientConfig
+	Model           string                `json:"model"`
+	APIKey          string                `json:"api_key,omitempty"`
+	BaseURL         string                `json:"base_url,omitempty"`
+	Temperature     *float64              `json:"temperature,omitempty"`
+	MaxOutputTokens int                   `json:"max_output_tokens,omitempty"`
+	TopP            *float64              `json:"top_p,omitempty"`
+	TopK            int                   `json:"top_k,omitempty"`
+	CandidateCount  int                   `json:"candidate_count,omitempty"`
+	SafetySettings  []GeminiSafetySetting `json:"safety_settings,omitempty"`
+	ModelInfo       *ModelInfo            `json:"model_info,omitempty"`
+}
+
+func (c *GeminiClientConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *GeminiClientConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
+
+// FailoverClientConfig tries its model clients in order until a request succeeds.
+type FailoverClientConfig struct {
+	ModelClients []*Component `json:"model_clients"`
+	// RoundRobin rotates the model client which is tried first on every request
+	RoundRobin bool `json:"round_robin,omitempty"`
+}
+
+func (c *FailoverClientConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *FailoverClientConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
+
+type MockToolCall struct {
+	Name string `json:"name"`
+	// Arguments is the JSON encoded arguments of the call
+	Arguments string `json:"arguments"`
+}
+
+type MockResponse struct {
+	Content   string         `json:"content,omitempty"`
+	ToolCalls []MockToolCall `json:"tool_calls,omitempty"`
+}
+
+type MockRule struct {
+	// Match is a regular expression matched against the content of the last message
+	Match    string       `json:"match"`
+	Response MockResponse `json:"response"`
+}
+
+type MockChatCompletionClientConfig struct {
+	Model           string        `json:"model"`
+	Rules           []MockRule    `json:"rules"`
+	DefaultResponse *MockResponse `json:"default_response,omitempty"`
+	ModelInfo       *ModelInfo    `json:"model_info,omitempty"`
+}
+
+func (c *MockChatCompletionClientConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *MockChatCompletionClientConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
@@ -69,3 +69,53 @@ func (c *StopMessageTerminationConfig) ToConfig() (map[string]interface{}, error
 func (c *StopMessageTerminationConfig) FromConfig(config map[string]interface{}) error {
 	return fromConfig(c, config)
 }
+
+type TimeoutTerminationConfig struct {
+	TimeoutSeconds float64 `json:"timeout_seconds"`
+}
+
+func (c *TimeoutTerminationConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *TimeoutTerminationConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
+
+type TokenUsageTerminationConfig struct {
+	MaxTotalToken      *int `json:"max_total_token,omitempty"`
+	MaxPromptToken     *int `json:"max_prompt_token,omitempty"`
+	MaxCompletionToken *int `json:"max_completion_token,omitempty"`
+}
+
+func (c *TokenUsageTerminationConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *TokenUsageTerminationConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
+
+type HandoffTerminationConfig struct {
+	Target string `json:"target"`
+}
+
+func (c *HandoffTerminationConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *HandoffTerminationConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
+
+type SourceMatchTerminationConfig struct {
+	Sources []string `json:"sources"`
+}
+
+func (c *SourceMatchTerminationConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *SourceMatchTerminationConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
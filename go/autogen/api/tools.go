@@ -44,3 +44,18 @@ func (c *TeamToolConfig) ToConfig() (map[string]interface{}, error) {
 func (c *TeamToolConfig) FromConfig(config map[string]interface{}) error {
 	return fromConfig(c, config)
 }
+
+type RemoteAgentToolConfig struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// The RemoteAgent to send tasks to, in the form <namespace>/<name>.
+	RemoteAgent string `json:"remote_agent"`
+}
+
+func (c *RemoteAgentToolConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *RemoteAgentToolConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
@@ -2,7 +2,10 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,69 +15,183 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	defaultTimeout        = time.Minute * 30
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+	// DefaultMaxEventSize is the default limit on the size of a single streamed event.
+	DefaultMaxEventSize = 16 * 1024 * 1024
+)
+
 type client struct {
 	BaseURL    string
 	HTTPClient *http.Client
+
+	timeout         *time.Duration
+	retryPolicy     RetryPolicy
+	requestEditors  []RequestEditorFn
+	tlsClientConfig *tls.Config
+	maxEventSize    int
 }
 
 type Client interface {
-	CreateFeedback(feedback *FeedbackSubmission) error
-	CreateRun(req *CreateRunRequest) (*CreateRunResult, error)
-	CreateSession(session *CreateSession) (*Session, error)
-	CreateTeam(team *Team) error
-	CreateToolServer(toolServer *ToolServer, userID string) (*ToolServer, error)
-	DeleteRun(runID uuid.UUID) error
-	DeleteSession(sessionID int, userID string) error
-	DeleteTeam(teamID int, userID string) error
-	DeleteToolServer(serverID *int, userID string) error
-	GetRun(runID int) (*Run, error)
-	GetRunMessages(runID uuid.UUID) ([]*RunMessage, error)
-	GetSession(sessionLabel string, userID string) (*Session, error)
-	GetSessionById(sessionID int, userID string) (*Session, error)
-	GetTeam(teamLabel string, userID string) (*Team, error)
-	GetTeamByID(teamID int, userID string) (*Team, error)
-	GetTool(provider string, userID string) (*Tool, error)
-	GetToolServer(serverID int, userID string) (*ToolServer, error)
-	GetToolServerByLabel(toolServerLabel string, userID string) (*ToolServer, error)
-	GetVersion() (string, error)
-	InvokeSession(sessionID int, userID string, task string) (*TeamResult, error)
-	InvokeSessionStream(sessionID int, userID string, task string) (<-chan *SseEvent, error)
-	InvokeTask(req *InvokeTaskRequest) (*InvokeTaskResult, error)
-	InvokeTaskStream(req *InvokeTaskRequest) (<-chan *SseEvent, error)
-	ListFeedback(userID string) ([]*FeedbackSubmission, error)
-	ListRuns(userID string) ([]*Run, error)
-	ListSessionRuns(sessionID int, userID string) ([]*Run, error)
-	ListSessions(userID string) ([]*Session, error)
-	ListSupportedModels() (*ProviderModels, error)
-	ListTeams(userID string) ([]*Team, error)
-	ListToolServers(userID string) ([]*ToolServer, error)
-	ListTools(userID string) ([]*Tool, error)
-	ListToolsForServer(serverID *int, userID string) ([]*Tool, error)
-	RefreshToolServer(serverID int, userID string) error
-	RefreshTools(serverID *int, userID string) error
-	UpdateSession(sessionID int, userID string, session *Session) (*Session, error)
-	UpdateToolServer(server *ToolServer, userID string) error
-	Validate(req *ValidationRequest) (*ValidationResponse, error)
-}
-
-func New(baseURL string) Client {
+	CreateFeedback(ctx context.Context, feedback *FeedbackSubmission) error
+	CreateRun(ctx context.Context, req *CreateRunRequest) (*CreateRunResult, error)
+	CreateSession(ctx context.Context, session *CreateSession) (*Session, error)
+	CreateTeam(ctx context.Context, team *Team) error
+	CreateToolServer(ctx context.Context, toolServer *ToolServer, userID string) (*ToolServer, error)
+	DeleteRun(ctx context.Context, runID uuid.UUID) error
+	DeleteSession(ctx context.Context, sessionID int, userID string) error
+	DeleteTeam(ctx context.Context, teamID int, userID string) error
+	DeleteToolServer(ctx context.Context, serverID *int, userID string) error
+	GetRun(ctx context.Context, runID int) (*Run, error)
+	GetRunMessages(ctx context.Context, runID uuid.UUID) ([]*RunMessage, error)
+	GetSession(ctx context.Context, sessionLabel string, userID string) (*Session, error)
+	GetSessionById(ctx context.Context, sessionID int, userID string) (*Session, error)
+	GetTeam(ctx context.Context, teamLabel string, userID string) (*Team, error)
+	GetTeamByID(ctx context.Context, teamID int, userID string) (*Team, error)
+	GetTool(ctx context.Context, provider string, userID string) (*Tool, error)
+	GetToolServer(ctx context.Context, serverID int, userID string) (*ToolServer, error)
+	GetToolServerByLabel(ctx context.Context, toolServerLabel string, userID string) (*ToolServer, error)
+	GetVersion(ctx context.Context) (string, error)
+	// The invoke methods stop the run in the backend when the context is canceled.
+	InvokeSession(ctx context.Context, sessionID int, userID string, task string) (*TeamResult, error)
+	InvokeSessionStream(ctx context.Context, sessionID int, userID string, task string) (<-chan *SseEvent, error)
+	InvokeTask(ctx context.Context, req *InvokeTaskRequest) (*InvokeTaskResult, error)
+	InvokeTaskStream(ctx context.Context, req *InvokeTaskRequest) (<-chan *SseEvent, error)
+	ListFeedback(ctx context.Context, userID string) ([]*FeedbackSubmission, error)
+	ListRuns(ctx context.Context, userID string) ([]*Run, error)
+	ListSessionRuns(ctx context.Context, sessionID int, userID string) ([]*Run, error)
+	ListSessions(ctx context.Context, userID string) ([]*Session, error)
+	ListSupportedModels(ctx context.Context) (*ProviderModels, error)
+	ListTeams(ctx context.Context, userID string) ([]*Team, error)
+	ListToolServers(ctx context.Context, userID string) ([]*ToolServer, error)
+	ListTools(ctx context.Context, userID string) ([]*Tool, error)
+	ListToolsForServer(ctx context.Context, serverID *int, userID string) ([]*Tool, error)
+	RefreshToolServer(ctx context.Context, serverID int, userID string) error
+	RefreshTools(ctx context.Context, serverID *int, userID string) error
+	UpdateSession(ctx context.Context, sessionID int, userID string, session *Session) (*Session, error)
+	UpdateToolServer(ctx context.Context, server *ToolServer, userID string) error
+	Validate(ctx context.Context, req *ValidationRequest) (*ValidationResponse, error)
+}
+
+// RetryPolicy configures how idempotent requests (GET, PUT and DELETE) are retried
+// after connection errors and responses indicating the backend is temporarily unavailable.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. 0 disables retries.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry, doubled on every further retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+}
+
+// RequestEditorFn is called on every request before it is sent, e.g. to add auth headers.
+type RequestEditorFn func(req *http.Request) error
+
+// Option configures the client.
+type Option func(*client)
+
+// WithHTTPClient replaces the HTTP client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithTimeout sets the timeout of a single request, including the time spent reading streamed responses.
+// 0 disables the timeout, leaving it to the context of the request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *client) {
+		c.timeout = &timeout
+	}
+}
+
+// WithRetryPolicy replaces the default retry policy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithRequestEditor adds a function which is called on every request before it is sent.
+func WithRequestEditor(fn RequestEditorFn) Option {
+	return func(c *client) {
+		c.requestEditors = append(c.requestEditors, fn)
+	}
+}
+
+// WithBearerToken authenticates every request with the given token.
+func WithBearerToken(token string) Option {
+	return WithRequestEditor(func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the backend,
+// e.g. to trust a private CA or to present a client certificate.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(c *client) {
+		c.tlsClientConfig = tlsConfig
+	}
+}
+
+// WithMaxEventSize limits the size of a single event of the streaming invoke methods.
+// Streams with larger events end with an error event.
+func WithMaxEventSize(size int) Option {
+	return func(c *client) {
+		c.maxEventSize = size
+	}
+}
+
+func New(baseURL string, opts ...Option) Client {
 	// Ensure baseURL doesn't end with a slash
 	baseURL = strings.TrimRight(baseURL, "/")
 
-	return &client{
+	c := &client{
 		BaseURL: baseURL,
 		HTTPClient: &http.Client{
-			Timeout: time.Minute * 30,
+			Timeout: defaultTimeout,
+		},
+		retryPolicy: RetryPolicy{
+			MaxRetries:     defaultMaxRetries,
+			InitialBackoff: defaultInitialBackoff,
+			MaxBackoff:     defaultMaxBackoff,
 		},
+		maxEventSize: DefaultMaxEventSize,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// the HTTP client may be shared with the caller, so it is copied rather than modified
+	if c.timeout != nil || c.tlsClientConfig != nil {
+		httpClient := *c.HTTPClient
+		if c.timeout != nil {
+			httpClient.Timeout = *c.timeout
+		}
+		if c.tlsClientConfig != nil {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			if t, ok := httpClient.Transport.(*http.Transport); ok {
+				transport = t.Clone()
+			}
+			transport.TLSClientConfig = c.tlsClientConfig
+			httpClient.Transport = transport
+		}
+		c.HTTPClient = &httpClient
 	}
+
+	return c
 }
 
-func (c *client) GetVersion() (string, error) {
+func (c *client) GetVersion(ctx context.Context) (string, error) {
 	var result struct {
 		Version string `json:"version"`
 	}
 
-	err := c.doRequest("GET", "/version", nil, &result)
+	err := c.doRequest(ctx, "GET", "/version", nil, &result)
 	if err != nil {
 		return "", err
 	}
@@ -82,14 +199,16 @@ func (c *client) GetVersion() (string, error) {
 	return result.Version, nil
 }
 
-func (c *client) startRequest(method, path string, body interface{}) (*http.Response, error) {
-	var bodyReader *bytes.Reader
+// startRequest sends the request, retrying idempotent requests according to the retry policy.
+// Responses with an error status are returned as *APIError.
+func (c *client) startRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	var bodyBytes []byte
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
+		var err error
+		bodyBytes, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("error marshaling request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
 	// Ensure path starts with a slash
@@ -97,34 +216,88 @@ func (c *client) startRequest(method, path string, body interface{}) (*http.Resp
 		path = "/" + path
 	}
 
-	url := c.BaseURL + path
+	maxRetries := 0
+	if isIdempotent(method) {
+		maxRetries = c.retryPolicy.MaxRetries
+	}
+	backoff := c.retryPolicy.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.sendRequest(ctx, method, path, bodyBytes)
+		if err == nil && resp.StatusCode < 400 {
+			return resp, nil
+		}
+		if err == nil {
+			err = newAPIError(method, path, resp)
+		}
+		if attempt >= maxRetries || !isRetryable(ctx, err) {
+			return nil, err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff = min(backoff*2, c.retryPolicy.MaxBackoff)
+	}
+}
 
-	var req *http.Request
-	var err error
-	if bodyReader != nil {
-		req, err = http.NewRequest(method, url, bodyReader)
-	} else {
-		req, err = http.NewRequest(method, url, nil)
+func (c *client) sendRequest(ctx context.Context, method, path string, bodyBytes []byte) (*http.Response, error) {
+	var bodyReader io.Reader
+	if bodyBytes != nil {
+		bodyReader = bytes.NewReader(bodyBytes)
 	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	for _, edit := range c.requestEditors {
+		if err := edit(req); err != nil {
+			return nil, fmt.Errorf("error editing request: %w", err)
+		}
+	}
 
-	return c.HTTPClient.Do(req)
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	return resp, nil
 }
 
-func (c *client) doRequest(method, path string, body interface{}, result interface{}) error {
-	resp, err := c.startRequest(method, path, body)
-	if err != nil {
-		return fmt.Errorf("error making request: %w", err)
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
 	}
-	defer resp.Body.Close()
+	return false
+}
 
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("request failed with status: %s", resp.Status)
+// isRetryable reports whether the request may succeed if sent again.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
 	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	// connection errors
+	return true
+}
+
+func (c *client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	resp, err := c.startRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -142,7 +315,12 @@ func (c *client) doRequest(method, path string, body interface{}, result interfa
 	} else {
 		// Check response status
 		if !apiResp.Status {
-			return fmt.Errorf("api error: [%+v]", apiResp)
+			return &APIError{
+				Method:     method,
+				Path:       path,
+				StatusCode: resp.StatusCode,
+				Message:    apiResp.Message,
+			}
 		}
 
 		// If caller wants the result, marshal the Data field into their result type
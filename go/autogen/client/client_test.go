@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxRetries:     2,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+}
+
+func TestClientRetriesIdempotentRequests(t *testing.T) {
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"version":"0.1.0"}`))
+	}))
+	defer ts.Close()
+
+	c := New(ts.URL, WithRetryPolicy(testRetryPolicy), WithBearerToken("secret"))
+	version, err := c.GetVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "0.1.0", version)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestClientDoesNotRetryPost(t *testing.T) {
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := New(ts.URL, WithRetryPolicy(testRetryPolicy))
+	err := c.CreateTeam(context.Background(), &Team{})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestClientReturnsAPIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"detail":"Team not found"}`))
+	}))
+	defer ts.Close()
+
+	c := New(ts.URL, WithRetryPolicy(testRetryPolicy))
+	_, err := c.GetTeamByID(context.Background(), 1, "user")
+	require.Error(t, err)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "Team not found", apiErr.Message)
+	assert.True(t, IsNotFound(err))
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const maxErrorBodySize = 64 * 1024
+
+var (
+	NotFoundError = errors.New("not found")
+)
+
+// APIError is returned when the backend responds with an error.
+type APIError struct {
+	Method string
+	Path   string
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Message is the error reported by the backend, if any.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s %s failed with status %d", e.Method, e.Path, e.StatusCode)
+	}
+	return fmt.Sprintf("%s %s failed with status %d: %s", e.Method, e.Path, e.StatusCode, e.Message)
+}
+
+// Is makes errors.Is(err, NotFoundError) match responses with status 404.
+func (e *APIError) Is(target error) bool {
+	return target == NotFoundError && e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound reports whether the error indicates that the requested object does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, NotFoundError)
+}
+
+// newAPIError reads the message of an error response, which is either a FastAPI error
+// or an APIResponse with a false status. The body is closed.
+func newAPIError(method, path string, resp *http.Response) *APIError {
+	defer resp.Body.Close()
+	apiErr := &APIError{
+		Method:     method,
+		Path:       path,
+		StatusCode: resp.StatusCode,
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return apiErr
+	}
+	var body struct {
+		Detail  interface{} `json:"detail"`
+		Message string      `json:"message"`
+	}
+	if err := json.Unmarshal(b, &body); err != nil {
+		apiErr.Message = strings.TrimSpace(string(b))
+		return apiErr
+	}
+	switch detail := body.Detail.(type) {
+	case nil:
+		apiErr.Message = body.Message
+	case string:
+		apiErr.Message = detail
+	default:
+		// validation errors are reported as a list of objects
+		detailBytes, _ := json.Marshal(detail)
+		apiErr.Message = string(detailBytes)
+	}
+	return apiErr
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"context"
+
 	"github.com/kagent-dev/kagent/go/autogen/api"
 )
 
@@ -15,17 +17,17 @@ type InvokeTaskResult struct {
 	Usage      string     `json:"usage"`
 }
 
-func (c *client) InvokeTask(req *InvokeTaskRequest) (*InvokeTaskResult, error) {
+func (c *client) InvokeTask(ctx context.Context, req *InvokeTaskRequest) (*InvokeTaskResult, error) {
 	var invoke InvokeTaskResult
-	err := c.doRequest("POST", "/invoke", req, &invoke)
+	err := c.doRequest(ctx, "POST", "/invoke", req, &invoke)
 	return &invoke, err
 }
 
-func (c *client) InvokeTaskStream(req *InvokeTaskRequest) (<-chan *SseEvent, error) {
-	resp, err := c.startRequest("POST", "/invoke/stream", req)
+func (c *client) InvokeTaskStream(ctx context.Context, req *InvokeTaskRequest) (<-chan *SseEvent, error) {
+	resp, err := c.startRequest(ctx, "POST", "/invoke/stream", req)
 	if err != nil {
 		return nil, err
 	}
-	ch := streamSseResponse(resp.Body)
+	ch := streamSseResponse(ctx, resp.Body, c.maxEventSize)
 	return ch, nil
 }
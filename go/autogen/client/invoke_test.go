@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvokeTaskStreamCancel(t *testing.T) {
+	disconnected := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: event\ndata: {\"type\":\"TextMessage\"}\n\n")
+		w.(http.Flusher).Flush()
+
+		// the run only stops when the client goes away
+		<-r.Context().Done()
+		close(disconnected)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := New(ts.URL).InvokeTaskStream(ctx, &InvokeTaskRequest{Task: "hello"})
+	require.NoError(t, err)
+
+	event := <-events
+	require.NotNil(t, event)
+	assert.Equal(t, "event", event.Event)
+	assert.JSONEq(t, `{"type":"TextMessage"}`, string(event.Data))
+
+	cancel()
+
+	select {
+	case <-disconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request to the backend was not aborted")
+	}
+	for range events {
+	}
+}
@@ -1,7 +1,9 @@
 package client
 
-func (c *client) ListSupportedModels() (*ProviderModels, error) {
+import "context"
+
+func (c *client) ListSupportedModels(ctx context.Context) (*ProviderModels, error) {
 	var models ProviderModels
-	err := c.doRequest("GET", "/models", nil, &models)
+	err := c.doRequest(ctx, "GET", "/models", nil, &models)
 	return &models, err
 }
@@ -1,28 +1,29 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/google/uuid"
 )
 
-func (c *client) CreateRun(req *CreateRunRequest) (*CreateRunResult, error) {
+func (c *client) CreateRun(ctx context.Context, req *CreateRunRequest) (*CreateRunResult, error) {
 	var run CreateRunResult
-	err := c.doRequest("POST", "/runs", req, &run)
+	err := c.doRequest(ctx, "POST", "/runs", req, &run)
 	return &run, err
 }
 
-func (c *client) GetRun(runID int) (*Run, error) {
+func (c *client) GetRun(ctx context.Context, runID int) (*Run, error) {
 
 	var run Run
-	err := c.doRequest("GET", fmt.Sprintf("/runs/%d", runID), nil, &run)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/runs/%d", runID), nil, &run)
 	return &run, err
 }
 
-func (c *client) ListRuns(userID string) ([]*Run, error) {
+func (c *client) ListRuns(ctx context.Context, userID string) ([]*Run, error) {
 	// Go through all sessions and then retrieve all runs for each session
 	var sessions []Session
-	err := c.doRequest("GET", fmt.Sprintf("/sessions/?user_id=%s", userID), nil, &sessions)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/sessions/?user_id=%s", userID), nil, &sessions)
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +31,7 @@ func (c *client) ListRuns(userID string) ([]*Run, error) {
 	// For each session, get the run information
 	var runs []*Run
 	for _, session := range sessions {
-		sessionRuns, err := c.ListSessionRuns(session.ID, userID)
+		sessionRuns, err := c.ListSessionRuns(ctx, session.ID, userID)
 		if err != nil {
 			return nil, err
 		}
@@ -39,12 +40,12 @@ func (c *client) ListRuns(userID string) ([]*Run, error) {
 	return runs, nil
 }
 
-func (c *client) GetRunMessages(runID uuid.UUID) ([]*RunMessage, error) {
+func (c *client) GetRunMessages(ctx context.Context, runID uuid.UUID) ([]*RunMessage, error) {
 	var messages []*RunMessage
-	err := c.doRequest("GET", fmt.Sprintf("/runs/%s/messages", runID), nil, &messages)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/runs/%s/messages", runID), nil, &messages)
 	return messages, err
 }
 
-func (c *client) DeleteRun(runID uuid.UUID) error {
-	return c.doRequest("DELETE", fmt.Sprintf("/runs/%s", runID), nil, nil)
+func (c *client) DeleteRun(ctx context.Context, runID uuid.UUID) error {
+	return c.doRequest(ctx, "DELETE", fmt.Sprintf("/runs/%s", runID), nil, nil)
 }
@@ -1,29 +1,30 @@
 package client
 
 import (
+	"context"
 	"fmt"
 )
 
-func (c *client) ListSessions(userID string) ([]*Session, error) {
+func (c *client) ListSessions(ctx context.Context, userID string) ([]*Session, error) {
 	var sessions []*Session
-	err := c.doRequest("GET", fmt.Sprintf("/sessions/?user_id=%s", userID), nil, &sessions)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/sessions/?user_id=%s", userID), nil, &sessions)
 	return sessions, err
 }
 
-func (c *client) CreateSession(session *CreateSession) (*Session, error) {
+func (c *client) CreateSession(ctx context.Context, session *CreateSession) (*Session, error) {
 	var result Session
-	err := c.doRequest("POST", "/sessions/", session, &result)
+	err := c.doRequest(ctx, "POST", "/sessions/", session, &result)
 	return &result, err
 }
 
-func (c *client) GetSessionById(sessionID int, userID string) (*Session, error) {
+func (c *client) GetSessionById(ctx context.Context, sessionID int, userID string) (*Session, error) {
 	var session Session
-	err := c.doRequest("GET", fmt.Sprintf("/sessions/%d?user_id=%s", sessionID, userID), nil, &session)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/sessions/%d?user_id=%s", sessionID, userID), nil, &session)
 	return &session, err
 }
 
-func (c *client) GetSession(sessionLabel string, userID string) (*Session, error) {
-	allSessions, err := c.ListSessions(userID)
+func (c *client) GetSession(ctx context.Context, sessionLabel string, userID string) (*Session, error) {
+	allSessions, err := c.ListSessions(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -37,32 +38,32 @@ func (c *client) GetSession(sessionLabel string, userID string) (*Session, error
 	return nil, NotFoundError
 }
 
-func (c *client) InvokeSession(sessionID int, userID string, task string) (*TeamResult, error) {
+func (c *client) InvokeSession(ctx context.Context, sessionID int, userID string, task string) (*TeamResult, error) {
 	var result TeamResult
-	err := c.doRequest("POST", fmt.Sprintf("/sessions/%d/invoke?user_id=%s", sessionID, userID), struct {
+	err := c.doRequest(ctx, "POST", fmt.Sprintf("/sessions/%d/invoke?user_id=%s", sessionID, userID), struct {
 		Task string `json:"task"`
 	}{Task: task}, &result)
 	return &result, err
 }
 
-func (c *client) InvokeSessionStream(sessionID int, userID string, task string) (<-chan *SseEvent, error) {
-	resp, err := c.startRequest("POST", fmt.Sprintf("/sessions/%d/invoke/stream?user_id=%s", sessionID, userID), struct {
+func (c *client) InvokeSessionStream(ctx context.Context, sessionID int, userID string, task string) (<-chan *SseEvent, error) {
+	resp, err := c.startRequest(ctx, "POST", fmt.Sprintf("/sessions/%d/invoke/stream?user_id=%s", sessionID, userID), struct {
 		Task string `json:"task"`
 	}{Task: task})
 	if err != nil {
 		return nil, err
 	}
-	ch := streamSseResponse(resp.Body)
+	ch := streamSseResponse(ctx, resp.Body, c.maxEventSize)
 	return ch, nil
 }
 
-func (c *client) DeleteSession(sessionID int, userID string) error {
-	return c.doRequest("DELETE", fmt.Sprintf("/sessions/%d?user_id=%s", sessionID, userID), nil, nil)
+func (c *client) DeleteSession(ctx context.Context, sessionID int, userID string) error {
+	return c.doRequest(ctx, "DELETE", fmt.Sprintf("/sessions/%d?user_id=%s", sessionID, userID), nil, nil)
 }
 
-func (c *client) ListSessionRuns(sessionID int, userID string) ([]*Run, error) {
+func (c *client) ListSessionRuns(ctx context.Context, sessionID int, userID string) ([]*Run, error) {
 	var runs SessionRuns
-	err := c.doRequest("GET", fmt.Sprintf("/sessions/%d/runs/?user_id=%s", sessionID, userID), nil, &runs)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/sessions/%d/runs/?user_id=%s", sessionID, userID), nil, &runs)
 	if err != nil {
 		return nil, err
 	}
@@ -74,8 +75,8 @@ func (c *client) ListSessionRuns(sessionID int, userID string) ([]*Run, error) {
 	return result, err
 }
 
-func (c *client) UpdateSession(sessionID int, userID string, session *Session) (*Session, error) {
+func (c *client) UpdateSession(ctx context.Context, sessionID int, userID string, session *Session) (*Session, error) {
 	var updatedSession Session
-	err := c.doRequest("PUT", fmt.Sprintf("/sessions/%d?user_id=%s", sessionID, userID), session, &updatedSession)
+	err := c.doRequest(ctx, "PUT", fmt.Sprintf("/sessions/%d?user_id=%s", sessionID, userID), session, &updatedSession)
 	return &updatedSession, err
 }
@@ -0,0 +1,221 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	// SseErrorEvent is the type of the event which ends a stream that failed.
+	SseErrorEvent = "error"
+
+	defaultSseEventType  = "message"
+	sseInitialBufferSize = 64 * 1024
+)
+
+// ErrEventTooLarge is returned when an event exceeds the maximum event size of the decoder.
+var ErrEventTooLarge = errors.New("event exceeds the maximum event size")
+
+type SseEvent struct {
+	// ID is the last event ID sent by the server, which applies to all following events.
+	ID    string `json:"id,omitempty"`
+	Event string `json:"event"`
+	// Data holds the data lines of the event, joined by newlines.
+	Data []byte `json:"data"`
+	// Retry is the reconnection time last requested by the server, if any.
+	Retry time.Duration `json:"retry,omitempty"`
+}
+
+// Encode returns the event in the wire format, e.g. to forward it to another client.
+func (e *SseEvent) Encode() []byte {
+	var buf bytes.Buffer
+	if e.ID != "" {
+		fmt.Fprintf(&buf, "id: %s\n", e.ID)
+	}
+	if e.Event != "" {
+		fmt.Fprintf(&buf, "event: %s\n", e.Event)
+	}
+	if e.Retry > 0 {
+		fmt.Fprintf(&buf, "retry: %d\n", e.Retry.Milliseconds())
+	}
+	for _, line := range bytes.Split(e.Data, []byte("\n")) {
+		fmt.Fprintf(&buf, "data: %s\n", line)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// SseDecoder reads server-sent events as specified in
+// https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+type SseDecoder struct {
+	scanner      *bufio.Scanner
+	maxEventSize int
+	started      bool
+	lastEventID  string
+	retry        time.Duration
+}
+
+// NewSseDecoder returns a decoder which fails with ErrEventTooLarge on events larger than maxEventSize.
+// If maxEventSize is not positive, DefaultMaxEventSize is used.
+func NewSseDecoder(r io.Reader, maxEventSize int) *SseDecoder {
+	if maxEventSize <= 0 {
+		maxEventSize = DefaultMaxEventSize
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, min(sseInitialBufferSize, maxEventSize)), maxEventSize)
+	scanner.Split(scanSseLines)
+	return &SseDecoder{
+		scanner:      scanner,
+		maxEventSize: maxEventSize,
+	}
+}
+
+// Next returns the next event of the stream, or io.EOF once the stream has ended.
+// An incomplete event at the end of the stream is discarded.
+func (d *SseDecoder) Next() (*SseEvent, error) {
+	var (
+		eventType string
+		data      bytes.Buffer
+		hasData   bool
+	)
+	for d.scanner.Scan() {
+		line := d.scanner.Bytes()
+		if !d.started {
+			d.started = true
+			line = bytes.TrimPrefix(line, []byte("\ufeff"))
+		}
+
+		if len(line) == 0 {
+			if !hasData {
+				eventType = ""
+				continue
+			}
+			if eventType == "" {
+				eventType = defaultSseEventType
+			}
+			return &SseEvent{
+				ID:    d.lastEventID,
+				Event: eventType,
+				Data:  data.Bytes(),
+				Retry: d.retry,
+			}, nil
+		}
+
+		// comment
+		if line[0] == ':' {
+			continue
+		}
+
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		value = bytes.TrimPrefix(value, []byte(" "))
+		switch string(field) {
+		case "event":
+			eventType = string(value)
+		case "data":
+			if data.Len()+len(value)+1 > d.maxEventSize {
+				return nil, ErrEventTooLarge
+			}
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.Write(value)
+			hasData = true
+		case "id":
+			if bytes.IndexByte(value, 0) < 0 {
+				d.lastEventID = string(value)
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(string(value), 10, 32); err == nil {
+				d.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, ErrEventTooLarge
+		}
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// scanSseLines splits the stream into lines ending with CRLF, LF or CR.
+func scanSseLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+			return i + 1, data[:i], nil
+		}
+		// a CR at the end of the buffer may be followed by a LF
+		if atEOF {
+			return i + 1, data[:i], nil
+		}
+		return 0, nil, nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// streamSseResponse forwards the events of the response until it ends or the context is canceled.
+// Canceling the context closes the connection, which stops the run in the backend.
+// A stream which cannot be read to the end is ended with an error event in the format used by the backend.
+func streamSseResponse(ctx context.Context, r io.ReadCloser, maxEventSize int) chan *SseEvent {
+	ch := make(chan *SseEvent)
+	go func() {
+		defer close(ch)
+		defer r.Close()
+		decoder := NewSseDecoder(r, maxEventSize)
+		for {
+			event, err := decoder.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				event = newSseErrorEvent(err)
+			}
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func newSseErrorEvent(err error) *SseEvent {
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": SseErrorEvent,
+		"data": map[string]string{
+			"message": fmt.Sprintf("failed to read event stream: %v", err),
+		},
+	})
+	return &SseEvent{
+		Event: SseErrorEvent,
+		Data:  data,
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeAll(t *testing.T, stream string, maxEventSize int) ([]*SseEvent, error) {
+	t.Helper()
+	decoder := NewSseDecoder(strings.NewReader(stream), maxEventSize)
+	var events []*SseEvent
+	for {
+		event, err := decoder.Next()
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return events, err
+		}
+		events = append(events, event)
+	}
+}
+
+func TestSseDecoder(t *testing.T) {
+	t.Run("should parse fields, comments and line endings", func(t *testing.T) {
+		stream := ": keep-alive\n" +
+			"id: 1\r\n" +
+			"retry: 3000\r\n" +
+			"event: task_result\r\n" +
+			"data: {\"a\":\r\n" +
+			"data:1}\r\n\r\n" +
+			"data: second\r\r" +
+			"event: ignored\n\n" +
+			"data: incomplete"
+
+		events, err := decodeAll(t, stream, 0)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		assert.Equal(t, &SseEvent{
+			ID:    "1",
+			Event: "task_result",
+			Data:  []byte("{\"a\":\n1}"),
+			Retry: 3 * time.Second,
+		}, events[0])
+		// the event id and the retry time carry over to following events
+		assert.Equal(t, &SseEvent{
+			ID:    "1",
+			Event: "message",
+			Data:  []byte("second"),
+			Retry: 3 * time.Second,
+		}, events[1])
+	})
+
+	t.Run("should decode events larger than the default scanner buffer", func(t *testing.T) {
+		large := strings.Repeat("x", 1024*1024)
+		events, err := decodeAll(t, "event: event\ndata: "+large+"\n\n", 0)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, large, string(events[0].Data))
+	})
+
+	t.Run("should fail on events larger than the maximum size", func(t *testing.T) {
+		_, err := decodeAll(t, "data: "+strings.Repeat("x", 100)+"\n\n", 64)
+		assert.ErrorIs(t, err, ErrEventTooLarge)
+
+		_, err = decodeAll(t, strings.Repeat("data: xxxxxxxxxx\n", 10)+"\n", 64)
+		assert.ErrorIs(t, err, ErrEventTooLarge)
+	})
+
+	t.Run("should round trip encoded events", func(t *testing.T) {
+		event := &SseEvent{ID: "7", Event: "event", Data: []byte("line 1\nline 2")}
+		events, err := decodeAll(t, string(event.Encode()), 0)
+		require.NoError(t, err)
+		assert.Equal(t, []*SseEvent{event}, events)
+	})
+}
+
+func TestStreamSseResponseEndsWithErrorEvent(t *testing.T) {
+	body := io.NopCloser(strings.NewReader("event: event\ndata: {}\n\ndata: " + strings.Repeat("x", 100) + "\n\n"))
+	var events []*SseEvent
+	for event := range streamSseResponse(context.Background(), body, 64) {
+		events = append(events, event)
+	}
+	require.Len(t, events, 2)
+	assert.Equal(t, "event", events[0].Event)
+	assert.Equal(t, SseErrorEvent, events[1].Event)
+	assert.Contains(t, string(events[1].Data), ErrEventTooLarge.Error())
+}
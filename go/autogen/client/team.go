@@ -1,27 +1,28 @@
 package client
 
 import (
+	"context"
 	"fmt"
 )
 
-func (c *client) ListTeams(userID string) ([]*Team, error) {
+func (c *client) ListTeams(ctx context.Context, userID string) ([]*Team, error) {
 	var teams []*Team
-	err := c.doRequest("GET", fmt.Sprintf("/teams/?user_id=%s", userID), nil, &teams)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/teams/?user_id=%s", userID), nil, &teams)
 	return teams, err
 }
 
-func (c *client) CreateTeam(team *Team) error {
-	return c.doRequest("POST", "/teams/", team, team)
+func (c *client) CreateTeam(ctx context.Context, team *Team) error {
+	return c.doRequest(ctx, "POST", "/teams/", team, team)
 }
 
-func (c *client) GetTeamByID(teamID int, userID string) (*Team, error) {
+func (c *client) GetTeamByID(ctx context.Context, teamID int, userID string) (*Team, error) {
 	var team *Team
-	err := c.doRequest("GET", fmt.Sprintf("/teams/%d?user_id=%s", teamID, userID), nil, &team)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/teams/%d?user_id=%s", teamID, userID), nil, &team)
 	return team, err
 }
 
-func (c *client) GetTeam(teamLabel string, userID string) (*Team, error) {
-	allTeams, err := c.ListTeams(userID)
+func (c *client) GetTeam(ctx context.Context, teamLabel string, userID string) (*Team, error) {
+	allTeams, err := c.ListTeams(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -35,6 +36,6 @@ func (c *client) GetTeam(teamLabel string, userID string) (*Team, error) {
 	return nil, nil
 }
 
-func (c *client) DeleteTeam(teamID int, userID string) error {
-	return c.doRequest("DELETE", fmt.Sprintf("/teams/%d?user_id=%s", teamID, userID), nil, nil)
+func (c *client) DeleteTeam(ctx context.Context, teamID int, userID string) error {
+	return c.doRequest(ctx, "DELETE", fmt.Sprintf("/teams/%d?user_id=%s", teamID, userID), nil, nil)
 }
@@ -1,17 +1,18 @@
 package client
 
 import (
+	"context"
 	"fmt"
 )
 
-func (c *client) ListTools(userID string) ([]*Tool, error) {
+func (c *client) ListTools(ctx context.Context, userID string) ([]*Tool, error) {
 	var tools []*Tool
-	err := c.doRequest("GET", fmt.Sprintf("/tools/?user_id=%s", userID), nil, &tools)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/tools/?user_id=%s", userID), nil, &tools)
 	return tools, err
 }
 
-func (c *client) GetTool(provider string, userID string) (*Tool, error) {
-	allTools, err := c.ListTools(userID)
+func (c *client) GetTool(ctx context.Context, provider string, userID string) (*Tool, error) {
+	allTools, err := c.ListTools(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -1,12 +1,14 @@
 package client
 
 import (
+	"context"
 	"fmt"
 )
 
-func (c *client) CreateToolServer(toolServer *ToolServer, userID string) (*ToolServer, error) {
+func (c *client) CreateToolServer(ctx context.Context, toolServer *ToolServer, userID string) (*ToolServer, error) {
 	var server ToolServer
 	err := c.doRequest(
+		ctx,
 		"POST",
 		fmt.Sprintf("/toolservers/?user_id=%s", userID),
 		toolServer,
@@ -15,20 +17,20 @@ func (c *client) CreateToolServer(toolServer *ToolServer, userID string) (*ToolS
 	return &server, err
 }
 
-func (c *client) ListToolServers(userID string) ([]*ToolServer, error) {
+func (c *client) ListToolServers(ctx context.Context, userID string) ([]*ToolServer, error) {
 	var toolServers []*ToolServer
-	err := c.doRequest("GET", fmt.Sprintf("/toolservers/?user_id=%s", userID), nil, &toolServers)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/toolservers/?user_id=%s", userID), nil, &toolServers)
 	return toolServers, err
 }
 
-func (c *client) GetToolServer(serverID int, userID string) (*ToolServer, error) {
+func (c *client) GetToolServer(ctx context.Context, serverID int, userID string) (*ToolServer, error) {
 	var toolServer *ToolServer
-	err := c.doRequest("GET", fmt.Sprintf("/toolservers/%d?user_id=%s", serverID, userID), nil, &toolServer)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/toolservers/%d?user_id=%s", serverID, userID), nil, &toolServer)
 	return toolServer, err
 }
 
-func (c *client) GetToolServerByLabel(toolServerLabel, userID string) (*ToolServer, error) {
-	allServers, err := c.ListToolServers(userID)
+func (c *client) GetToolServerByLabel(ctx context.Context, toolServerLabel, userID string) (*ToolServer, error) {
+	allServers, err := c.ListToolServers(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -39,26 +41,27 @@ func (c *client) GetToolServerByLabel(toolServerLabel, userID string) (*ToolServ
 		}
 	}
 
-	return nil, fmt.Errorf("tool server with label %s not found", toolServerLabel)
+	return nil, fmt.Errorf("tool server with label %s: %w", toolServerLabel, NotFoundError)
 }
 
-func (c *client) DeleteToolServer(serverID *int, userID string) error {
-	return c.doRequest("DELETE", fmt.Sprintf("/toolservers/%d?user_id=%s", *serverID, userID), nil, nil)
+func (c *client) DeleteToolServer(ctx context.Context, serverID *int, userID string) error {
+	return c.doRequest(ctx, "DELETE", fmt.Sprintf("/toolservers/%d?user_id=%s", *serverID, userID), nil, nil)
 }
 
-func (c *client) RefreshTools(serverID *int, userID string) error {
-	return c.doRequest("POST", fmt.Sprintf("/toolservers/%d/refresh?user_id=%s", *serverID, userID), nil, nil)
+func (c *client) RefreshTools(ctx context.Context, serverID *int, userID string) error {
+	return c.doRequest(ctx, "POST", fmt.Sprintf("/toolservers/%d/refresh?user_id=%s", *serverID, userID), nil, nil)
 }
 
-func (c *client) ListToolsForServer(serverID *int, userID string) ([]*Tool, error) {
+func (c *client) ListToolsForServer(ctx context.Context, serverID *int, userID string) ([]*Tool, error) {
 	var tools []*Tool
-	err := c.doRequest("GET", fmt.Sprintf("/toolservers/%d/tools?user_id=%s", *serverID, userID), nil, &tools)
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/toolservers/%d/tools?user_id=%s", *serverID, userID), nil, &tools)
 	return tools, err
 }
 
 // RefreshToolServer refreshes tools for a specific server
-func (c *client) RefreshToolServer(serverID int, userID string) error {
+func (c *client) RefreshToolServer(ctx context.Context, serverID int, userID string) error {
 	return c.doRequest(
+		ctx,
 		"POST",
 		fmt.Sprintf("/toolservers/%d/refresh?user_id=%s", serverID, userID),
 		nil,
@@ -67,8 +70,8 @@ func (c *client) RefreshToolServer(serverID int, userID string) error {
 }
 
 // CreateToolServer creates a new server
-func (c *client) UpdateToolServer(server *ToolServer, userID string) error {
-	return c.doRequest("PUT", fmt.Sprintf(
+func (c *client) UpdateToolServer(ctx context.Context, server *ToolServer, userID string) error {
+	return c.doRequest(ctx, "PUT", fmt.Sprintf(
 		"/toolservers/%v?user_id=%s",
 		server.Id,
 		userID,
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/kagent-dev/kagent/go/autogen/api"
@@ -40,8 +41,8 @@ func (r ValidationResponse) ErrorMsg() string {
 	return msg
 }
 
-func (c *client) Validate(req *ValidationRequest) (*ValidationResponse, error) {
+func (c *client) Validate(ctx context.Context, req *ValidationRequest) (*ValidationResponse, error) {
 	var resp ValidationResponse
-	err := c.doRequest("POST", "/validate", req, &resp)
+	err := c.doRequest(ctx, "POST", "/validate", req, &resp)
 	return &resp, err
 }
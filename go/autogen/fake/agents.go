@@ -0,0 +1,138 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+)
+
+const defaultStopReason = "Maximum number of turns reached."
+
+// Agent computes the reply of a team to a task. It is called with the context of the request,
+// which is canceled when the client disconnects, and must return once it is done.
+type Agent func(ctx context.Context, task string) (*Reply, error)
+
+// Reply is the scripted reply of a team to a task.
+type Reply struct {
+	// Events are streamed before the messages, but are not part of the task result,
+	// e.g. streaming chunks of the model output.
+	Events []autogen_client.TaskMessageMap
+	// Messages are streamed after the events and returned in the task result, following the task.
+	Messages []autogen_client.TaskMessageMap
+	// StopReason is the reason the team stopped, a default reason is used if empty.
+	StopReason string
+}
+
+// TextReply returns a reply consisting of a single text message.
+func TextReply(source, content string) *Reply {
+	return &Reply{
+		Messages: []autogen_client.TaskMessageMap{TextMessage(source, content)},
+	}
+}
+
+// Echo returns an agent which replies with the task.
+func Echo(source string) Agent {
+	return func(ctx context.Context, task string) (*Reply, error) {
+		return TextReply(source, task), nil
+	}
+}
+
+// Script returns an agent which returns the given replies in order, one per task.
+// Tasks after the last reply fail.
+func Script(replies ...*Reply) Agent {
+	var mu sync.Mutex
+	next := 0
+	return func(ctx context.Context, task string) (*Reply, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if next >= len(replies) {
+			return nil, fmt.Errorf("no scripted reply left for task %q", task)
+		}
+		reply := replies[next]
+		next++
+		return reply, nil
+	}
+}
+
+// Block returns an agent which only returns once the run is canceled, e.g. to test cancellation.
+func Block() Agent {
+	return func(ctx context.Context, task string) (*Reply, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+}
+
+// TextMessage returns a TextMessage as sent by autogen.
+func TextMessage(source, content string) autogen_client.TaskMessageMap {
+	return autogen_client.TaskMessageMap{
+		"type":         "TextMessage",
+		"source":       source,
+		"content":      content,
+		"models_usage": nil,
+		"metadata":     map[string]interface{}{},
+	}
+}
+
+// StreamingChunk returns a ModelClientStreamingChunkEvent as sent by autogen.
+func StreamingChunk(source, content string) autogen_client.TaskMessageMap {
+	return autogen_client.TaskMessageMap{
+		"type":         "ModelClientStreamingChunkEvent",
+		"source":       source,
+		"content":      content,
+		"models_usage": nil,
+		"metadata":     map[string]interface{}{},
+	}
+}
+
+// ToolCallRequest returns a ToolCallRequestEvent of a single tool call as sent by autogen.
+func ToolCallRequest(source, callID, name, arguments string) autogen_client.TaskMessageMap {
+	return autogen_client.TaskMessageMap{
+		"type":   "ToolCallRequestEvent",
+		"source": source,
+		"content": []interface{}{
+			map[string]interface{}{
+				"id":        callID,
+				"name":      name,
+				"arguments": arguments,
+			},
+		},
+		"models_usage": nil,
+		"metadata":     map[string]interface{}{},
+	}
+}
+
+// ToolCallExecution returns a ToolCallExecutionEvent of a single tool call as sent by autogen.
+func ToolCallExecution(source, callID, name, content string) autogen_client.TaskMessageMap {
+	return autogen_client.TaskMessageMap{
+		"type":   "ToolCallExecutionEvent",
+		"source": source,
+		"content": []interface{}{
+			map[string]interface{}{
+				"call_id":  callID,
+				"name":     name,
+				"content":  content,
+				"is_error": false,
+			},
+		},
+		"models_usage": nil,
+		"metadata":     map[string]interface{}{},
+	}
+}
+
+// teamResult returns the result of the team for the given task.
+func (r *Reply) teamResult(task string, duration float64) *autogen_client.TeamResult {
+	stopReason := r.StopReason
+	if stopReason == "" {
+		stopReason = defaultStopReason
+	}
+	messages := append([]autogen_client.TaskMessageMap{TextMessage("user", task)}, r.Messages...)
+	return &autogen_client.TeamResult{
+		TaskResult: autogen_client.TaskResult{
+			Messages:   messages,
+			StopReason: stopReason,
+		},
+		Duration: duration,
+	}
+}
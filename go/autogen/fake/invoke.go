@@ -0,0 +1,245 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+)
+
+const (
+	taskResultEvent = "task_result"
+	messageEvent    = "event"
+	completionEvent = "completion"
+)
+
+type invokeSessionRequest struct {
+	Task string `json:"task"`
+}
+
+func (s *Server) handleInvoke(w http.ResponseWriter, r *http.Request) {
+	var req autogen_client.InvokeTaskRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	result, _, err := s.runAgent(r.Context(), req.TeamConfig, req.Task)
+	if err != nil {
+		writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: false, Message: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: true, Message: "Task successfully completed", Data: result})
+}
+
+func (s *Server) handleInvokeStream(w http.ResponseWriter, r *http.Request) {
+	var req autogen_client.InvokeTaskRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	startStream(w)
+	result, messages, err := s.runAgent(r.Context(), req.TeamConfig, req.Task)
+	if r.Context().Err() != nil {
+		return
+	}
+	if err != nil {
+		// errors of task streams are sent as events without a type
+		writeEvent(w, "", errorPayload(err))
+		return
+	}
+	for _, message := range messages {
+		writeEvent(w, messageEvent, message)
+	}
+	writeEvent(w, taskResultEvent, result)
+}
+
+func (s *Server) handleInvokeSession(w http.ResponseWriter, r *http.Request) {
+	var req invokeSessionRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	runID, team, ok := s.startSessionRun(w, r, req.Task)
+	if !ok {
+		return
+	}
+
+	result, _, err := s.runAgent(r.Context(), team.Component, req.Task)
+	switch {
+	case r.Context().Err() != nil:
+		s.finishRun(runID, runStatusStopped, nil, "")
+		writeError(w, 499, "Client disconnected")
+	case err != nil:
+		s.finishRun(runID, runStatusError, nil, err.Error())
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Internal server error while invoking run: %v", err))
+	default:
+		s.finishRun(runID, runStatusComplete, result, "")
+		writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: true, Message: "Run executed successfully", Data: result})
+	}
+}
+
+func (s *Server) handleInvokeSessionStream(w http.ResponseWriter, r *http.Request) {
+	var req invokeSessionRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	runID, team, ok := s.startSessionRun(w, r, req.Task)
+	if !ok {
+		return
+	}
+
+	startStream(w)
+	result, messages, err := s.runAgent(r.Context(), team.Component, req.Task)
+	switch {
+	case r.Context().Err() != nil:
+		s.finishRun(runID, runStatusStopped, nil, "")
+		return
+	case err != nil:
+		s.finishRun(runID, runStatusError, nil, err.Error())
+		writeEvent(w, messageEvent, errorPayload(err))
+	default:
+		for _, message := range messages {
+			writeEvent(w, messageEvent, message)
+		}
+		s.finishRun(runID, runStatusComplete, result, "")
+		writeEvent(w, taskResultEvent, result)
+	}
+	writeEvent(w, completionEvent, map[string]interface{}{
+		"type":   completionEvent,
+		"status": "success",
+		"data":   nil,
+	})
+}
+
+// startSessionRun records a new active run of the session of the request and returns the team of the session.
+func (s *Server) startSessionRun(w http.ResponseWriter, r *http.Request, task string) (int, *autogen_client.Team, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.getSession(w, r)
+	if !ok {
+		return 0, nil, false
+	}
+	team, ok := s.teams[session.TeamID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return 0, nil, false
+	}
+	run := s.createRun(session.ID, &task)
+	run.Status = runStatusActive
+	return run.ID, team, true
+}
+
+// runAgent lets the agent of the team reply to the task. It returns the result of the team
+// and the messages streamed by autogen, starting with the task.
+func (s *Server) runAgent(
+	ctx context.Context,
+	team *api.Component,
+	task string,
+) (*autogen_client.TeamResult, []autogen_client.TaskMessageMap, error) {
+	s.mu.Lock()
+	agent := s.defaultAgent
+	if team != nil {
+		if teamAgent, ok := s.agents[team.Label]; ok {
+			agent = teamAgent
+		}
+	}
+	s.mu.Unlock()
+
+	start := time.Now()
+	reply, err := agent(ctx, task)
+	if err != nil {
+		return nil, nil, err
+	}
+	if reply == nil {
+		reply = &Reply{}
+	}
+
+	result := reply.teamResult(task, time.Since(start).Seconds())
+	messages := []autogen_client.TaskMessageMap{result.TaskResult.Messages[0]}
+	messages = append(messages, reply.Events...)
+	messages = append(messages, reply.Messages...)
+	return result, messages, nil
+}
+
+func errorPayload(err error) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "error",
+		"data": map[string]string{
+			"message": err.Error(),
+			"details": fmt.Sprintf("%T", err),
+		},
+	}
+}
+
+// startStream sends the headers of an event stream, so that clients can start reading events.
+func startStream(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event string, data interface{}) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		b, _ = json.Marshal(errorPayload(err))
+	}
+	w.Write((&autogen_client.SseEvent{Event: event, Data: b}).Encode()) //nolint:errcheck
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	var req autogen_client.ValidationRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	validate := s.validate
+	s.mu.Unlock()
+
+	resp := &autogen_client.ValidationResponse{IsValid: true}
+	if validate != nil {
+		resp = validate(req.Component)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleListModels(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.models)
+}
+
+func (s *Server) handleListFeedback(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	feedback := []*autogen_client.FeedbackSubmission{}
+	for _, f := range s.feedback {
+		if f.UserID == userID(r) {
+			feedback = append(feedback, f)
+		}
+	}
+	writeData(w, feedback)
+}
+
+func (s *Server) handleCreateFeedback(w http.ResponseWriter, r *http.Request) {
+	var feedback autogen_client.FeedbackSubmission
+	if !decodeBody(w, r, &feedback) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	feedback.ID = s.nextID()
+	feedback.CreatedAt = now()
+	feedback.UpdatedAt = feedback.CreatedAt
+	s.feedback = append(s.feedback, &feedback)
+	writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: true, Message: "Feedback submitted successfully", Data: feedback})
+}
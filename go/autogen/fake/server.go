@@ -0,0 +1,257 @@
+// Package fake implements the HTTP API of the autogen backend in memory, so that the controller,
+// the HTTP server, the A2A handlers and the CLI can be tested without running the Python engine.
+//
+// Teams reply to tasks with scripted agents, which are selected by the label of the team:
+//
+//	backend := fake.NewServer()
+//	defer backend.Close()
+//	backend.SetAgent("k8s-agent", fake.Script(fake.TextReply("k8s_agent", "done")))
+//	client := backend.Client()
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+)
+
+const (
+	// APIPrefix is the path the API is served under, as by the autogen backend.
+	APIPrefix = "/api"
+	// Version is the version reported by the fake backend.
+	Version = "0.0.0-fake"
+
+	runStatusCreated  = "created"
+	runStatusActive   = "active"
+	runStatusComplete = "complete"
+	runStatusError    = "error"
+	runStatusStopped  = "stopped"
+
+	stdioMcpToolAdapter = "autogen_ext.tools.mcp.StdioMcpToolAdapter"
+	sseMcpToolAdapter   = "autogen_ext.tools.mcp.SseMcpToolAdapter"
+)
+
+// Server is a fake autogen backend running on an httptest.Server.
+type Server struct {
+	// URL is the base URL of the API, which is passed to autogen_client.New.
+	URL string
+
+	server *httptest.Server
+
+	mu           sync.Mutex
+	lastID       int
+	teams        map[int]*autogen_client.Team
+	sessions     map[int]*autogen_client.Session
+	runs         map[int]*autogen_client.Run
+	toolServers  map[int]*autogen_client.ToolServer
+	tools        map[int]*autogen_client.Tool
+	feedback     []*autogen_client.FeedbackSubmission
+	agents       map[string]Agent
+	defaultAgent Agent
+	serverTools  map[string][]api.MCPTool
+	validate     func(*api.Component) *autogen_client.ValidationResponse
+	models       autogen_client.ProviderModels
+}
+
+// NewServer starts a fake backend, which must be closed by the caller.
+// Teams without an agent echo the task.
+func NewServer() *Server {
+	s := &Server{
+		teams:        map[int]*autogen_client.Team{},
+		sessions:     map[int]*autogen_client.Session{},
+		runs:         map[int]*autogen_client.Run{},
+		toolServers:  map[int]*autogen_client.ToolServer{},
+		tools:        map[int]*autogen_client.Tool{},
+		agents:       map[string]Agent{},
+		defaultAgent: Echo("assistant"),
+		serverTools:  map[string][]api.MCPTool{},
+		models: autogen_client.ProviderModels{
+			"openai": {{Name: "gpt-4o", FunctionCalling: true}},
+		},
+	}
+	s.server = httptest.NewServer(s.routes())
+	s.URL = s.server.URL + APIPrefix
+	return s
+}
+
+// Close shuts down the server, waiting for running requests to complete.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client of the fake backend.
+func (s *Server) Client(opts ...autogen_client.Option) autogen_client.Client {
+	return autogen_client.New(s.URL, opts...)
+}
+
+// SetAgent sets the agent which replies to tasks of the team with the given label.
+func (s *Server) SetAgent(teamLabel string, agent Agent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.agents[teamLabel] = agent
+}
+
+// SetDefaultAgent sets the agent which replies to tasks of teams without an agent.
+func (s *Server) SetDefaultAgent(agent Agent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultAgent = agent
+}
+
+// SetToolServerTools sets the tools discovered when the tool server with the given label is refreshed.
+func (s *Server) SetToolServerTools(serverLabel string, tools ...api.MCPTool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.serverTools[serverLabel] = tools
+}
+
+// SetValidator replaces the validation of components, which accepts all components by default.
+func (s *Server) SetValidator(validate func(*api.Component) *autogen_client.ValidationResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.validate = validate
+}
+
+// SetSupportedModels sets the models listed as supported.
+func (s *Server) SetSupportedModels(models autogen_client.ProviderModels) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.models = models
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	handle := func(pattern string, handler http.HandlerFunc) {
+		method, path, _ := strings.Cut(pattern, " ")
+		mux.HandleFunc(method+" "+APIPrefix+path, handler)
+	}
+
+	handle("GET /version", s.handleVersion)
+	handle("GET /health", s.handleHealth)
+
+	handle("GET /teams/{$}", s.handleListTeams)
+	handle("POST /teams/{$}", s.handleCreateTeam)
+	handle("GET /teams/{id}", s.handleGetTeam)
+	handle("DELETE /teams/{id}", s.handleDeleteTeam)
+
+	handle("GET /sessions/{$}", s.handleListSessions)
+	handle("POST /sessions/{$}", s.handleCreateSession)
+	handle("GET /sessions/{id}", s.handleGetSession)
+	handle("PUT /sessions/{id}", s.handleUpdateSession)
+	handle("DELETE /sessions/{id}", s.handleDeleteSession)
+	handle("GET /sessions/{id}/runs", s.handleListSessionRuns)
+	handle("GET /sessions/{id}/runs/{$}", s.handleListSessionRuns)
+	handle("POST /sessions/{id}/invoke", s.handleInvokeSession)
+	handle("POST /sessions/{id}/invoke/stream", s.handleInvokeSessionStream)
+
+	handle("POST /runs", s.handleCreateRun)
+	handle("POST /runs/{$}", s.handleCreateRun)
+	handle("GET /runs/{id}", s.handleGetRun)
+	handle("GET /runs/{id}/messages", s.handleGetRunMessages)
+	handle("DELETE /runs/{id}", s.handleDeleteRun)
+
+	handle("GET /toolservers/{$}", s.handleListToolServers)
+	handle("POST /toolservers/{$}", s.handleCreateToolServer)
+	handle("GET /toolservers/{id}", s.handleGetToolServer)
+	handle("PUT /toolservers/{id}", s.handleUpdateToolServer)
+	handle("DELETE /toolservers/{id}", s.handleDeleteToolServer)
+	handle("GET /toolservers/{id}/tools", s.handleListToolServerTools)
+	handle("POST /toolservers/{id}/refresh", s.handleRefreshToolServer)
+
+	handle("GET /tools/{$}", s.handleListTools)
+
+	handle("POST /validate", s.handleValidate)
+	handle("POST /validate/{$}", s.handleValidate)
+
+	handle("GET /models", s.handleListModels)
+	handle("GET /models/{$}", s.handleListModels)
+
+	handle("POST /invoke", s.handleInvoke)
+	handle("POST /invoke/{$}", s.handleInvoke)
+	handle("POST /invoke/stream", s.handleInvokeStream)
+
+	handle("GET /feedback/{$}", s.handleListFeedback)
+	handle("POST /feedback/{$}", s.handleCreateFeedback)
+
+	return mux
+}
+
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	writeData(w, map[string]string{"version": Version})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: true, Message: "Service is healthy"})
+}
+
+// nextID returns a new ID, s.mu must be held.
+func (s *Server) nextID() int {
+	s.lastID++
+	return s.lastID
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
+
+// writeData writes a successful response wrapped like the responses of the autogen backend.
+func writeData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: true, Data: data})
+}
+
+// writeError writes an error response like FastAPI does.
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+func pathID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid id %q", r.PathValue("id")))
+		return 0, false
+	}
+	return id, true
+}
+
+func userID(r *http.Request) string {
+	return r.URL.Query().Get("user_id")
+}
+
+// sortedValues returns the values of the map ordered by ID.
+func sortedValues[T any](m map[int]*T, keep func(*T) bool) []*T {
+	ids := make([]int, 0, len(m))
+	for id, v := range m {
+		if keep(v) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	values := make([]*T, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, m[id])
+	}
+	return values
+}
@@ -0,0 +1,148 @@
+package fake_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/autogen/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userID = "admin@kagent.dev"
+
+func createTeam(t *testing.T, client autogen_client.Client, label string) *autogen_client.Team {
+	t.Helper()
+	team := &autogen_client.Team{
+		BaseObject: autogen_client.BaseObject{UserID: userID},
+		Component:  &api.Component{Provider: "autogen_agentchat.teams.RoundRobinGroupChat", Label: label},
+	}
+	require.NoError(t, client.CreateTeam(context.Background(), team))
+	return team
+}
+
+func TestTeams(t *testing.T) {
+	backend := fake.NewServer()
+	defer backend.Close()
+	client := backend.Client()
+	ctx := context.Background()
+
+	team := createTeam(t, client, "k8s-agent")
+	require.NotZero(t, team.Id)
+
+	// creating a team with an ID updates it
+	team.Component.Description = "updated"
+	require.NoError(t, client.CreateTeam(ctx, team))
+	teams, err := client.ListTeams(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "updated", teams[0].Component.Description)
+
+	require.NoError(t, client.DeleteTeam(ctx, team.Id, userID))
+	_, err = client.GetTeamByID(ctx, team.Id, userID)
+	assert.True(t, autogen_client.IsNotFound(err))
+}
+
+func TestInvokeSessionStream(t *testing.T) {
+	backend := fake.NewServer()
+	defer backend.Close()
+	backend.SetAgent("k8s-agent", fake.Script(&fake.Reply{
+		Events: []autogen_client.TaskMessageMap{
+			fake.ToolCallRequest("k8s_agent", "1", "get_pods", "{}"),
+			fake.ToolCallExecution("k8s_agent", "1", "get_pods", "pod-a"),
+		},
+		Messages: []autogen_client.TaskMessageMap{fake.TextMessage("k8s_agent", "found pod-a")},
+	}))
+	client := backend.Client()
+	ctx := context.Background()
+
+	team := createTeam(t, client, "k8s-agent")
+	session, err := client.CreateSession(ctx, &autogen_client.CreateSession{UserID: userID, TeamID: team.Id, Name: "test"})
+	require.NoError(t, err)
+
+	events, err := client.InvokeSessionStream(ctx, session.ID, userID, "list pods")
+	require.NoError(t, err)
+	var types []string
+	var result autogen_client.TeamResult
+	for event := range events {
+		switch event.Event {
+		case "task_result":
+			require.NoError(t, json.Unmarshal(event.Data, &result))
+		case "event":
+			var message autogen_client.TaskMessageMap
+			require.NoError(t, json.Unmarshal(event.Data, &message))
+			types = append(types, message["type"].(string))
+		}
+	}
+	assert.Equal(t, []string{"TextMessage", "ToolCallRequestEvent", "ToolCallExecutionEvent", "TextMessage"}, types)
+	require.Len(t, result.TaskResult.Messages, 2)
+	assert.Equal(t, "found pod-a", result.TaskResult.Messages[1]["content"])
+
+	runs, err := client.ListSessionRuns(ctx, session.ID, userID)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "complete", runs[0].Status)
+	assert.Equal(t, "list pods", runs[0].Task.Content)
+	assert.Len(t, runs[0].Messages, 2)
+
+	// the script has no reply left
+	_, err = client.InvokeSession(ctx, session.ID, userID, "list pods again")
+	var apiErr *autogen_client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Contains(t, apiErr.Message, "no scripted reply left")
+}
+
+func TestInvokeTaskStreamCancel(t *testing.T) {
+	backend := fake.NewServer()
+	defer backend.Close()
+	backend.SetDefaultAgent(fake.Block())
+	client := backend.Client()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := client.InvokeTaskStream(ctx, &autogen_client.InvokeTaskRequest{
+		Task:       "wait",
+		TeamConfig: &api.Component{Label: "any"},
+	})
+	require.NoError(t, err)
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream was not closed")
+	}
+}
+
+func TestRefreshToolServer(t *testing.T) {
+	backend := fake.NewServer()
+	defer backend.Close()
+	backend.SetToolServerTools("everything", api.MCPTool{Name: "echo", Description: "echoes the input"})
+	client := backend.Client()
+	ctx := context.Background()
+
+	server, err := client.CreateToolServer(ctx, &autogen_client.ToolServer{
+		UserID:    userID,
+		Component: api.Component{Provider: "kagent.tool_servers.SseMcpToolServer", Label: "everything"},
+	}, userID)
+	require.NoError(t, err)
+	require.NoError(t, client.RefreshToolServer(ctx, server.Id, userID))
+
+	tools, err := client.ListToolsForServer(ctx, &server.Id, userID)
+	require.NoError(t, err)
+	require.Len(t, tools, 1)
+	assert.Equal(t, "autogen_ext.tools.mcp.SseMcpToolAdapter", tools[0].Component.Provider)
+	var config api.MCPToolConfig
+	b, err := json.Marshal(tools[0].Component.Config)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &config))
+	assert.Equal(t, "echo", config.Tool.Name)
+
+	_, err = client.GetToolServer(ctx, server.Id+100, userID)
+	assert.True(t, autogen_client.IsNotFound(err))
+}
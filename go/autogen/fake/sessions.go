@@ -0,0 +1,231 @@
+package fake
+
+import (
+	"net/http"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+)
+
+func (s *Server) handleListSessions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sessions := sortedValues(s.sessions, func(session *autogen_client.Session) bool {
+		return session.UserID == userID(r)
+	})
+	writeData(w, sessions)
+}
+
+func (s *Server) handleCreateSession(w http.ResponseWriter, r *http.Request) {
+	var req autogen_client.CreateSession
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session := &autogen_client.Session{
+		ID:        s.nextID(),
+		UserID:    req.UserID,
+		TeamID:    req.TeamID,
+		Name:      req.Name,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+	s.sessions[session.ID] = session
+	writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: true, Message: "Session created successfully", Data: session})
+}
+
+func (s *Server) handleGetSession(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.getSession(w, r)
+	if !ok {
+		return
+	}
+	writeData(w, session)
+}
+
+// handleUpdateSession renames the session, which is the only change supported by the autogen backend.
+func (s *Server) handleUpdateSession(w http.ResponseWriter, r *http.Request) {
+	var req autogen_client.Session
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.getSession(w, r)
+	if !ok {
+		return
+	}
+	session.Name = req.Name
+	session.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: true, Message: "Session updated successfully", Data: session})
+}
+
+// handleDeleteSession deletes the session and its runs.
+func (s *Server) handleDeleteSession(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if session, ok := s.sessions[id]; ok && session.UserID == userID(r) {
+		for runID, run := range s.runs {
+			if run.SessionID == id {
+				delete(s.runs, runID)
+			}
+		}
+		delete(s.sessions, id)
+	}
+	writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: true, Message: "Session deleted successfully"})
+}
+
+func (s *Server) handleListSessionRuns(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.getSession(w, r)
+	if !ok {
+		return
+	}
+	runs := []autogen_client.Run{}
+	for _, run := range s.runsOfSession(session.ID) {
+		runs = append(runs, *run)
+	}
+	writeData(w, autogen_client.SessionRuns{Runs: runs})
+}
+
+func (s *Server) handleCreateRun(w http.ResponseWriter, r *http.Request) {
+	var req autogen_client.CreateRunRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[req.SessionID]
+	if !ok || session.UserID != req.UserID {
+		writeError(w, http.StatusNotFound, "Session not found")
+		return
+	}
+	run := s.createRun(session.ID, nil)
+	writeData(w, autogen_client.CreateRunResult{ID: run.ID})
+}
+
+func (s *Server) handleGetRun(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, ok := s.getRun(w, r)
+	if !ok {
+		return
+	}
+	writeData(w, run)
+}
+
+func (s *Server) handleGetRunMessages(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, ok := s.getRun(w, r)
+	if !ok {
+		return
+	}
+	writeData(w, run.Messages)
+}
+
+func (s *Server) handleDeleteRun(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.runs, id)
+	writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: true})
+}
+
+// getSession returns the session of the request, s.mu must be held.
+func (s *Server) getSession(w http.ResponseWriter, r *http.Request) (*autogen_client.Session, bool) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return nil, false
+	}
+	session, ok := s.sessions[id]
+	if !ok || session.UserID != userID(r) {
+		writeError(w, http.StatusNotFound, "Session not found")
+		return nil, false
+	}
+	return session, true
+}
+
+// getRun returns the run of the request, s.mu must be held.
+func (s *Server) getRun(w http.ResponseWriter, r *http.Request) (*autogen_client.Run, bool) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return nil, false
+	}
+	run, ok := s.runs[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Run not found")
+		return nil, false
+	}
+	return run, true
+}
+
+// runsOfSession returns the runs of the session in the order they were created, s.mu must be held.
+func (s *Server) runsOfSession(sessionID int) []*autogen_client.Run {
+	return sortedValues(s.runs, func(run *autogen_client.Run) bool {
+		return run.SessionID == sessionID
+	})
+}
+
+// createRun records a new run of the session, s.mu must be held.
+func (s *Server) createRun(sessionID int, task *string) *autogen_client.Run {
+	run := &autogen_client.Run{
+		ID:        s.nextID(),
+		SessionID: sessionID,
+		CreatedAt: now(),
+		Status:    runStatusCreated,
+	}
+	if task != nil {
+		run.Task = autogen_client.Task{
+			Source:      "user",
+			Content:     *task,
+			MessageType: "text",
+		}
+	}
+	s.runs[run.ID] = run
+	return run
+}
+
+// finishRun records the outcome of a run and its messages.
+func (s *Server) finishRun(runID int, status string, result *autogen_client.TeamResult, errorMessage string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, ok := s.runs[runID]
+	if !ok {
+		// the run was deleted while running
+		return
+	}
+	run.Status = status
+	run.ErrorMessage = errorMessage
+	if result == nil {
+		return
+	}
+	run.TeamResult = *result
+	var userID *string
+	if session, ok := s.sessions[run.SessionID]; ok {
+		userID = &session.UserID
+	}
+	for _, message := range result.TaskResult.Messages {
+		run.Messages = append(run.Messages, &autogen_client.RunMessage{
+			ID:        s.nextID(),
+			SessionID: run.SessionID,
+			RunID:     run.ID,
+			UserID:    userID,
+			Config:    message,
+		})
+	}
+}
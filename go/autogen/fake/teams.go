@@ -0,0 +1,66 @@
+package fake
+
+import (
+	"net/http"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+)
+
+func (s *Server) handleListTeams(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	teams := sortedValues(s.teams, func(t *autogen_client.Team) bool {
+		return t.UserID == userID(r)
+	})
+	writeData(w, teams)
+}
+
+// handleCreateTeam updates the team with the ID of the request, or creates a new team.
+func (s *Server) handleCreateTeam(w http.ResponseWriter, r *http.Request) {
+	var team autogen_client.Team
+	if !decodeBody(w, r, &team) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.teams[team.Id]; ok {
+		team.CreatedAt = existing.CreatedAt
+	} else {
+		team.Id = s.nextID()
+		team.CreatedAt = now()
+	}
+	team.UpdatedAt = now()
+	s.teams[team.Id] = &team
+	writeData(w, team)
+}
+
+func (s *Server) handleGetTeam(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	team, ok := s.teams[id]
+	if !ok || team.UserID != userID(r) {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+	writeData(w, team)
+}
+
+func (s *Server) handleDeleteTeam(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if team, ok := s.teams[id]; ok && team.UserID == userID(r) {
+		delete(s.teams, id)
+	}
+	writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: true, Message: "Team deleted successfully"})
+}
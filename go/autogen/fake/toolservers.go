@@ -0,0 +1,211 @@
+package fake
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+)
+
+func (s *Server) handleListToolServers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	servers := sortedValues(s.toolServers, func(ts *autogen_client.ToolServer) bool {
+		return ts.UserID == userID(r)
+	})
+	sort.SliceStable(servers, func(i, j int) bool {
+		return servers[i].Component.Label < servers[j].Component.Label
+	})
+	writeData(w, servers)
+}
+
+// handleCreateToolServer updates the tool server with the ID of the request, or creates a new tool server.
+func (s *Server) handleCreateToolServer(w http.ResponseWriter, r *http.Request) {
+	var server autogen_client.ToolServer
+	if !decodeBody(w, r, &server) {
+		return
+	}
+	if server.UserID == "" {
+		server.UserID = userID(r)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.upsertToolServer(&server)
+	writeData(w, server)
+}
+
+func (s *Server) handleGetToolServer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	server, ok := s.getToolServer(w, r)
+	if !ok {
+		return
+	}
+	writeData(w, server)
+}
+
+func (s *Server) handleUpdateToolServer(w http.ResponseWriter, r *http.Request) {
+	var server autogen_client.ToolServer
+	if !decodeBody(w, r, &server) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.getToolServer(w, r)
+	if !ok {
+		return
+	}
+	server.Id = existing.Id
+	server.UserID = existing.UserID
+	s.upsertToolServer(&server)
+	writeData(w, server)
+}
+
+// handleDeleteToolServer deletes the tool server and its tools.
+func (s *Server) handleDeleteToolServer(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if server, ok := s.toolServers[id]; ok && server.UserID == userID(r) {
+		for toolID, tool := range s.tools {
+			if tool.ServerID != nil && *tool.ServerID == id {
+				delete(s.tools, toolID)
+			}
+		}
+		delete(s.toolServers, id)
+	}
+	writeJSON(w, http.StatusOK, autogen_client.APIResponse{Status: true, Message: "Server and associated tools deleted successfully"})
+}
+
+func (s *Server) handleListToolServerTools(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	server, ok := s.getToolServer(w, r)
+	if !ok {
+		return
+	}
+	writeData(w, s.toolsOfServer(server.Id))
+}
+
+// handleRefreshToolServer updates the tools of the server with the tools set by SetToolServerTools.
+func (s *Server) handleRefreshToolServer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	server, ok := s.getToolServer(w, r)
+	if !ok {
+		return
+	}
+	server.LastConnected = now()
+
+	existing := map[string]*autogen_client.Tool{}
+	for _, tool := range s.toolsOfServer(server.Id) {
+		existing[tool.Component.Label] = tool
+	}
+	updated, created := 0, 0
+	for _, mcpTool := range s.serverTools[server.Component.Label] {
+		component := mcpToolComponent(server, mcpTool)
+		if tool, ok := existing[mcpTool.Name]; ok {
+			tool.Component = component
+			tool.UpdatedAt = now()
+			updated++
+			continue
+		}
+		tool := &autogen_client.Tool{
+			BaseObject: autogen_client.BaseObject{
+				Id:        s.nextID(),
+				UserID:    server.UserID,
+				CreatedAt: now(),
+				UpdatedAt: now(),
+			},
+			Component: component,
+			ServerID:  &server.Id,
+		}
+		s.tools[tool.Id] = tool
+		created++
+	}
+
+	writeJSON(w, http.StatusOK, autogen_client.APIResponse{
+		Status:  true,
+		Message: "Server refreshed successfully.",
+		Data: map[string]int{
+			"total_count":   len(s.serverTools[server.Component.Label]),
+			"updated_count": updated,
+			"created_count": created,
+		},
+	})
+}
+
+func (s *Server) handleListTools(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tools := sortedValues(s.tools, func(t *autogen_client.Tool) bool {
+		return t.UserID == userID(r)
+	})
+	writeData(w, tools)
+}
+
+// upsertToolServer stores the tool server, s.mu must be held.
+func (s *Server) upsertToolServer(server *autogen_client.ToolServer) {
+	if existing, ok := s.toolServers[server.Id]; ok {
+		server.CreatedAt = existing.CreatedAt
+		server.LastConnected = existing.LastConnected
+	} else {
+		server.Id = s.nextID()
+		server.CreatedAt = now()
+	}
+	server.UpdatedAt = now()
+	s.toolServers[server.Id] = server
+}
+
+// getToolServer returns the tool server of the request, s.mu must be held.
+func (s *Server) getToolServer(w http.ResponseWriter, r *http.Request) (*autogen_client.ToolServer, bool) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return nil, false
+	}
+	server, ok := s.toolServers[id]
+	if !ok || server.UserID != userID(r) {
+		writeError(w, http.StatusNotFound, "Server not found")
+		return nil, false
+	}
+	return server, true
+}
+
+// toolsOfServer returns the tools discovered on the server, s.mu must be held.
+func (s *Server) toolsOfServer(serverID int) []*autogen_client.Tool {
+	return sortedValues(s.tools, func(t *autogen_client.Tool) bool {
+		return t.ServerID != nil && *t.ServerID == serverID
+	})
+}
+
+// mcpToolComponent returns the component of a tool discovered on the server, as created by the autogen backend.
+func mcpToolComponent(server *autogen_client.ToolServer, tool api.MCPTool) *api.Component {
+	provider := stdioMcpToolAdapter
+	if strings.Contains(server.Component.Provider, "Sse") {
+		provider = sseMcpToolAdapter
+	}
+	return &api.Component{
+		Provider:         provider,
+		ComponentType:    "tool",
+		Version:          1,
+		ComponentVersion: 1,
+		Description:      tool.Description,
+		Label:            tool.Name,
+		Config: map[string]interface{}{
+			"server_params": server.Component.Config,
+			"tool": map[string]interface{}{
+				"name":         tool.Name,
+				"description":  tool.Description,
+				"input_schema": tool.InputSchema,
+			},
+		},
+	}
+}
@@ -80,7 +80,7 @@ func main() {
 		Long:  `Generate a bug report`,
 		Run: func(cmd *cobra.Command, args []string) {
 			client := autogen_client.New(cfg.APIURL)
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				pf := cli.NewPortForward(ctx, cfg)
 				defer pf.Stop()
 			}
@@ -94,11 +94,11 @@ func main() {
 		Long:  `Print the kagent version`,
 		Run: func(cmd *cobra.Command, args []string) {
 			client := autogen_client.New(cfg.APIURL)
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				pf := cli.NewPortForward(ctx, cfg)
 				defer pf.Stop()
 			}
-			cli.VersionCmd(cfg)
+			cli.VersionCmd(ctx, cfg)
 		},
 	}
 
@@ -135,7 +135,7 @@ func main() {
 		Long:  `Get a kagent resource`,
 		Run: func(cmd *cobra.Command, args []string) {
 			client := autogen_client.New(cfg.APIURL)
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				pf := cli.NewPortForward(ctx, cfg)
 				defer pf.Stop()
 			}
@@ -152,13 +152,13 @@ func main() {
 			}
 			switch strings.TrimSuffix(strings.ToLower(resourceType), "s") {
 			case "session":
-				cli.GetSessionCmd(cfg, resourceName)
+				cli.GetSessionCmd(ctx, cfg, resourceName)
 			case "run":
-				cli.GetRunCmd(cfg, resourceName)
+				cli.GetRunCmd(ctx, cfg, resourceName)
 			case "agent":
-				cli.GetAgentCmd(cfg, resourceName)
+				cli.GetAgentCmd(ctx, cfg, resourceName)
 			case "tool":
-				cli.GetToolCmd(cfg)
+				cli.GetToolCmd(ctx, cfg)
 			default:
 				fmt.Fprintf(os.Stderr, "Invalid resource type: %s\n", resourceType)
 				os.Exit(1)
@@ -258,7 +258,7 @@ Examples:
 - chat
 `,
 		Func: func(c *ishell.Context) {
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				c.Println(err)
 				return
 			}
@@ -332,15 +332,15 @@ Examples:
   get session
   `,
 		Func: func(c *ishell.Context) {
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				c.Println(err)
 				return
 			}
 			cfg := config.GetCfg(c)
 			if len(c.Args) > 0 {
-				cli.GetSessionCmd(cfg, c.Args[0])
+				cli.GetSessionCmd(ctx, cfg, c.Args[0])
 			} else {
-				cli.GetSessionCmd(cfg, "")
+				cli.GetSessionCmd(ctx, cfg, "")
 			}
 		},
 	})
@@ -357,15 +357,15 @@ Examples:
   get run
   `,
 		Func: func(c *ishell.Context) {
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				c.Println(err)
 				return
 			}
 			cfg := config.GetCfg(c)
 			if len(c.Args) > 0 {
-				cli.GetRunCmd(cfg, c.Args[0])
+				cli.GetRunCmd(ctx, cfg, c.Args[0])
 			} else {
-				cli.GetRunCmd(cfg, "")
+				cli.GetRunCmd(ctx, cfg, "")
 			}
 		},
 	})
@@ -382,15 +382,15 @@ Examples:
   get agent
   `,
 		Func: func(c *ishell.Context) {
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				c.Println(err)
 				return
 			}
 			cfg := config.GetCfg(c)
 			if len(c.Args) > 0 {
-				cli.GetAgentCmd(cfg, c.Args[0])
+				cli.GetAgentCmd(ctx, cfg, c.Args[0])
 			} else {
-				cli.GetAgentCmd(cfg, "")
+				cli.GetAgentCmd(ctx, cfg, "")
 			}
 		},
 	})
@@ -407,12 +407,12 @@ Examples:
   get tool
   `,
 		Func: func(c *ishell.Context) {
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				c.Println(err)
 				return
 			}
 			cfg := config.GetCfg(c)
-			cli.GetToolCmd(cfg)
+			cli.GetToolCmd(ctx, cfg)
 		},
 	})
 
@@ -434,7 +434,7 @@ Example:
   bug-report
 `,
 		Func: func(c *ishell.Context) {
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				c.Println(err)
 				return
 			}
@@ -449,7 +449,7 @@ Example:
 		// Hidden create command
 		if len(c.Args) > 0 && c.Args[0] == "create" {
 			c.Args = c.Args[1:]
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				c.Println(err)
 				return
 			}
@@ -457,7 +457,7 @@ Example:
 			c.SetPrompt(config.BoldBlue("kagent >> "))
 		} else if len(c.Args) > 0 && c.Args[0] == "delete" {
 			c.Args = c.Args[1:]
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				c.Println(err)
 				return
 			}
@@ -473,7 +473,7 @@ Example:
 		Aliases: []string{"v"},
 		Help:    "Print the kagent version.",
 		Func: func(c *ishell.Context) {
-			cli.VersionCmd(cfg)
+			cli.VersionCmd(ctx, cfg)
 			c.SetPrompt(config.BoldBlue("kagent >> "))
 		},
 	})
@@ -493,7 +493,7 @@ Example:
 		Aliases: []string{"u"},
 		Help:    "Uninstall kagent.",
 		Func: func(c *ishell.Context) {
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				c.Println(err)
 				return
 			}
@@ -507,7 +507,7 @@ Example:
 		Aliases: []string{"d"},
 		Help:    "Open the kagent dashboard.",
 		Func: func(c *ishell.Context) {
-			if err := cli.CheckServerConnection(client); err != nil {
+			if err := cli.CheckServerConnection(ctx, client); err != nil {
 				c.Println(err)
 				return
 			}
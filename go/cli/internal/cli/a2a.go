@@ -59,13 +59,7 @@ func A2ARun(ctx context.Context, cfg *A2ACfg) {
 	case protocol.TaskStateCompleted:
 		fmt.Fprintln(os.Stderr, "Task completed successfully:")
 		for _, artifact := range result.Artifacts {
-			var text string
-			for _, part := range artifact.Parts {
-				if textPart, ok := part.(protocol.TextPart); ok {
-					text += textPart.Text
-				}
-			}
-			fmt.Fprintln(os.Stdout, text)
+			fmt.Fprintln(os.Stdout, a2autils.ExtractPartsText(artifact.Parts))
 		}
 	}
 }
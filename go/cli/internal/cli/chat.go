@@ -1,9 +1,12 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
+	"os"
+	"os/signal"
 	"slices"
 
 	"github.com/abiosoft/ishell/v2"
@@ -18,6 +21,7 @@ const (
 )
 
 func ChatCmd(c *ishell.Context) {
+	ctx := context.Background()
 	verbose := false
 	var sessionName string
 	flagSet := pflag.NewFlagSet(c.RawArgs[0], pflag.ContinueOnError)
@@ -35,7 +39,7 @@ func ChatCmd(c *ishell.Context) {
 	if len(flagSet.Args()) > 0 {
 		teamName := flagSet.Args()[0]
 		var err error
-		team, err = client.GetTeam(teamName, cfg.UserID)
+		team, err = client.GetTeam(ctx, teamName, cfg.UserID)
 		if err != nil {
 			c.Println(err)
 			return
@@ -45,7 +49,7 @@ func ChatCmd(c *ishell.Context) {
 	if team == nil {
 		c.Printf("Please select from available teams.\n")
 		// Get the teams based on the input + userID
-		teams, err := client.ListTeams(cfg.UserID)
+		teams, err := client.ListTeams(ctx, cfg.UserID)
 		if err != nil {
 			c.Println(err)
 			return
@@ -68,7 +72,7 @@ func ChatCmd(c *ishell.Context) {
 		team = teams[selectedTeamIdx]
 	}
 
-	sessions, err := client.ListSessions(cfg.UserID)
+	sessions, err := client.ListSessions(ctx, cfg.UserID)
 	if err != nil {
 		c.Println(err)
 		return
@@ -102,7 +106,7 @@ func ChatCmd(c *ishell.Context) {
 			return
 		}
 		c.ShowPrompt(true)
-		session, err = client.CreateSession(&autogen_client.CreateSession{
+		session, err = client.CreateSession(ctx, &autogen_client.CreateSession{
 			UserID: cfg.UserID,
 			Name:   sessionName,
 			TeamID: team.Id,
@@ -148,13 +152,20 @@ func ChatCmd(c *ishell.Context) {
 		// s.Start()
 		// defer s.Stop()
 
-		ch, err := client.InvokeSessionStream(session.ID, cfg.UserID, task)
+		// interrupting the run stops it in the backend and returns to the prompt
+		runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		ch, err := client.InvokeSessionStream(runCtx, session.ID, cfg.UserID, task)
 		if err != nil {
+			stop()
 			c.Printf("Failed to invoke session: %v\n", err)
 			return
 		}
 
 		StreamEvents(ch, usage, verbose)
+		if runCtx.Err() != nil {
+			c.Println("run interrupted")
+		}
+		stop()
 	}
 }
 
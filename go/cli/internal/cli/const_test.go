@@ -42,6 +42,13 @@ func TestGetModelProvider(t *testing.T) {
 			expectedAPIKey:  "ANTHROPIC_API_KEY",
 			expectedHelmKey: "anthropic",
 		},
+		{
+			name:            "Gemini provider",
+			envVarValue:     string(v1alpha1.Gemini),
+			expectedResult:  v1alpha1.Gemini,
+			expectedAPIKey:  GEMINI_API_KEY,
+			expectedHelmKey: "gemini",
+		},
 		{
 			name:            "Ollama provider",
 			envVarValue:     string(v1alpha1.Ollama),
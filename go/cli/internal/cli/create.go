@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 
@@ -11,6 +12,7 @@ import (
 )
 
 func CreateCmd(c *ishell.Context) {
+	ctx := context.Background()
 	if len(c.Args) < 2 {
 		c.Println("Usage: create [resource_type] [file]")
 		return
@@ -45,7 +47,7 @@ func CreateCmd(c *ishell.Context) {
 			c.Println("Team label is required")
 			return
 		}
-		existingTeam, err := client.GetTeam(cmp.Label, cfg.UserID)
+		existingTeam, err := client.GetTeam(ctx, cmp.Label, cfg.UserID)
 		if err != nil {
 			c.Printf("Error getting team: %v\n", err)
 			return
@@ -74,7 +76,7 @@ func CreateCmd(c *ishell.Context) {
 			Component: &cmp,
 		}
 		// call client validate
-		resp, err := client.Validate(&req)
+		resp, err := client.Validate(ctx, &req)
 		if err != nil {
 			c.Printf("Error validating component: %v\n", err)
 			return
@@ -90,7 +92,7 @@ func CreateCmd(c *ishell.Context) {
 			}
 			return
 		}
-		if err := client.CreateTeam(team); err != nil {
+		if err := client.CreateTeam(ctx, team); err != nil {
 			c.Printf("Error creating team: %v\n", err)
 			return
 		}
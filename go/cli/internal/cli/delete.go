@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/abiosoft/ishell/v2"
@@ -9,6 +10,7 @@ import (
 )
 
 func DeleteCmd(c *ishell.Context) {
+	ctx := context.Background()
 	if len(c.Args) < 2 {
 		c.Println("Usage: delete [resource_type] id")
 		return
@@ -34,7 +36,7 @@ func DeleteCmd(c *ishell.Context) {
 			c.Printf("Invalid team ID: %v\n", err)
 			return
 		}
-		if err := client.DeleteTeam(teamID, cfg.UserID); err != nil {
+		if err := client.DeleteTeam(ctx, teamID, cfg.UserID); err != nil {
 			c.Printf("Error deleting team: %v\n", err)
 			return
 		}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,11 +11,11 @@ import (
 	"github.com/kagent-dev/kagent/go/cli/internal/config"
 )
 
-func GetAgentCmd(cfg *config.Config, resourceName string) {
+func GetAgentCmd(ctx context.Context, cfg *config.Config, resourceName string) {
 	client := autogen_client.New(cfg.APIURL)
 
 	if resourceName == "" {
-		agentList, err := client.ListTeams(cfg.UserID)
+		agentList, err := client.ListTeams(ctx, cfg.UserID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get agents: %v\n", err)
 			return
@@ -30,7 +31,7 @@ func GetAgentCmd(cfg *config.Config, resourceName string) {
 			return
 		}
 	} else {
-		agent, err := client.GetTeam(resourceName, cfg.UserID)
+		agent, err := client.GetTeam(ctx, resourceName, cfg.UserID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get agent %s: %v\n", resourceName, err)
 			return
@@ -40,10 +41,10 @@ func GetAgentCmd(cfg *config.Config, resourceName string) {
 	}
 }
 
-func GetRunCmd(cfg *config.Config, resourceName string) {
+func GetRunCmd(ctx context.Context, cfg *config.Config, resourceName string) {
 	client := autogen_client.New(cfg.APIURL)
 	if resourceName == "" {
-		runList, err := client.ListRuns(cfg.UserID)
+		runList, err := client.ListRuns(ctx, cfg.UserID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get runs: %v\n", err)
 			return
@@ -66,7 +67,7 @@ func GetRunCmd(cfg *config.Config, resourceName string) {
 			return
 		}
 
-		run, err := client.GetRun(runID)
+		run, err := client.GetRun(ctx, runID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get run %d: %v\n", runID, err)
 			return
@@ -76,10 +77,10 @@ func GetRunCmd(cfg *config.Config, resourceName string) {
 	}
 }
 
-func GetSessionCmd(cfg *config.Config, resourceName string) {
+func GetSessionCmd(ctx context.Context, cfg *config.Config, resourceName string) {
 	client := autogen_client.New(cfg.APIURL)
 	if resourceName == "" {
-		sessionList, err := client.ListSessions(cfg.UserID)
+		sessionList, err := client.ListSessions(ctx, cfg.UserID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get sessions: %v\n", err)
 			return
@@ -100,7 +101,7 @@ func GetSessionCmd(cfg *config.Config, resourceName string) {
 			fmt.Fprintf(os.Stderr, "Failed to convert session name to ID: %v\n", err)
 			return
 		}
-		session, err := client.GetSessionById(sessionID, cfg.UserID)
+		session, err := client.GetSessionById(ctx, sessionID, cfg.UserID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get session %s: %v\n", resourceName, err)
 			return
@@ -110,9 +111,9 @@ func GetSessionCmd(cfg *config.Config, resourceName string) {
 	}
 }
 
-func GetToolCmd(cfg *config.Config) {
+func GetToolCmd(ctx context.Context, cfg *config.Config) {
 	client := autogen_client.New(cfg.APIURL)
-	toolList, err := client.ListTools(cfg.UserID)
+	toolList, err := client.ListTools(ctx, cfg.UserID)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to get tools: %v\n", err)
 		return
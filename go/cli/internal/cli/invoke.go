@@ -25,7 +25,7 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 	client := autogen_client.New(cfg.Config.APIURL)
 
 	var pf *portForward
-	if err := CheckServerConnection(client); err != nil {
+	if err := CheckServerConnection(ctx, client); err != nil {
 		pf = NewPortForward(ctx, cfg.Config)
 		defer pf.Stop()
 	}
@@ -54,7 +54,7 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 	}
 	// If session is set invoke within a session.
 	if cfg.Session != "" {
-		session, err := client.GetSession(cfg.Session, cfg.Config.UserID)
+		session, err := client.GetSession(ctx, cfg.Session, cfg.Config.UserID)
 		if err != nil {
 			if errors.Is(err, autogen_client.NotFoundError) {
 				if cfg.Agent == "" {
@@ -62,12 +62,12 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 					return
 				}
 				// If the session is not found, create it
-				team, err := client.GetTeam(cfg.Agent, cfg.Config.UserID)
+				team, err := client.GetTeam(ctx, cfg.Agent, cfg.Config.UserID)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Error getting team: %v\n", err)
 					return
 				}
-				session, err = client.CreateSession(&autogen_client.CreateSession{
+				session, err = client.CreateSession(ctx, &autogen_client.CreateSession{
 					Name:   cfg.Session,
 					UserID: cfg.Config.UserID,
 					TeamID: team.Id,
@@ -84,14 +84,14 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 
 		if cfg.Stream {
 			usage := &autogen_client.ModelsUsage{}
-			ch, err := client.InvokeSessionStream(session.ID, cfg.Config.UserID, task)
+			ch, err := client.InvokeSessionStream(ctx, session.ID, cfg.Config.UserID, task)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error invoking session: %v\n", err)
 				return
 			}
 			StreamEvents(ch, usage, cfg.Config.Verbose)
 		} else {
-			result, err := client.InvokeSession(session.ID, cfg.Config.UserID, task)
+			result, err := client.InvokeSession(ctx, session.ID, cfg.Config.UserID, task)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error invoking session: %v\n", err)
 				return
@@ -107,7 +107,7 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 
 	} else {
 
-		team, err := client.GetTeam(cfg.Agent, cfg.Config.UserID)
+		team, err := client.GetTeam(ctx, cfg.Agent, cfg.Config.UserID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting team: %v\n", err)
 			return
@@ -120,14 +120,14 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 
 		if cfg.Stream {
 			usage := &autogen_client.ModelsUsage{}
-			ch, err := client.InvokeTaskStream(req)
+			ch, err := client.InvokeTaskStream(ctx, req)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error invoking task: %v\n", err)
 				return
 			}
 			StreamEvents(ch, usage, cfg.Config.Verbose)
 		} else {
-			result, err := client.InvokeTask(req)
+			result, err := client.InvokeTask(ctx, req)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error invoking task: %v\n", err)
 				return
@@ -14,13 +14,18 @@ import (
 	"github.com/kagent-dev/kagent/go/cli/internal/config"
 )
 
-func CheckServerConnection(client autogen_client.Client) error {
+// serverConnectionTimeout bounds the retries of the client when checking whether the server is reachable
+const serverConnectionTimeout = time.Second
+
+func CheckServerConnection(ctx context.Context, client autogen_client.Client) error {
 	// Only check if we have a valid client
 	if client == nil {
 		return fmt.Errorf("Error connecting to server. Please run 'install' command first.")
 	}
 
-	_, err := client.GetVersion()
+	ctx, cancel := context.WithTimeout(ctx, serverConnectionTimeout)
+	defer cancel()
+	_, err := client.GetVersion(ctx)
 	if err != nil {
 		return fmt.Errorf("Error connecting to server. Please run 'install' command first.")
 	}
@@ -46,7 +51,7 @@ func NewPortForward(ctx context.Context, cfg *config.Config) *portForward {
 	client := autogen_client.New(cfg.APIURL)
 	// Try to connect 5 times
 	for i := 0; i < 5; i++ {
-		if err := CheckServerConnection(client); err == nil {
+		if err := CheckServerConnection(ctx, client); err == nil {
 			break
 		}
 		time.Sleep(50 * time.Millisecond)
@@ -173,6 +178,19 @@ func ParseEvent(event []byte) (Event, error) {
 	}
 }
 
+// sseErrorMessage extracts the message of an error event, falling back to its raw data.
+func sseErrorMessage(data []byte) string {
+	var errEvent struct {
+		Data struct {
+			Message string `json:"message"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &errEvent); err != nil || errEvent.Data.Message == "" {
+		return string(data)
+	}
+	return errEvent.Data.Message
+}
+
 func StreamEvents(ch <-chan *autogen_client.SseEvent, usage *autogen_client.ModelsUsage, verbose bool) {
 	// Tool call requests and executions are sent as separate messages, but we should print them together
 	// so if we receive a tool call request, we buffer it until we receive the corresponding tool call execution
@@ -182,6 +200,10 @@ func StreamEvents(ch <-chan *autogen_client.SseEvent, usage *autogen_client.Mode
 	// If we are then we don't want to print the whole TextMessage, but only the content of the ModelStreamingEvent
 	streaming := map[string]bool{}
 	for event := range ch {
+		if event.Event == autogen_client.SseErrorEvent {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", sseErrorMessage(event.Data))
+			continue
+		}
 		ev, err := ParseEvent(event.Data)
 		if err != nil {
 			// TODO: verbose logging
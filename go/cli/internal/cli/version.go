@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -15,13 +16,13 @@ var (
 	BuildDate = "unknown"
 )
 
-func VersionCmd(cfg *config.Config) {
+func VersionCmd(ctx context.Context, cfg *config.Config) {
 	fmt.Fprintf(os.Stdout, "kagent version %s\n", Version)
 	fmt.Fprintf(os.Stdout, "git commit: %s\n", GitCommit)
 	fmt.Fprintf(os.Stdout, "build date: %s\n", BuildDate)
 
 	client := autogen_client.New(cfg.APIURL)
-	version, err := client.GetVersion()
+	version, err := client.GetVersion(ctx)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Warning: Could not fetch backend version")
 	} else {
@@ -43,6 +43,15 @@ type AgentSpec struct {
 	Tools []*Tool `json:"tools,omitempty"`
 	// +optional
 	Memory []string `json:"memory,omitempty"`
+	// Handoffs are the agents this agent may hand off the conversation to in swarm teams.
+	// They are ignored in other teams.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	Handoffs []Handoff `json:"handoffs,omitempty"`
+	// ModelContext determines which messages of the conversation are sent to the model.
+	// If not specified, all messages are sent.
+	// +optional
+	ModelContext *ModelContext `json:"modelContext,omitempty"`
 	// A2AConfig instantiates an A2A server for this agent,
 	// served on the HTTP port of the kagent kubernetes
 	// controller (default 8083).
@@ -53,14 +62,86 @@ type AgentSpec struct {
 	A2AConfig *A2AConfig `json:"a2aConfig,omitempty"`
 }
 
+// Handoff allows an agent of a swarm team to hand off the conversation to another participant.
+type Handoff struct {
+	// The name of the target agent, which must be a participant of the swarm team.
+	// The builtin planning agent of the team is named planning_agent.
+	// +kubebuilder:validation:MinLength=1
+	Target string `json:"target"`
+	// Describes to the model when to hand off to the target. Defaults to the description of the target.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// The message added to the conversation when handing off to the target.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ModelContextType is the strategy which limits the messages sent to the model
+// +kubebuilder:validation:Enum=Unbounded;Buffered;HeadAndTail;TokenLimited
+type ModelContextType string
+
+const (
+	ModelContextType_Unbounded    ModelContextType = "Unbounded"
+	ModelContextType_Buffered     ModelContextType = "Buffered"
+	ModelContextType_HeadAndTail  ModelContextType = "HeadAndTail"
+	ModelContextType_TokenLimited ModelContextType = "TokenLimited"
+)
+
+// ModelContext limits the messages of long conversations sent to the model, so that they do
+// not overflow the context window of the model:
+//   - Unbounded sends all messages
+//   - Buffered sends the last messages
+//   - HeadAndTail sends the first and the last messages
+//   - TokenLimited sends the last messages which fit in a number of tokens
+//
+// +kubebuilder:validation:XValidation:message="modelContext.buffered must be nil if the type is not Buffered",rule="!(has(self.buffered) && self.type != 'Buffered')"
+// +kubebuilder:validation:XValidation:message="modelContext.buffered must be specified for the Buffered type",rule="!(!has(self.buffered) && self.type == 'Buffered')"
+// +kubebuilder:validation:XValidation:message="modelContext.headAndTail must be nil if the type is not HeadAndTail",rule="!(has(self.headAndTail) && self.type != 'HeadAndTail')"
+// +kubebuilder:validation:XValidation:message="modelContext.headAndTail must be specified for the HeadAndTail type",rule="!(!has(self.headAndTail) && self.type == 'HeadAndTail')"
+// +kubebuilder:validation:XValidation:message="modelContext.tokenLimited must be nil if the type is not TokenLimited",rule="!(has(self.tokenLimited) && self.type != 'TokenLimited')"
+type ModelContext struct {
+	// +kubebuilder:default=Unbounded
+	Type ModelContextType `json:"type"`
+	// +optional
+	Buffered *BufferedModelContext `json:"buffered,omitempty"`
+	// +optional
+	HeadAndTail *HeadAndTailModelContext `json:"headAndTail,omitempty"`
+	// +optional
+	TokenLimited *TokenLimitedModelContext `json:"tokenLimited,omitempty"`
+}
+
+type BufferedModelContext struct {
+	// The number of last messages sent to the model
+	// +kubebuilder:validation:Minimum=1
+	BufferSize int `json:"bufferSize"`
+}
+
+type HeadAndTailModelContext struct {
+	// The number of first messages sent to the model, e.g. the task
+	// +kubebuilder:validation:Minimum=1
+	HeadSize int `json:"headSize"`
+	// The number of last messages sent to the model
+	// +kubebuilder:validation:Minimum=1
+	TailSize int `json:"tailSize"`
+}
+
+type TokenLimitedModelContext struct {
+	// The maximum number of tokens of the messages sent to the model. It must not exceed the
+	// context window of the model. If not specified, the messages fill the context window of the model.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TokenLimit *int `json:"tokenLimit,omitempty"`
+}
+
 // ToolProviderType represents the tool provider type
-// +kubebuilder:validation:Enum=Builtin;McpServer;Agent
+// +kubebuilder:validation:Enum=Builtin;McpServer;Agent;RemoteAgent
 type ToolProviderType string
 
 const (
-	ToolProviderType_Builtin   ToolProviderType = "Builtin"
-	ToolProviderType_McpServer ToolProviderType = "McpServer"
-	ToolProviderType_Agent     ToolProviderType = "Agent"
+	ToolProviderType_Builtin     ToolProviderType = "Builtin"
+	ToolProviderType_McpServer   ToolProviderType = "McpServer"
+	ToolProviderType_Agent       ToolProviderType = "Agent"
+	ToolProviderType_RemoteAgent ToolProviderType = "RemoteAgent"
 )
 
 // +kubebuilder:validation:XValidation:message="type.builtin must be nil if the type is not Builtin",rule="!(has(self.builtin) && self.type != 'Builtin')"
@@ -69,8 +150,10 @@ const (
 // +kubebuilder:validation:XValidation:message="type.mcpServer must be specified for McpServer filter.type",rule="!(!has(self.mcpServer) && self.type == 'McpServer')"
 // +kubebuilder:validation:XValidation:message="type.agent must be nil if the type is not Agent",rule="!(has(self.agent) && self.type != 'Agent')"
 // +kubebuilder:validation:XValidation:message="type.agent must be specified for Agent filter.type",rule="!(!has(self.agent) && self.type == 'Agent')"
+// +kubebuilder:validation:XValidation:message="type.remoteAgent must be nil if the type is not RemoteAgent",rule="!(has(self.remoteAgent) && self.type != 'RemoteAgent')"
+// +kubebuilder:validation:XValidation:message="type.remoteAgent must be specified for RemoteAgent filter.type",rule="!(!has(self.remoteAgent) && self.type == 'RemoteAgent')"
 type Tool struct {
-	// +kubebuilder:validation:Enum=Builtin;McpServer;Agent
+	// +kubebuilder:validation:Enum=Builtin;McpServer;Agent;RemoteAgent
 	Type ToolProviderType `json:"type,omitempty"`
 	// +optional
 	Builtin *BuiltinTool `json:"builtin,omitempty"`
@@ -78,6 +161,8 @@ type Tool struct {
 	McpServer *McpServerTool `json:"mcpServer,omitempty"`
 	// +optional
 	Agent *AgentTool `json:"agent,omitempty"`
+	// +optional
+	RemoteAgent *RemoteAgentTool `json:"remoteAgent,omitempty"`
 }
 
 type AgentTool struct {
@@ -87,6 +172,13 @@ type AgentTool struct {
 	Ref string `json:"ref,omitempty"`
 }
 
+type RemoteAgentTool struct {
+	// Reference to the RemoteAgent resource to use as a tool.
+	// Can either be a reference to the name of a RemoteAgent in the same namespace as the referencing Agent, or a reference to the name of a RemoteAgent in a different namespace in the form <namespace>/<name>
+	// +kubebuilder:validation:MinLength=1
+	Ref string `json:"ref,omitempty"`
+}
+
 type BuiltinTool struct {
 	// the name of the builtin tool
 	Name string `json:"name,omitempty"`
@@ -112,6 +204,60 @@ type AnyType struct {
 type A2AConfig struct {
 	// +kubebuilder:validation:MinItems=1
 	Skills []AgentSkill `json:"skills,omitempty"`
+	// Authentication restricts who may send tasks to the A2A server of this agent.
+	// A request is accepted if it satisfies any of the configured schemes.
+	// The A2A server is open to every client if it is not set.
+	// The accepted schemes are published in the agent card.
+	// +optional
+	Authentication *A2AAuthentication `json:"authentication,omitempty"`
+	// The kinds of message parts the agent accepts. Defaults to text.
+	// File parts are passed to the agent as attachments and data parts as JSON context.
+	// +kubebuilder:validation:items:Enum=text;file;data
+	// +optional
+	DefaultInputModes []string `json:"defaultInputModes,omitempty"`
+	// The kinds of message parts the agent returns. Defaults to text.
+	// With data, structured results are returned as data parts instead of text.
+	// +kubebuilder:validation:items:Enum=text;data
+	// +optional
+	DefaultOutputModes []string `json:"defaultOutputModes,omitempty"`
+}
+
+type A2AAuthentication struct {
+	// Bearer accepts static bearer tokens stored in a Secret.
+	// +optional
+	Bearer *A2ABearerAuthentication `json:"bearer,omitempty"`
+	// ServiceAccount accepts Kubernetes ServiceAccount tokens, validated via TokenReview.
+	// +optional
+	ServiceAccount *A2AServiceAccountAuthentication `json:"serviceAccount,omitempty"`
+	// MTLS accepts client certificates verified by the A2A TLS listener of the controller.
+	// +optional
+	MTLS *A2AMTLSAuthentication `json:"mtls,omitempty"`
+}
+
+type A2ABearerAuthentication struct {
+	// The name of a Secret in the namespace of the agent holding the accepted tokens.
+	SecretRef string `json:"secretRef"`
+	// The key of the Secret holding the accepted tokens, one per line.
+	// +kubebuilder:default=token
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+}
+
+type A2AServiceAccountAuthentication struct {
+	// The audiences the token must be issued for. Defaults to the audiences of the API server.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+	// The ServiceAccounts allowed to send tasks, in the form <namespace>/<name>.
+	// Every ServiceAccount in the cluster is allowed if empty.
+	// +optional
+	AllowedServiceAccounts []string `json:"allowedServiceAccounts,omitempty"`
+}
+
+type A2AMTLSAuthentication struct {
+	// The subject common names of the client certificates allowed to send tasks.
+	// Every certificate signed by the client CA of the controller is allowed if empty.
+	// +optional
+	AllowedCommonNames []string `json:"allowedCommonNames,omitempty"`
 }
 
 type AgentSkill server.AgentSkill
@@ -120,6 +266,13 @@ type AgentSkill server.AgentSkill
 type AgentStatus struct {
 	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	// A hash of the autogen component last pushed to the backend.
+	// The backend is only updated when the translated component hash changes or the backend lost the component.
+	// +optional
+	ComponentHash string `json:"componentHash,omitempty"`
+	// The ID of the team in the backend the component was last pushed to.
+	// +optional
+	BackendTeamID int `json:"backendTeamID,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -25,14 +25,17 @@ const (
 )
 
 // ModelProvider represents the model provider type
-// +kubebuilder:validation:Enum=Anthropic;OpenAI;AzureOpenAI;Ollama
+// +kubebuilder:validation:Enum=Anthropic;OpenAI;AzureOpenAI;Ollama;Gemini;OpenAICompatible;Mock
 type ModelProvider string
 
 const (
-	Anthropic   ModelProvider = "Anthropic"
-	AzureOpenAI ModelProvider = "AzureOpenAI"
-	OpenAI      ModelProvider = "OpenAI"
-	Ollama      ModelProvider = "Ollama"
+	Anthropic        ModelProvider = "Anthropic"
+	AzureOpenAI      ModelProvider = "AzureOpenAI"
+	OpenAI           ModelProvider = "OpenAI"
+	Ollama           ModelProvider = "Ollama"
+	Gemini           ModelProvider = "Gemini"
+	OpenAICompatible ModelProvider = "OpenAICompatible"
+	Mock             ModelProvider = "Mock"
 )
 
 // AnthropicConfig contains Anthropic-specific configuration options
@@ -146,12 +149,131 @@ type OllamaConfig struct {
 	Options map[string]string `json:"options,omitempty"`
 }
 
+// GeminiSafetySetting blocks responses with a probability of harm of the category above the threshold
+type GeminiSafetySetting struct {
+	// The category of harm
+	// +kubebuilder:validation:Enum=HARM_CATEGORY_HARASSMENT;HARM_CATEGORY_HATE_SPEECH;HARM_CATEGORY_SEXUALLY_EXPLICIT;HARM_CATEGORY_DANGEROUS_CONTENT;HARM_CATEGORY_CIVIC_INTEGRITY
+	Category string `json:"category"`
+
+	// The probability of harm from which responses are blocked
+	// +kubebuilder:validation:Enum=BLOCK_LOW_AND_ABOVE;BLOCK_MEDIUM_AND_ABOVE;BLOCK_ONLY_HIGH;BLOCK_NONE;OFF
+	Threshold string `json:"threshold"`
+}
+
+// GeminiConfig contains Gemini-specific configuration options
+type GeminiConfig struct {
+	// Base URL of the Gemini API (overrides default), e.g. the URL of a Vertex AI style endpoint or proxy
+	// serving the models/{model}:generateContent API
+	// +optional
+	BaseURL string `json:"baseUrl,omitempty"`
+
+	// Temperature for sampling
+	// +optional
+	Temperature string `json:"temperature,omitempty"`
+
+	// Maximum tokens to generate
+	// +optional
+	MaxOutputTokens int `json:"maxOutputTokens,omitempty"`
+
+	// Top-p sampling parameter
+	// +optional
+	TopP string `json:"topP,omitempty"`
+
+	// Top-k sampling parameter
+	// +optional
+	TopK int `json:"topK,omitempty"`
+
+	// Number of candidates to generate, agents use the first candidate
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8
+	// +optional
+	CandidateCount int `json:"candidateCount,omitempty"`
+
+	// Safety settings of the requests, which override the default settings of the API per category
+	// +optional
+	SafetySettings []GeminiSafetySetting `json:"safetySettings,omitempty"`
+}
+
+// OpenAICompatibleConfig contains configuration options for servers implementing the OpenAI API,
+// such as vLLM, LM Studio, llama.cpp or LiteLLM. The capabilities of the model are detected by
+// probing the server and recorded in the status of the ModelConfig.
+type OpenAICompatibleConfig struct {
+	// Base URL of the OpenAI-compatible API, including the version, e.g. http://vllm.models:8000/v1
+	// +required
+	BaseURL string `json:"baseUrl"`
+
+	// Temperature for sampling
+	// +optional
+	Temperature string `json:"temperature,omitempty"`
+
+	// Maximum tokens to generate
+	// +optional
+	MaxTokens int `json:"maxTokens,omitempty"`
+
+	// Top-p sampling parameter
+	// +optional
+	TopP string `json:"topP,omitempty"`
+
+	// Frequency penalty
+	// +optional
+	FrequencyPenalty string `json:"frequencyPenalty,omitempty"`
+
+	// Presence penalty
+	// +optional
+	PresencePenalty string `json:"presencePenalty,omitempty"`
+
+	// Seed value
+	// +optional
+	Seed *int `json:"seed,omitempty"`
+}
+
+// MockConfig contains configuration options of the Mock provider, which replies with the
+// responses of a script instead of calling a model. The script is a YAML document of the form:
+//
+//	rules:
+//	- match: "list (the )?pods"
+//	  toolCalls:
+//	  - name: k8s_get_resources
+//	    arguments:
+//	      resource_type: pod
+//	- match: "pod-"
+//	  content: "The cluster runs the pods listed above."
+//	default:
+//	  content: "I don't know."
+//
+// The response of the first rule whose regular expression matches the content of the last
+// message is returned, and the default response if no rule matches.
+type MockConfig struct {
+	// The reference to the ConfigMap that contains the script. Can either be a reference to the name of a ConfigMap in the same namespace as the referencing ModelConfig, or a reference to the name of a ConfigMap in a different namespace in the form <namespace>/<name>
+	// +required
+	ScriptConfigMapRef string `json:"scriptConfigMapRef"`
+
+	// The key in the ConfigMap that contains the script
+	// +kubebuilder:default=script.yaml
+	// +optional
+	ScriptKey string `json:"scriptKey,omitempty"`
+}
+
+// APIKeySecretReference references the key of a secret that contains an API key.
+type APIKeySecretReference struct {
+	// The name of a secret in the same namespace as the referencing ModelConfig, or of a Secret in a different namespace in the form <namespace>/<name>
+	// +required
+	Name string `json:"name"`
+
+	// The key in the secret that contains the API key
+	// +required
+	Key string `json:"key"`
+}
+
 // ModelConfigSpec defines the desired state of ModelConfig.
 //
 // +kubebuilder:validation:XValidation:message="provider.openAI must be nil if the provider is not OpenAI",rule="!(has(self.openAI) && self.provider != 'OpenAI')"
 // +kubebuilder:validation:XValidation:message="provider.anthropic must be nil if the provider is not Anthropic",rule="!(has(self.anthropic) && self.provider != 'Anthropic')"
 // +kubebuilder:validation:XValidation:message="provider.azureOpenAI must be nil if the provider is not AzureOpenAI",rule="!(has(self.azureOpenAI) && self.provider != 'AzureOpenAI')"
 // +kubebuilder:validation:XValidation:message="provider.ollama must be nil if the provider is not Ollama",rule="!(has(self.ollama) && self.provider != 'Ollama')"
+// +kubebuilder:validation:XValidation:message="provider.gemini must be nil if the provider is not Gemini",rule="!(has(self.gemini) && self.provider != 'Gemini')"
+// +kubebuilder:validation:XValidation:message="provider.openAICompatible must be nil if the provider is not OpenAICompatible",rule="!(has(self.openAICompatible) && self.provider != 'OpenAICompatible')"
+// +kubebuilder:validation:XValidation:message="provider.mock must be nil if the provider is not Mock",rule="!(has(self.mock) && self.provider != 'Mock')"
 type ModelConfigSpec struct {
 	Model string `json:"model"`
 
@@ -167,12 +289,27 @@ type ModelConfigSpec struct {
 	// +optional
 	APIKeySecretKey string `json:"apiKeySecretKey"`
 
+	// Additional API keys of the provider. Requests are distributed round-robin across the API key of
+	// apiKeySecretRef and these keys, and a request which fails with one key is retried with the next one.
+	// +optional
+	APIKeySecrets []APIKeySecretReference `json:"apiKeySecrets,omitempty"`
+
+	// The ModelConfigs to fall back to, in order, if requests to this model fail, e.g. during an outage of its provider.
+	// Each is the name of a ModelConfig in the same namespace, or of a ModelConfig in a different namespace in the form <namespace>/<name>.
+	// The fallbacks of the referenced ModelConfigs are not followed.
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	Fallbacks []string `json:"fallbacks,omitempty"`
+
 	// +optional
 	DefaultHeaders map[string]string `json:"defaultHeaders,omitempty"`
 
 	// ModelInfo contains information about the model.
 	// This field is required if the model is not one of the
 	// pre-defined autogen models. That list can be found here:
+	// https://github.com/microsoft/autogen/blob/main/python/packages/autogen-ext/src/autogen_ext/models/openai/_model_info.py
+	//
+	// For the OpenAICompatible provider, the server is not probed if it is set.
 	// +optional
 	ModelInfo *ModelInfo `json:"modelInfo,omitempty"`
 
@@ -191,6 +328,18 @@ type ModelConfigSpec struct {
 	// Ollama-specific configuration
 	// +optional
 	Ollama *OllamaConfig `json:"ollama,omitempty"`
+
+	// Gemini-specific configuration
+	// +optional
+	Gemini *GeminiConfig `json:"gemini,omitempty"`
+
+	// Configuration of OpenAI-compatible servers
+	// +optional
+	OpenAICompatible *OpenAICompatibleConfig `json:"openAICompatible,omitempty"`
+
+	// Mock-specific configuration
+	// +optional
+	Mock *MockConfig `json:"mock,omitempty"`
 }
 
 // Model Configurations
@@ -209,12 +358,48 @@ type ModelInfo struct {
 	StructuredOutput bool `json:"structuredOutput"`
 	// +optional
 	MultipleSystemMessages bool `json:"multipleSystemMessages"`
+	// The maximum number of tokens of the context of the model. Token-limited model contexts of
+	// agents are validated against it, and against the known context windows of well-known models if it is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ContextWindow int `json:"contextWindow,omitempty"`
+}
+
+// ModelClientHealth reports whether an API key or fallback of a ModelConfig was available when it was last checked.
+// The controller checks them periodically by listing the models of their provider.
+type ModelClientHealth struct {
+	// The secret and key of the API key in the form <secret>/<key>, or the name of the fallback ModelConfig
+	Name string `json:"name"`
+	// Whether the provider served requests with the API key, or the fallback ModelConfig exists,
+	// is accepted and its provider served requests with one of its API keys
+	Healthy bool `json:"healthy"`
+	// The last time the API key or fallback was found to be unhealthy, e.g. during an outage or rate limiting of the provider
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// The reason of the last failure
+	// +optional
+	LastFailureMessage string `json:"lastFailureMessage,omitempty"`
 }
 
 // ModelConfigStatus defines the observed state of ModelConfig.
 type ModelConfigStatus struct {
 	Conditions         []metav1.Condition `json:"conditions"`
 	ObservedGeneration int64              `json:"observedGeneration"`
+	// The model info detected by probing the server of an OpenAICompatible provider.
+	// +optional
+	ModelInfo *ModelInfo `json:"modelInfo,omitempty"`
+	// The generation of the ModelConfig the model info was detected for.
+	// +optional
+	ProbedGeneration int64 `json:"probedGeneration,omitempty"`
+	// The last time the server was probed successfully.
+	// +optional
+	LastProbedTime *metav1.Time `json:"lastProbedTime,omitempty"`
+	// The health of the API keys requests are distributed across, if apiKeySecrets is set.
+	// +optional
+	APIKeys []ModelClientHealth `json:"apiKeys,omitempty"`
+	// The health of the fallbacks, in order.
+	// +optional
+	Fallbacks []ModelClientHealth `json:"fallbacks,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -24,11 +24,31 @@ const (
 	TeamConditionTypeAccepted = "Accepted"
 )
 
+// ModelConfigOverridePolicy determines whether the model config of a team overrides the model configs of its participants.
+// +kubebuilder:validation:Enum=None;All
+type ModelConfigOverridePolicy string
+
+const (
+	// ModelConfigOverrideNone keeps the model configs of the participants. Participants without a
+	// model config use the model config of the team.
+	ModelConfigOverrideNone ModelConfigOverridePolicy = "None"
+	// ModelConfigOverrideAll makes all participants use the model config of the team.
+	ModelConfigOverrideAll ModelConfigOverridePolicy = "All"
+)
+
 // TeamSpec defines the desired state of Team.
 type TeamSpec struct {
 	Participants []string `json:"participants"`
 	Description  string   `json:"description"`
-	ModelConfig  string   `json:"modelConfig"`
+	// The model config of the team, used by the selector of selector teams, the orchestrator of
+	// magentic one teams and the planning agent of swarm teams.
+	ModelConfig string `json:"modelConfig"`
+	// Whether the model config of the team overrides the model configs of the participants.
+	// By default participants keep their own model config, e.g. so that cheap models do the work
+	// of the participants while a strong model selects the next speaker.
+	// +kubebuilder:default=None
+	// +optional
+	ModelConfigOverride ModelConfigOverridePolicy `json:"modelConfigOverride,omitempty"`
 	// +kubebuilder:validation:Optional
 	RoundRobinTeamConfig *RoundRobinTeamConfig `json:"roundRobinTeamConfig"`
 	// +kubebuilder:validation:Optional
@@ -53,15 +73,31 @@ type MagenticOneTeamConfig struct {
 }
 
 type SwarmTeamConfig struct {
+	// Whether to leave out the builtin planning agent, which starts the conversation and may hand off to
+	// every participant. Without it, the first participant starts the conversation and the participants
+	// hand off to each other along their handoffs only.
+	// +optional
+	DisablePlanningAgent bool `json:"disablePlanningAgent,omitempty"`
 }
 
 type TerminationCondition struct {
-	// ONEOF: maxMessageTermination, textMentionTermination, orTermination
+	// ONEOF: maxMessageTermination, textMentionTermination, textMessageTermination, stopMessageTermination,
+	// timeoutTermination, tokenUsageTermination, handoffTermination, sourceMatchTermination, andTermination, orTermination
 	MaxMessageTermination  *MaxMessageTermination  `json:"maxMessageTermination,omitempty"`
 	TextMentionTermination *TextMentionTermination `json:"textMentionTermination,omitempty"`
 	TextMessageTermination *TextMessageTermination `json:"textMessageTermination,omitempty"`
 	StopMessageTermination *StopMessageTermination `json:"stopMessageTermination,omitempty"`
-	OrTermination          *OrTermination          `json:"orTermination,omitempty"`
+	// +optional
+	TimeoutTermination *TimeoutTermination `json:"timeoutTermination,omitempty"`
+	// +optional
+	TokenUsageTermination *TokenUsageTermination `json:"tokenUsageTermination,omitempty"`
+	// +optional
+	HandoffTermination *HandoffTermination `json:"handoffTermination,omitempty"`
+	// +optional
+	SourceMatchTermination *SourceMatchTermination `json:"sourceMatchTermination,omitempty"`
+	// +optional
+	AndTermination *AndTermination `json:"andTermination,omitempty"`
+	OrTermination  *OrTermination  `json:"orTermination,omitempty"`
 }
 
 type MaxMessageTermination struct {
@@ -78,19 +114,70 @@ type TextMessageTermination struct {
 
 type StopMessageTermination struct{}
 
-type OrTermination struct {
-	Conditions []OrTerminationCondition `json:"conditions"`
+// TimeoutTermination terminates the conversation after the given duration, e.g. 10m.
+type TimeoutTermination struct {
+	Timeout metav1.Duration `json:"timeout"`
 }
 
-type OrTerminationCondition struct {
-	MaxMessageTermination  *MaxMessageTermination  `json:"maxMessageTermination,omitempty"`
-	TextMentionTermination *TextMentionTermination `json:"textMentionTermination,omitempty"`
+// TokenUsageTermination terminates the conversation once the model clients used more than the given
+// number of tokens. At least one of the limits must be set.
+// +kubebuilder:validation:XValidation:message="at least one token limit must be set",rule="has(self.maxTotalTokens) || has(self.maxPromptTokens) || has(self.maxCompletionTokens)"
+type TokenUsageTermination struct {
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTotalTokens *int `json:"maxTotalTokens,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPromptTokens *int `json:"maxPromptTokens,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxCompletionTokens *int `json:"maxCompletionTokens,omitempty"`
+}
+
+// HandoffTermination terminates the conversation when an agent hands off to the target,
+// which is usually the user.
+type HandoffTermination struct {
+	// +kubebuilder:validation:MinLength=1
+	Target string `json:"target"`
+}
+
+// SourceMatchTermination terminates the conversation once one of the given agents responded.
+type SourceMatchTermination struct {
+	// +kubebuilder:validation:MinItems=1
+	Sources []string `json:"sources"`
+}
+
+// AndTermination terminates the conversation once all of its conditions are met.
+type AndTermination struct {
+	// The conditions are termination conditions themselves, which can be nested. They are validated by the
+	// controller, as the schema of recursive types is not generated.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Type=object
+	// +kubebuilder:validation:items:XPreserveUnknownFields
+	Conditions []TerminationCondition `json:"conditions"`
+}
+
+// OrTermination terminates the conversation once any of its conditions is met.
+type OrTermination struct {
+	// The conditions are termination conditions themselves, which can be nested. They are validated by the
+	// controller, as the schema of recursive types is not generated.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Type=object
+	// +kubebuilder:validation:items:XPreserveUnknownFields
+	Conditions []TerminationCondition `json:"conditions"`
 }
 
 // TeamStatus defines the observed state of Team.
 type TeamStatus struct {
 	Conditions         []metav1.Condition `json:"conditions"`
 	ObservedGeneration int64              `json:"observedGeneration"`
+	// A hash of the autogen component last pushed to the backend.
+	// The backend is only updated when the translated component hash changes or the backend lost the component.
+	// +optional
+	ComponentHash string `json:"componentHash,omitempty"`
+	// The ID of the team in the backend the component was last pushed to.
+	// +optional
+	BackendTeamID int `json:"backendTeamID,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RemoteAgentConditionTypeAccepted = "Accepted"
+)
+
+// RemoteAgentSpec defines the desired state of RemoteAgent.
+type RemoteAgentSpec struct {
+	// The URL of the A2A server of the agent. The agent card is fetched from
+	// <url>/.well-known/agent.json
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+	// Overrides the description of the agent card, which is used as the tool description.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Authentication configures the credentials sent to the remote agent.
+	// +optional
+	Authentication *RemoteAgentAuthentication `json:"authentication,omitempty"`
+	// How often the agent card is re-fetched. Defaults to 5m.
+	// A value of 0 disables periodic fetching; the card is then only refreshed when the RemoteAgent changes.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+	// How long to wait for a task sent to the agent to complete. Defaults to 5m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type RemoteAgentAuthentication struct {
+	// Bearer sends a static bearer token stored in a Secret.
+	// +optional
+	Bearer *RemoteAgentBearerAuthentication `json:"bearer,omitempty"`
+}
+
+type RemoteAgentBearerAuthentication struct {
+	// The name of a Secret in the namespace of the RemoteAgent holding the token.
+	SecretRef string `json:"secretRef"`
+	// The key of the Secret holding the token.
+	// +kubebuilder:default=token
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+}
+
+// RemoteAgentCard is the agent card published by the remote agent.
+type RemoteAgentCard struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Version     string `json:"version,omitempty"`
+	// +optional
+	Skills []AgentSkill `json:"skills,omitempty"`
+	// +optional
+	Streaming bool `json:"streaming,omitempty"`
+	// +optional
+	DefaultInputModes []string `json:"defaultInputModes,omitempty"`
+	// +optional
+	DefaultOutputModes []string `json:"defaultOutputModes,omitempty"`
+	// The authentication schemes required by the agent.
+	// +optional
+	AuthenticationSchemes []string `json:"authenticationSchemes,omitempty"`
+}
+
+// RemoteAgentStatus defines the observed state of RemoteAgent.
+type RemoteAgentStatus struct {
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	// The agent card last fetched from the remote agent.
+	// It is kept if the agent can not be reached.
+	// +optional
+	AgentCard *RemoteAgentCard `json:"agentCard,omitempty"`
+	// The last time the agent card was fetched successfully.
+	// +optional
+	LastFetchedTime *metav1.Time `json:"lastFetchedTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ra
+// +kubebuilder:printcolumn:name="Accepted",type="string",JSONPath=".status.conditions[0].status",description="Whether or not the agent card could be fetched."
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.url",description="The URL of the remote agent."
+
+// RemoteAgent is the Schema for the remoteagents API.
+// It references an agent served by an A2A server outside of this cluster,
+// which agents can use as a tool.
+type RemoteAgent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteAgentSpec   `json:"spec,omitempty"`
+	Status RemoteAgentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteAgentList contains a list of RemoteAgent.
+type RemoteAgentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteAgent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RemoteAgent{}, &RemoteAgentList{})
+}
@@ -24,6 +24,11 @@ import (
 type ToolServerSpec struct {
 	Description string           `json:"description"`
 	Config      ToolServerConfig `json:"config"`
+	// How often the tools provided by the server are re-discovered. Defaults to 60s.
+	// A value of 0 disables periodic discovery; tools are then only refreshed when the ToolServer changes.
+	// Failed discoveries are retried with an exponential backoff regardless of this interval.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
 }
 
 type ToolServerConfig struct {
@@ -85,6 +90,12 @@ type ToolServerStatus struct {
 	Conditions         []metav1.Condition `json:"conditions"`
 	// +kubebuilder:validation:Optional
 	DiscoveredTools []*MCPTool `json:"discoveredTools"`
+	// A hash of the discovered tools, which changes whenever the set of tools provided by the server changes.
+	// +optional
+	ObservedToolsHash string `json:"observedToolsHash,omitempty"`
+	// The last time the set of discovered tools changed.
+	// +optional
+	ToolsLastChangedTime *metav1.Time `json:"toolsLastChangedTime,omitempty"`
 }
 
 type MCPTool struct {
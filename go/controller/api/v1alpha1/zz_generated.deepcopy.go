@@ -26,6 +26,51 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *A2AAuthentication) DeepCopyInto(out *A2AAuthentication) {
+	*out = *in
+	if in.Bearer != nil {
+		in, out := &in.Bearer, &out.Bearer
+		*out = new(A2ABearerAuthentication)
+		**out = **in
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(A2AServiceAccountAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = new(A2AMTLSAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2AAuthentication.
+func (in *A2AAuthentication) DeepCopy() *A2AAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(A2AAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *A2ABearerAuthentication) DeepCopyInto(out *A2ABearerAuthentication) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2ABearerAuthentication.
+func (in *A2ABearerAuthentication) DeepCopy() *A2ABearerAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(A2ABearerAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *A2AConfig) DeepCopyInto(out *A2AConfig) {
 	*out = *in
@@ -36,6 +81,21 @@ func (in *A2AConfig) DeepCopyInto(out *A2AConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(A2AAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultInputModes != nil {
+		in, out := &in.DefaultInputModes, &out.DefaultInputModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultOutputModes != nil {
+		in, out := &in.DefaultOutputModes, &out.DefaultOutputModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2AConfig.
@@ -48,6 +108,66 @@ func (in *A2AConfig) DeepCopy() *A2AConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *A2AMTLSAuthentication) DeepCopyInto(out *A2AMTLSAuthentication) {
+	*out = *in
+	if in.AllowedCommonNames != nil {
+		in, out := &in.AllowedCommonNames, &out.AllowedCommonNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2AMTLSAuthentication.
+func (in *A2AMTLSAuthentication) DeepCopy() *A2AMTLSAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(A2AMTLSAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *A2AServiceAccountAuthentication) DeepCopyInto(out *A2AServiceAccountAuthentication) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedServiceAccounts != nil {
+		in, out := &in.AllowedServiceAccounts, &out.AllowedServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2AServiceAccountAuthentication.
+func (in *A2AServiceAccountAuthentication) DeepCopy() *A2AServiceAccountAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(A2AServiceAccountAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeySecretReference) DeepCopyInto(out *APIKeySecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeySecretReference.
+func (in *APIKeySecretReference) DeepCopy() *APIKeySecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeySecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Agent) DeepCopyInto(out *Agent) {
 	*out = *in
@@ -171,6 +291,16 @@ func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Handoffs != nil {
+		in, out := &in.Handoffs, &out.Handoffs
+		*out = make([]Handoff, len(*in))
+		copy(*out, *in)
+	}
+	if in.ModelContext != nil {
+		in, out := &in.ModelContext, &out.ModelContext
+		*out = new(ModelContext)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.A2AConfig != nil {
 		in, out := &in.A2AConfig, &out.A2AConfig
 		*out = new(A2AConfig)
@@ -225,6 +355,28 @@ func (in *AgentTool) DeepCopy() *AgentTool {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AndTermination) DeepCopyInto(out *AndTermination) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]TerminationCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AndTermination.
+func (in *AndTermination) DeepCopy() *AndTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(AndTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AnthropicConfig) DeepCopyInto(out *AnthropicConfig) {
 	*out = *in
@@ -280,6 +432,21 @@ func (in *AzureOpenAIConfig) DeepCopy() *AzureOpenAIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BufferedModelContext) DeepCopyInto(out *BufferedModelContext) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BufferedModelContext.
+func (in *BufferedModelContext) DeepCopy() *BufferedModelContext {
+	if in == nil {
+		return nil
+	}
+	out := new(BufferedModelContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuiltinTool) DeepCopyInto(out *BuiltinTool) {
 	*out = *in
@@ -324,6 +491,86 @@ func (in *Component) DeepCopy() *Component {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeminiConfig) DeepCopyInto(out *GeminiConfig) {
+	*out = *in
+	if in.SafetySettings != nil {
+		in, out := &in.SafetySettings, &out.SafetySettings
+		*out = make([]GeminiSafetySetting, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeminiConfig.
+func (in *GeminiConfig) DeepCopy() *GeminiConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GeminiConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeminiSafetySetting) DeepCopyInto(out *GeminiSafetySetting) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeminiSafetySetting.
+func (in *GeminiSafetySetting) DeepCopy() *GeminiSafetySetting {
+	if in == nil {
+		return nil
+	}
+	out := new(GeminiSafetySetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Handoff) DeepCopyInto(out *Handoff) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Handoff.
+func (in *Handoff) DeepCopy() *Handoff {
+	if in == nil {
+		return nil
+	}
+	out := new(Handoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HandoffTermination) DeepCopyInto(out *HandoffTermination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HandoffTermination.
+func (in *HandoffTermination) DeepCopy() *HandoffTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(HandoffTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeadAndTailModelContext) DeepCopyInto(out *HeadAndTailModelContext) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadAndTailModelContext.
+func (in *HeadAndTailModelContext) DeepCopy() *HeadAndTailModelContext {
+	if in == nil {
+		return nil
+	}
+	out := new(HeadAndTailModelContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPTool) DeepCopyInto(out *MCPTool) {
 	*out = *in
@@ -516,6 +763,40 @@ func (in *MemoryStatus) DeepCopy() *MemoryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MockConfig) DeepCopyInto(out *MockConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MockConfig.
+func (in *MockConfig) DeepCopy() *MockConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MockConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelClientHealth) DeepCopyInto(out *ModelClientHealth) {
+	*out = *in
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelClientHealth.
+func (in *ModelClientHealth) DeepCopy() *ModelClientHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelClientHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelConfig) DeepCopyInto(out *ModelConfig) {
 	*out = *in
@@ -578,6 +859,16 @@ func (in *ModelConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelConfigSpec) DeepCopyInto(out *ModelConfigSpec) {
 	*out = *in
+	if in.APIKeySecrets != nil {
+		in, out := &in.APIKeySecrets, &out.APIKeySecrets
+		*out = make([]APIKeySecretReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.DefaultHeaders != nil {
 		in, out := &in.DefaultHeaders, &out.DefaultHeaders
 		*out = make(map[string]string, len(*in))
@@ -610,6 +901,21 @@ func (in *ModelConfigSpec) DeepCopyInto(out *ModelConfigSpec) {
 		*out = new(OllamaConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Gemini != nil {
+		in, out := &in.Gemini, &out.Gemini
+		*out = new(GeminiConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenAICompatible != nil {
+		in, out := &in.OpenAICompatible, &out.OpenAICompatible
+		*out = new(OpenAICompatibleConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mock != nil {
+		in, out := &in.Mock, &out.Mock
+		*out = new(MockConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelConfigSpec.
@@ -632,6 +938,29 @@ func (in *ModelConfigStatus) DeepCopyInto(out *ModelConfigStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ModelInfo != nil {
+		in, out := &in.ModelInfo, &out.ModelInfo
+		*out = new(ModelInfo)
+		**out = **in
+	}
+	if in.LastProbedTime != nil {
+		in, out := &in.LastProbedTime, &out.LastProbedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.APIKeys != nil {
+		in, out := &in.APIKeys, &out.APIKeys
+		*out = make([]ModelClientHealth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]ModelClientHealth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelConfigStatus.
@@ -644,6 +973,36 @@ func (in *ModelConfigStatus) DeepCopy() *ModelConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelContext) DeepCopyInto(out *ModelContext) {
+	*out = *in
+	if in.Buffered != nil {
+		in, out := &in.Buffered, &out.Buffered
+		*out = new(BufferedModelContext)
+		**out = **in
+	}
+	if in.HeadAndTail != nil {
+		in, out := &in.HeadAndTail, &out.HeadAndTail
+		*out = new(HeadAndTailModelContext)
+		**out = **in
+	}
+	if in.TokenLimited != nil {
+		in, out := &in.TokenLimited, &out.TokenLimited
+		*out = new(TokenLimitedModelContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelContext.
+func (in *ModelContext) DeepCopy() *ModelContext {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelInfo) DeepCopyInto(out *ModelInfo) {
 	*out = *in
@@ -681,6 +1040,26 @@ func (in *OllamaConfig) DeepCopy() *OllamaConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAICompatibleConfig) DeepCopyInto(out *OpenAICompatibleConfig) {
+	*out = *in
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAICompatibleConfig.
+func (in *OpenAICompatibleConfig) DeepCopy() *OpenAICompatibleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAICompatibleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenAIConfig) DeepCopyInto(out *OpenAIConfig) {
 	*out = *in
@@ -716,7 +1095,7 @@ func (in *OrTermination) DeepCopyInto(out *OrTermination) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]OrTerminationCondition, len(*in))
+		*out = make([]TerminationCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -734,46 +1113,228 @@ func (in *OrTermination) DeepCopy() *OrTermination {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OrTerminationCondition) DeepCopyInto(out *OrTerminationCondition) {
+func (in *PineconeConfig) DeepCopyInto(out *PineconeConfig) {
 	*out = *in
-	if in.MaxMessageTermination != nil {
-		in, out := &in.MaxMessageTermination, &out.MaxMessageTermination
-		*out = new(MaxMessageTermination)
-		**out = **in
+	if in.RecordFields != nil {
+		in, out := &in.RecordFields, &out.RecordFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.TextMentionTermination != nil {
-		in, out := &in.TextMentionTermination, &out.TextMentionTermination
-		*out = new(TextMentionTermination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PineconeConfig.
+func (in *PineconeConfig) DeepCopy() *PineconeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PineconeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgent) DeepCopyInto(out *RemoteAgent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgent.
+func (in *RemoteAgent) DeepCopy() *RemoteAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteAgent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentAuthentication) DeepCopyInto(out *RemoteAgentAuthentication) {
+	*out = *in
+	if in.Bearer != nil {
+		in, out := &in.Bearer, &out.Bearer
+		*out = new(RemoteAgentBearerAuthentication)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrTerminationCondition.
-func (in *OrTerminationCondition) DeepCopy() *OrTerminationCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentAuthentication.
+func (in *RemoteAgentAuthentication) DeepCopy() *RemoteAgentAuthentication {
 	if in == nil {
 		return nil
 	}
-	out := new(OrTerminationCondition)
+	out := new(RemoteAgentAuthentication)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PineconeConfig) DeepCopyInto(out *PineconeConfig) {
+func (in *RemoteAgentBearerAuthentication) DeepCopyInto(out *RemoteAgentBearerAuthentication) {
 	*out = *in
-	if in.RecordFields != nil {
-		in, out := &in.RecordFields, &out.RecordFields
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentBearerAuthentication.
+func (in *RemoteAgentBearerAuthentication) DeepCopy() *RemoteAgentBearerAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentBearerAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentCard) DeepCopyInto(out *RemoteAgentCard) {
+	*out = *in
+	if in.Skills != nil {
+		in, out := &in.Skills, &out.Skills
+		*out = make([]AgentSkill, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultInputModes != nil {
+		in, out := &in.DefaultInputModes, &out.DefaultInputModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultOutputModes != nil {
+		in, out := &in.DefaultOutputModes, &out.DefaultOutputModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuthenticationSchemes != nil {
+		in, out := &in.AuthenticationSchemes, &out.AuthenticationSchemes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PineconeConfig.
-func (in *PineconeConfig) DeepCopy() *PineconeConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentCard.
+func (in *RemoteAgentCard) DeepCopy() *RemoteAgentCard {
 	if in == nil {
 		return nil
 	}
-	out := new(PineconeConfig)
+	out := new(RemoteAgentCard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentList) DeepCopyInto(out *RemoteAgentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentList.
+func (in *RemoteAgentList) DeepCopy() *RemoteAgentList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteAgentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentSpec) DeepCopyInto(out *RemoteAgentSpec) {
+	*out = *in
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(RemoteAgentAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentSpec.
+func (in *RemoteAgentSpec) DeepCopy() *RemoteAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentStatus) DeepCopyInto(out *RemoteAgentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AgentCard != nil {
+		in, out := &in.AgentCard, &out.AgentCard
+		*out = new(RemoteAgentCard)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastFetchedTime != nil {
+		in, out := &in.LastFetchedTime, &out.LastFetchedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentStatus.
+func (in *RemoteAgentStatus) DeepCopy() *RemoteAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentTool) DeepCopyInto(out *RemoteAgentTool) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentTool.
+func (in *RemoteAgentTool) DeepCopy() *RemoteAgentTool {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentTool)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -808,6 +1369,26 @@ func (in *SelectorTeamConfig) DeepCopy() *SelectorTeamConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceMatchTermination) DeepCopyInto(out *SourceMatchTermination) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceMatchTermination.
+func (in *SourceMatchTermination) DeepCopy() *SourceMatchTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceMatchTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SseMcpServerConfig) DeepCopyInto(out *SseMcpServerConfig) {
 	*out = *in
@@ -1046,6 +1627,31 @@ func (in *TerminationCondition) DeepCopyInto(out *TerminationCondition) {
 		*out = new(StopMessageTermination)
 		**out = **in
 	}
+	if in.TimeoutTermination != nil {
+		in, out := &in.TimeoutTermination, &out.TimeoutTermination
+		*out = new(TimeoutTermination)
+		**out = **in
+	}
+	if in.TokenUsageTermination != nil {
+		in, out := &in.TokenUsageTermination, &out.TokenUsageTermination
+		*out = new(TokenUsageTermination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HandoffTermination != nil {
+		in, out := &in.HandoffTermination, &out.HandoffTermination
+		*out = new(HandoffTermination)
+		**out = **in
+	}
+	if in.SourceMatchTermination != nil {
+		in, out := &in.SourceMatchTermination, &out.SourceMatchTermination
+		*out = new(SourceMatchTermination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AndTermination != nil {
+		in, out := &in.AndTermination, &out.AndTermination
+		*out = new(AndTermination)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.OrTermination != nil {
 		in, out := &in.OrTermination, &out.OrTermination
 		*out = new(OrTermination)
@@ -1093,6 +1699,72 @@ func (in *TextMessageTermination) DeepCopy() *TextMessageTermination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeoutTermination) DeepCopyInto(out *TimeoutTermination) {
+	*out = *in
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeoutTermination.
+func (in *TimeoutTermination) DeepCopy() *TimeoutTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeoutTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenLimitedModelContext) DeepCopyInto(out *TokenLimitedModelContext) {
+	*out = *in
+	if in.TokenLimit != nil {
+		in, out := &in.TokenLimit, &out.TokenLimit
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenLimitedModelContext.
+func (in *TokenLimitedModelContext) DeepCopy() *TokenLimitedModelContext {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenLimitedModelContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenUsageTermination) DeepCopyInto(out *TokenUsageTermination) {
+	*out = *in
+	if in.MaxTotalTokens != nil {
+		in, out := &in.MaxTotalTokens, &out.MaxTotalTokens
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxPromptTokens != nil {
+		in, out := &in.MaxPromptTokens, &out.MaxPromptTokens
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxCompletionTokens != nil {
+		in, out := &in.MaxCompletionTokens, &out.MaxCompletionTokens
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenUsageTermination.
+func (in *TokenUsageTermination) DeepCopy() *TokenUsageTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenUsageTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tool) DeepCopyInto(out *Tool) {
 	*out = *in
@@ -1111,6 +1783,11 @@ func (in *Tool) DeepCopyInto(out *Tool) {
 		*out = new(AgentTool)
 		**out = **in
 	}
+	if in.RemoteAgent != nil {
+		in, out := &in.RemoteAgent, &out.RemoteAgent
+		*out = new(RemoteAgentTool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tool.
@@ -1211,6 +1888,11 @@ func (in *ToolServerList) DeepCopyObject() runtime.Object {
 func (in *ToolServerSpec) DeepCopyInto(out *ToolServerSpec) {
 	*out = *in
 	in.Config.DeepCopyInto(&out.Config)
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolServerSpec.
@@ -1244,6 +1926,10 @@ func (in *ToolServerStatus) DeepCopyInto(out *ToolServerStatus) {
 			}
 		}
 	}
+	if in.ToolsLastChangedTime != nil {
+		in, out := &in.ToolsLastChangedTime, &out.ToolsLastChangedTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolServerStatus.
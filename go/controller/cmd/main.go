@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
@@ -41,13 +42,17 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -56,6 +61,7 @@ import (
 
 	agentv1alpha1 "github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
 	"github.com/kagent-dev/kagent/go/controller/internal/controller"
+	webhookv1alpha1 "github.com/kagent-dev/kagent/go/controller/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -87,8 +93,17 @@ func main() {
 	var defaultModelConfig types.NamespacedName
 	var tlsOpts []func(*tls.Config)
 	var httpServerAddr string
+	var runtimeServerAddr string
 	var watchNamespaces string
 	var a2aBaseUrl string
+	var a2aTaskStore string
+	var a2aTaskStorePath string
+	var a2aTaskTTL time.Duration
+	var modelHealthCheckInterval time.Duration
+	var a2aPushAllowedHosts string
+	var a2aPushConfig a2a.PushNotifierConfig
+	var a2aTLSConfig httpserver.A2ATLSConfig
+	var enableWebhooks bool
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -107,13 +122,42 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, the validating admission webhooks for kagent resources will be served. "+
+			"Requires a serving certificate, see --webhook-cert-path.")
 
 	flag.StringVar(&autogenStudioBaseURL, "autogen-base-url", "http://127.0.0.1:8081/api", "The base url of the Autogen Studio server.")
 
 	flag.StringVar(&defaultModelConfig.Name, "default-model-config-name", "default-model-config", "The name of the default model config.")
 	flag.StringVar(&defaultModelConfig.Namespace, "default-model-config-namespace", kagentNamespace, "The namespace of the default model config.")
 	flag.StringVar(&httpServerAddr, "http-server-address", ":8083", "The address the HTTP server binds to.")
+	flag.StringVar(&runtimeServerAddr, "runtime-server-address", "127.0.0.1:8084",
+		"The address the HTTP server for the agent runtime binds to. Must be a loopback address, as its endpoints act with the permissions of the controller.")
 	flag.StringVar(&a2aBaseUrl, "a2a-base-url", "http://127.0.0.1:8083", "The base URL of the A2A Server endpoint, as advertised to clients.")
+	flag.DurationVar(&modelHealthCheckInterval, "model-health-check-interval", 5*time.Minute,
+		"The interval at which the API keys and fallbacks of model configs are checked against their providers. 0 disables the checks.")
+	flag.StringVar(&a2aTaskStore, "a2a-task-store", string(a2a.MemoryTaskStoreType),
+		"The backend used to persist A2A tasks. One of memory, configmap or file.")
+	flag.StringVar(&a2aTaskStorePath, "a2a-task-store-path", "/var/lib/kagent/a2a-tasks",
+		"The directory used to persist A2A tasks when --a2a-task-store=file.")
+	flag.DurationVar(&a2aTaskTTL, "a2a-task-ttl", 24*time.Hour,
+		"Stored A2A tasks are deleted once they were not updated for this duration. 0 keeps them until their agent is deleted.")
+	flag.StringVar(&a2aTLSConfig.BindAddr, "a2a-tls-bind-address", "",
+		"The address the A2A TLS listener binds to. Required for mTLS authentication of A2A clients. Disabled if empty.")
+	flag.StringVar(&a2aTLSConfig.CertFile, "a2a-tls-cert-file", "", "The serving certificate of the A2A TLS listener.")
+	flag.StringVar(&a2aTLSConfig.KeyFile, "a2a-tls-key-file", "", "The serving key of the A2A TLS listener.")
+	flag.StringVar(&a2aTLSConfig.ClientCAFile, "a2a-tls-client-ca-file", "",
+		"The CA bundle used to verify A2A client certificates.")
+	flag.StringVar(&a2aPushAllowedHosts, "a2a-push-allowed-hosts", "",
+		"Comma separated hosts A2A push notifications may be sent to, e.g. hooks.example.com or *.example.com. "+
+			"Push notifications are disabled if empty.")
+	flag.IntVar(&a2aPushConfig.MaxAttempts, "a2a-push-max-attempts", 5, "The number of delivery attempts per A2A push notification.")
+	flag.DurationVar(&a2aPushConfig.InitialBackoff, "a2a-push-initial-backoff", time.Second,
+		"The delay before the first retry of an A2A push notification, doubled on every further retry.")
+	flag.DurationVar(&a2aPushConfig.Timeout, "a2a-push-timeout", 10*time.Second, "The timeout of a single A2A push notification attempt.")
+	flag.StringVar(&a2aPushConfig.SigningKeyPath, "a2a-push-signing-key-path", "",
+		"The PEM encoded RSA private key used to sign A2A push notifications. "+
+			"Must be shared by all replicas. A key is generated if empty.")
 
 	flag.StringVar(&watchNamespaces, "watch-namespaces", "", "The namespaces to watch for .")
 
@@ -223,6 +267,10 @@ func main() {
 		LeaderElectionID:       "0e9f6799.kagent.dev",
 		Cache: cache.Options{
 			DefaultNamespaces: ConfigureNamespaceWatching(watchNamespaces),
+			ByObject: map[client.Object]cache.ByObject{
+				// A2A tasks are stored in ConfigMaps, which change far too often to be cached
+				&corev1.ConfigMap{}: {Label: withoutA2ATaskConfigMaps()},
+			},
 		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
@@ -250,34 +298,95 @@ func main() {
 	)
 
 	// wait for autogen to become ready on port 8081 before starting the manager
-	if err := waitForAutogenReady(setupLog, autogenClient, time.Minute*5, time.Second*5); err != nil {
+	if err := waitForAutogenReady(context.Background(), setupLog, autogenClient, time.Minute*5, time.Second*5); err != nil {
 		setupLog.Error(err, "failed to wait for autogen to become ready")
 		os.Exit(1)
 	}
 
 	kubeClient := mgr.GetClient()
 
+	if err := autogen.SetupIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to set up field indexes")
+		os.Exit(1)
+	}
+
 	apiTranslator := autogen.NewAutogenApiTranslator(
 		kubeClient,
 		defaultModelConfig,
 	)
 
-	a2aHandler := a2a.NewA2AHttpMux(httpserver.APIPathA2A)
+	var taskStore a2a.TaskStore
+	switch a2a.TaskStoreType(a2aTaskStore) {
+	case a2a.MemoryTaskStoreType:
+		taskStore = a2a.NewMemoryTaskStore()
+	case a2a.ConfigMapTaskStoreType:
+		// task ConfigMaps are excluded from the cache, so they are read directly from the API server
+		taskStore = a2a.NewConfigMapTaskStore(mgr.GetAPIReader(), kubeClient)
+	case a2a.FileTaskStoreType:
+		taskStore, err = a2a.NewFileTaskStore(a2aTaskStorePath)
+		if err != nil {
+			setupLog.Error(err, "unable to create a2a task store")
+			os.Exit(1)
+		}
+	default:
+		setupLog.Error(fmt.Errorf("unknown a2a task store %s", a2aTaskStore), "unable to create a2a task store")
+		os.Exit(1)
+	}
+
+	if a2aTaskTTL > 0 {
+		if err := mgr.Add(a2a.NewTaskGarbageCollector(taskStore, a2aTaskTTL)); err != nil {
+			setupLog.Error(err, "unable to add a2a task garbage collector to manager")
+			os.Exit(1)
+		}
+	}
+
+	if a2aPushAllowedHosts != "" {
+		a2aPushConfig.AllowedHosts = strings.Split(a2aPushAllowedHosts, ",")
+	}
+	pushNotifier, err := a2a.NewPushNotifier(a2aPushConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to create a2a push notifier")
+		os.Exit(1)
+	}
+	if err := mgr.Add(pushNotifier); err != nil {
+		setupLog.Error(err, "unable to add a2a push notifier to manager")
+		os.Exit(1)
+	}
+
+	a2aHandler := a2a.NewA2AHttpMux(httpserver.APIPathA2A, taskStore, pushNotifier)
 
 	a2aReconciler := a2a.NewAutogenReconciler(
 		autogenClient,
+		kubeClient,
 		a2aHandler,
 		a2aBaseUrl+httpserver.APIPathA2A,
+		taskStore,
 	)
 
 	autogenReconciler := autogen.NewAutogenReconciler(
 		apiTranslator,
 		kubeClient,
 		autogenClient,
+		a2a.NewRemoteAgentClient(kubeClient),
 		defaultModelConfig,
-		a2aReconciler,
 	)
 
+	if modelHealthCheckInterval > 0 {
+		if err := mgr.Add(autogen.NewModelHealthChecker(kubeClient, modelHealthCheckInterval)); err != nil {
+			setupLog.Error(err, "unable to add model health checker to manager")
+			os.Exit(1)
+		}
+	}
+
+	// runs on every replica, as every replica serves A2A requests
+	if err = (&controller.A2AAgentReconciler{
+		Client:     kubeClient,
+		Scheme:     mgr.GetScheme(),
+		Reconciler: a2aReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "A2AAgent")
+		os.Exit(1)
+	}
 	if err = (&controller.AutogenTeamReconciler{
 		Client:     kubeClient,
 		Scheme:     mgr.GetScheme(),
@@ -326,6 +435,40 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Memory")
 		os.Exit(1)
 	}
+	if err = (&controller.RemoteAgentReconciler{
+		Client:     kubeClient,
+		Scheme:     mgr.GetScheme(),
+		Reconciler: autogenReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RemoteAgent")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err = webhookv1alpha1.SetupAgentWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Agent")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupTeamWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Team")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupModelConfigWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ModelConfig")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupToolServerWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ToolServer")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupMemoryWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Memory")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupRemoteAgentWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RemoteAgent")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
@@ -352,12 +495,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	httpServer := httpserver.NewHTTPServer(httpserver.ServerConfig{
-		BindAddr:      httpServerAddr,
-		AutogenClient: autogenClient,
-		KubeClient:    kubeClient,
-		A2AHandler:    a2aHandler,
-	})
+	httpServerConfig := httpserver.ServerConfig{
+		BindAddr:        httpServerAddr,
+		RuntimeBindAddr: runtimeServerAddr,
+		AutogenClient:   autogenClient,
+		KubeClient:      kubeClient,
+		A2AHandler:      a2aHandler,
+	}
+	if a2aTLSConfig.BindAddr != "" {
+		httpServerConfig.A2ATLS = &a2aTLSConfig
+	}
+	httpServer := httpserver.NewHTTPServer(httpServerConfig)
 	if err := mgr.Add(httpServer); err != nil {
 		setupLog.Error(err, "unable to set up HTTP server")
 		os.Exit(1)
@@ -371,13 +519,14 @@ func main() {
 }
 
 func waitForAutogenReady(
+	ctx context.Context,
 	log logr.Logger,
 	client autogen_client.Client,
 	timeout, interval time.Duration,
 ) error {
 	log.Info("waiting for autogen to become ready")
 	return waitForReady(func() error {
-		version, err := client.GetVersion()
+		version, err := client.GetVersion(ctx)
 		if err != nil {
 			log.Error(err, "autogen is not ready")
 			return err
@@ -401,6 +550,15 @@ func waitForReady(f func() error, timeout, interval time.Duration) error {
 	}
 }
 
+// withoutA2ATaskConfigMaps selects all ConfigMaps except the ones holding A2A tasks.
+func withoutA2ATaskConfigMaps() labels.Selector {
+	requirement, err := labels.NewRequirement(a2a.TaskStoreAgentLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		panic(err)
+	}
+	return labels.NewSelector().Add(*requirement)
+}
+
 // ConfigureNamespaceWatching sets up the controller manager to watch specific namespaces
 // based on the provided configuration. It returns the list of namespaces being watched,
 // or nil if watching all namespaces.
@@ -0,0 +1,228 @@
+package a2a
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"trpc.group/trpc-go/trpc-a2a-go/auth"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+// The authentication schemes published in the agent card.
+const (
+	BearerAuthScheme         = "bearer"
+	ServiceAccountAuthScheme = "kubernetes-serviceaccount"
+	MTLSAuthScheme           = "mtls"
+
+	defaultBearerSecretKey = "token"
+	tokenReviewCacheTTL    = time.Minute
+	serviceAccountPrefix   = "system:serviceaccount:"
+)
+
+var (
+	errMissingBearerToken = errors.New("missing bearer token")
+)
+
+// translateAuthentication returns the provider enforcing the authentication of an agent,
+// along with the schemes to publish in its card. It returns nil if authentication is not configured.
+func translateAuthentication(
+	kube client.Client,
+	agentNamespace string,
+	authentication *v1alpha1.A2AAuthentication,
+) (auth.Provider, *protocol.AuthenticationInfo) {
+	if authentication == nil {
+		return nil, nil
+	}
+
+	var providers []auth.Provider
+	var schemes []string
+	if authentication.Bearer != nil {
+		secretKey := authentication.Bearer.SecretKey
+		if secretKey == "" {
+			secretKey = defaultBearerSecretKey
+		}
+		providers = append(providers, &secretBearerProvider{
+			kube:      kube,
+			namespace: agentNamespace,
+			name:      authentication.Bearer.SecretRef,
+			key:       secretKey,
+		})
+		schemes = append(schemes, BearerAuthScheme)
+	}
+	if authentication.ServiceAccount != nil {
+		providers = append(providers, &serviceAccountProvider{
+			kube:            kube,
+			audiences:       authentication.ServiceAccount.Audiences,
+			allowedAccounts: authentication.ServiceAccount.AllowedServiceAccounts,
+			reviews:         make(map[[sha256.Size]byte]tokenReviewResult),
+		})
+		schemes = append(schemes, ServiceAccountAuthScheme)
+	}
+	if authentication.MTLS != nil {
+		providers = append(providers, &mtlsProvider{
+			allowedCommonNames: authentication.MTLS.AllowedCommonNames,
+		})
+		schemes = append(schemes, MTLSAuthScheme)
+	}
+
+	if len(providers) == 0 {
+		// an empty authentication block denies every request rather than silently allowing them
+		providers = append(providers, denyProvider{})
+	}
+
+	return auth.NewChainAuthProvider(providers...), &protocol.AuthenticationInfo{
+		Schemes: schemes,
+	}
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return "", errMissingBearerToken
+	}
+	return token, nil
+}
+
+// secretBearerProvider accepts the tokens stored in a Secret.
+// The Secret is read on every request, so that rotated tokens take effect immediately.
+type secretBearerProvider struct {
+	kube      client.Client
+	namespace string
+	name      string
+	key       string
+}
+
+func (p *secretBearerProvider) Authenticate(r *http.Request) (*auth.User, error) {
+	token, err := bearerToken(r)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	if err := p.kube.Get(r.Context(), client.ObjectKey{Namespace: p.namespace, Name: p.name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get token secret %s/%s: %w", p.namespace, p.name, err)
+	}
+
+	for _, accepted := range strings.Split(string(secret.Data[p.key]), "\n") {
+		accepted = strings.TrimSpace(accepted)
+		if accepted != "" && subtle.ConstantTimeCompare([]byte(accepted), []byte(token)) == 1 {
+			return &auth.User{ID: "bearer:" + p.name}, nil
+		}
+	}
+
+	return nil, errors.New("invalid bearer token")
+}
+
+type tokenReviewResult struct {
+	username string
+	expires  time.Time
+}
+
+// serviceAccountProvider accepts ServiceAccount tokens, validated via TokenReview.
+// Successful reviews are cached briefly to avoid a review for every request.
+type serviceAccountProvider struct {
+	kube            client.Client
+	audiences       []string
+	allowedAccounts []string
+
+	lock    sync.Mutex
+	reviews map[[sha256.Size]byte]tokenReviewResult
+}
+
+func (p *serviceAccountProvider) Authenticate(r *http.Request) (*auth.User, error) {
+	token, err := bearerToken(r)
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := p.review(r, token)
+	if err != nil {
+		return nil, err
+	}
+
+	account, ok := strings.CutPrefix(username, serviceAccountPrefix)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service account", username)
+	}
+	// usernames are system:serviceaccount:<namespace>:<name>
+	account = strings.Replace(account, ":", "/", 1)
+	if len(p.allowedAccounts) > 0 && !slices.Contains(p.allowedAccounts, account) {
+		return nil, fmt.Errorf("service account %s is not allowed", account)
+	}
+
+	return &auth.User{ID: username}, nil
+}
+
+func (p *serviceAccountProvider) review(r *http.Request, token string) (string, error) {
+	key := sha256.Sum256([]byte(token))
+
+	p.lock.Lock()
+	cached, ok := p.reviews[key]
+	p.lock.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.username, nil
+	}
+
+	review := &authv1.TokenReview{
+		Spec: authv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: p.audiences,
+		},
+	}
+	if err := p.kube.Create(r.Context(), review); err != nil {
+		return "", fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return "", fmt.Errorf("token is not authenticated: %s", review.Status.Error)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := time.Now()
+	for k, v := range p.reviews {
+		if now.After(v.expires) {
+			delete(p.reviews, k)
+		}
+	}
+	p.reviews[key] = tokenReviewResult{
+		username: review.Status.User.Username,
+		expires:  now.Add(tokenReviewCacheTTL),
+	}
+
+	return review.Status.User.Username, nil
+}
+
+// mtlsProvider accepts client certificates verified by the TLS listener.
+type mtlsProvider struct {
+	allowedCommonNames []string
+}
+
+func (p *mtlsProvider) Authenticate(r *http.Request) (*auth.User, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, errors.New("missing verified client certificate")
+	}
+
+	commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
+	if len(p.allowedCommonNames) > 0 && !slices.Contains(p.allowedCommonNames, commonName) {
+		return nil, fmt.Errorf("client certificate %s is not allowed", commonName)
+	}
+
+	return &auth.User{ID: "mtls:" + commonName}, nil
+}
+
+type denyProvider struct{}
+
+func (denyProvider) Authenticate(*http.Request) (*auth.User, error) {
+	return nil, errors.New("no authentication scheme configured")
+}
@@ -0,0 +1,73 @@
+package a2a
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTranslateAuthentication(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "a2a-tokens",
+			Namespace: "kagent",
+		},
+		Data: map[string][]byte{
+			"token": []byte("first-token\nsecond-token\n"),
+		},
+	}).Build()
+
+	provider, info := translateAuthentication(kubeClient, "kagent", &v1alpha1.A2AAuthentication{
+		Bearer: &v1alpha1.A2ABearerAuthentication{
+			SecretRef: "a2a-tokens",
+		},
+		MTLS: &v1alpha1.A2AMTLSAuthentication{
+			AllowedCommonNames: []string{"incident-bot"},
+		},
+	})
+	require.NotNil(t, provider)
+	assert.Equal(t, []string{BearerAuthScheme, MTLSAuthScheme}, info.Schemes)
+
+	t.Run("should accept tokens from the secret", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.Header.Set("Authorization", "Bearer second-token")
+		_, err := provider.Authenticate(r)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject unknown tokens", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.Header.Set("Authorization", "Bearer other-token")
+		_, err := provider.Authenticate(r)
+		assert.Error(t, err)
+	})
+
+	t.Run("should accept allowed client certificates", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "incident-bot"}}}},
+		}
+		_, err := provider.Authenticate(r)
+		assert.NoError(t, err)
+
+		r.TLS.VerifiedChains[0][0].Subject.CommonName = "other-bot"
+		_, err = provider.Authenticate(r)
+		assert.Error(t, err)
+	})
+
+	t.Run("should not require authentication if it is not configured", func(t *testing.T) {
+		provider, info := translateAuthentication(kubeClient, "kagent", nil)
+		assert.Nil(t, provider)
+		assert.Nil(t, info)
+	})
+}
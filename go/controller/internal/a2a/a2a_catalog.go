@@ -0,0 +1,119 @@
+package a2a
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+)
+
+// AgentCatalogPath is the path of the catalog of all agents exposing A2A, relative to the A2A base path.
+// Like the agent cards it lists, the catalog is served unauthenticated.
+const AgentCatalogPath = "/.well-known/agents.json"
+
+// The query parameters filtering the agent catalog.
+const (
+	catalogNamespaceParam = "namespace"
+	catalogSkillTagParam  = "skillTag"
+	catalogInputModeParam = "inputMode"
+)
+
+// AgentCatalog lists the cards of the agents exposing A2A.
+type AgentCatalog struct {
+	Agents []AgentCatalogEntry `json:"agents"`
+}
+
+type AgentCatalogEntry struct {
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+	Card      server.AgentCard `json:"card"`
+}
+
+// AgentCatalogFilter selects the agents listed in the catalog. Empty fields match every agent.
+type AgentCatalogFilter struct {
+	Namespace string
+	// SkillTag matches agents with a skill carrying the tag.
+	SkillTag string
+	// InputMode matches agents with a skill accepting the input mode.
+	// Skills without input modes accept the default input modes of the agent.
+	InputMode string
+}
+
+func agentCatalogFilterFromRequest(r *http.Request) AgentCatalogFilter {
+	query := r.URL.Query()
+	return AgentCatalogFilter{
+		Namespace: query.Get(catalogNamespaceParam),
+		SkillTag:  query.Get(catalogSkillTagParam),
+		InputMode: query.Get(catalogInputModeParam),
+	}
+}
+
+func (f AgentCatalogFilter) matches(agentNamespace string, card *server.AgentCard) bool {
+	if f.Namespace != "" && f.Namespace != agentNamespace {
+		return false
+	}
+	if f.SkillTag == "" && f.InputMode == "" {
+		return true
+	}
+
+	// both the tag and the input mode have to match the same skill
+	return slices.ContainsFunc(card.Skills, func(skill server.AgentSkill) bool {
+		if f.SkillTag != "" && !slices.Contains(skill.Tags, f.SkillTag) {
+			return false
+		}
+		if f.InputMode != "" {
+			inputModes := skill.InputModes
+			if len(inputModes) == 0 {
+				inputModes = card.DefaultInputModes
+			}
+			if !slices.Contains(inputModes, f.InputMode) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// ListAgentCards returns the cards of the agents matching the filter, ordered by namespace and name.
+func (a *handlerMux) ListAgentCards(filter AgentCatalogFilter) []AgentCatalogEntry {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	entries := []AgentCatalogEntry{}
+	for handlerName, card := range a.cards {
+		agentNamespace, agentName, _ := strings.Cut(handlerName, "/")
+		if !filter.matches(agentNamespace, &card) {
+			continue
+		}
+		entries = append(entries, AgentCatalogEntry{
+			Namespace: agentNamespace,
+			Name:      agentName,
+			Card:      card,
+		})
+	}
+	slices.SortFunc(entries, func(a, b AgentCatalogEntry) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return entries
+}
+
+func (a *handlerMux) serveAgentCatalog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	catalog := AgentCatalog{
+		Agents: a.ListAgentCards(agentCatalogFilterFromRequest(r)),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(catalog); err != nil {
+		http.Error(w, "Failed to encode agent catalog", http.StatusInternalServerError)
+	}
+}
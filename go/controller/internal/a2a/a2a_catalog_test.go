@@ -0,0 +1,61 @@
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+)
+
+func TestAgentCatalog(t *testing.T) {
+	pushNotifier, err := NewPushNotifier(PushNotifierConfig{})
+	require.NoError(t, err)
+	mux := NewA2AHttpMux("/api/a2a", NewMemoryTaskStore(), pushNotifier)
+
+	handleTask := func(context.Context, string, *string, func(TaskUpdate)) (*TaskResult, error) {
+		return &TaskResult{}, nil
+	}
+	setAgent := func(namespace, name string, skills ...server.AgentSkill) {
+		require.NoError(t, mux.SetAgentHandler(namespace, name, &A2AHandlerParams{
+			AgentCard: server.AgentCard{
+				Name:              name,
+				URL:               "http://kagent/api/a2a/" + namespace + "/" + name,
+				DefaultInputModes: []string{"text"},
+				Skills:            skills,
+			},
+			HandleTask: handleTask,
+		}))
+	}
+	setAgent("kagent", "k8s-agent", server.AgentSkill{ID: "k8s", Tags: []string{"kubernetes"}})
+	setAgent("kagent", "image-agent", server.AgentSkill{ID: "images", Tags: []string{"vision"}, InputModes: []string{"file"}})
+	setAgent("team-a", "helm-agent", server.AgentSkill{ID: "helm", Tags: []string{"kubernetes", "helm"}})
+	setAgent("team-a", "removed-agent", server.AgentSkill{ID: "removed", Tags: []string{"kubernetes"}})
+	mux.RemoveAgentHandler("team-a", "removed-agent")
+
+	listAgents := func(query string) []string {
+		req := httptest.NewRequest(http.MethodGet, "/api/a2a"+AgentCatalogPath+query, nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var catalog AgentCatalog
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &catalog))
+		names := []string{}
+		for _, entry := range catalog.Agents {
+			names = append(names, entry.Namespace+"/"+entry.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"kagent/image-agent", "kagent/k8s-agent", "team-a/helm-agent"}, listAgents(""))
+	assert.Equal(t, []string{"team-a/helm-agent"}, listAgents("?namespace=team-a"))
+	assert.Equal(t, []string{"kagent/k8s-agent", "team-a/helm-agent"}, listAgents("?skillTag=kubernetes"))
+	assert.Equal(t, []string{"kagent/k8s-agent"}, listAgents("?skillTag=kubernetes&namespace=kagent"))
+	assert.Equal(t, []string{"kagent/image-agent"}, listAgents("?inputMode=file"))
+	assert.Equal(t, []string{}, listAgents("?skillTag=vision&inputMode=text"))
+}
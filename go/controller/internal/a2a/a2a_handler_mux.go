@@ -5,13 +5,18 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+
+	"trpc.group/trpc-go/trpc-a2a-go/auth"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 	"trpc.group/trpc-go/trpc-a2a-go/server"
-	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
 )
 
 type A2AHandlerParams struct {
 	AgentCard  server.AgentCard
 	HandleTask TaskHandler
+	// AuthProvider authenticates requests to the agent, if set.
+	// The agent card is always served unauthenticated.
+	AuthProvider auth.Provider
 }
 
 // A2AHandlerMux is an interface that defines methods for adding, getting, and removing agentic task handlers.
@@ -25,20 +30,30 @@ type A2AHandlerMux interface {
 		agentNamespace string,
 		agentName string,
 	)
+	// ListAgentCards returns the cards of the agents matching the filter.
+	ListAgentCards(filter AgentCatalogFilter) []AgentCatalogEntry
 	http.Handler
 }
 
 type handlerMux struct {
 	handlers       map[string]http.Handler
+	cards          map[string]server.AgentCard
+	taskManagers   map[string]*storeTaskManager
+	taskStore      TaskStore
+	pushNotifier   *PushNotifier
 	lock           sync.RWMutex
 	basePathPrefix string
 }
 
 var _ A2AHandlerMux = &handlerMux{}
 
-func NewA2AHttpMux(pathPrefix string) *handlerMux {
+func NewA2AHttpMux(pathPrefix string, taskStore TaskStore, pushNotifier *PushNotifier) *handlerMux {
 	return &handlerMux{
 		handlers:       make(map[string]http.Handler),
+		cards:          make(map[string]server.AgentCard),
+		taskManagers:   make(map[string]*storeTaskManager),
+		taskStore:      taskStore,
+		pushNotifier:   pushNotifier,
 		basePathPrefix: pathPrefix,
 	}
 }
@@ -48,22 +63,35 @@ func (a *handlerMux) SetAgentHandler(
 	agentName string,
 	params *A2AHandlerParams,
 ) error {
-	processor := newA2ATaskProcessor(params.HandleTask)
+	processor := newA2ATaskProcessor(params.HandleTask, params.AgentCard.DefaultInputModes, params.AgentCard.DefaultOutputModes)
+	handlerName := makeHandlerName(agentNamespace, agentName)
 
-	// Create task manager and inject processor.
-	taskManager, err := taskmanager.NewMemoryTaskManager(processor)
-	if err != nil {
-		return fmt.Errorf("failed to create task manager: %w", err)
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	// Reuse the task manager of the agent so that tasks survive handler updates.
+	taskManager, ok := a.taskManagers[handlerName]
+	if ok {
+		taskManager.setProcessor(processor)
+	} else {
+		jwksURL := strings.TrimSuffix(params.AgentCard.URL, "/") + protocol.JWKSPath
+		taskManager = newStoreTaskManager(handlerName, a.taskStore, a.pushNotifier, jwksURL, processor)
+	}
+
+	card := params.AgentCard
+	card.Capabilities.PushNotifications = a.pushNotifier.Enabled()
+	var opts []server.Option
+	if params.AuthProvider != nil {
+		opts = append(opts, server.WithAuthProvider(params.AuthProvider))
 	}
-	srv, err := server.NewA2AServer(params.AgentCard, taskManager)
+	srv, err := server.NewA2AServer(card, taskManager, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create A2A server: %w", err)
 	}
 
-	a.lock.Lock()
-	defer a.lock.Unlock()
-
-	a.handlers[makeHandlerName(agentNamespace, agentName)] = srv.Handler()
+	a.taskManagers[handlerName] = taskManager
+	a.handlers[handlerName] = srv.Handler()
+	a.cards[handlerName] = card
 
 	return nil
 }
@@ -74,7 +102,10 @@ func (a *handlerMux) RemoveAgentHandler(
 ) {
 	a.lock.Lock()
 	defer a.lock.Unlock()
-	delete(a.handlers, makeHandlerName(agentNamespace, agentName))
+	handlerName := makeHandlerName(agentNamespace, agentName)
+	delete(a.handlers, handlerName)
+	delete(a.cards, handlerName)
+	delete(a.taskManagers, handlerName)
 }
 
 func (a *handlerMux) getHandler(name string) (http.Handler, bool) {
@@ -88,6 +119,11 @@ func (a *handlerMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// get the handler name from the first path segment
 	path := strings.TrimPrefix(r.URL.Path, a.basePathPrefix)
+	// the catalog can not clash with an agent, as namespaces never start with a dot
+	if path == AgentCatalogPath {
+		a.serveAgentCatalog(w, r)
+		return
+	}
 	agentNamespace, remainingPath := popPath(path)
 	if agentNamespace == "" {
 		http.Error(w, "Agent namespace not provided", http.StatusBadRequest)
@@ -115,6 +151,12 @@ func (a *handlerMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// update the request URL to the remaining path
 	r.URL.Path = "/" + remainingPath
 
+	// the signing keys of push notifications are shared by all agents
+	if r.URL.Path == protocol.JWKSPath {
+		a.pushNotifier.HandleJWKS(w, r)
+		return
+	}
+
 	handlerHandler.ServeHTTP(w, r)
 }
 
@@ -0,0 +1,166 @@
+package a2a
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+// The input and output modes supported by agents, as published in their cards.
+const (
+	TextMode = string(protocol.PartTypeText)
+	FileMode = string(protocol.PartTypeFile)
+	DataMode = string(protocol.PartTypeData)
+)
+
+var (
+	defaultInputModes  = []string{TextMode}
+	defaultOutputModes = []string{TextMode}
+)
+
+// TaskResult is the final result of a task.
+type TaskResult struct {
+	// Text is the result of agents answering in plain text.
+	Text string
+	// Data is the result of agents answering with structured content. It takes precedence over Text.
+	Data any
+}
+
+// messageToTask renders the parts of a message into the task passed to the agent.
+// File parts are attached as fenced blocks and data parts as JSON context.
+// Parts of a mode the agent does not accept are rejected.
+func messageToTask(message protocol.Message, inputModes []string) (string, error) {
+	var sections []string
+	for _, part := range message.Parts {
+		switch p := part.(type) {
+		case protocol.TextPart:
+			if !slices.Contains(inputModes, TextMode) {
+				return "", fmt.Errorf("agent does not accept text input")
+			}
+			if p.Text != "" {
+				sections = append(sections, p.Text)
+			}
+		case protocol.FilePart:
+			if !slices.Contains(inputModes, FileMode) {
+				return "", fmt.Errorf("agent does not accept file input")
+			}
+			section, err := renderFilePart(p)
+			if err != nil {
+				return "", err
+			}
+			sections = append(sections, section)
+		case protocol.DataPart:
+			if !slices.Contains(inputModes, DataMode) {
+				return "", fmt.Errorf("agent does not accept data input")
+			}
+			b, err := json.MarshalIndent(p.Data, "", "  ")
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal data part: %w", err)
+			}
+			sections = append(sections, fmt.Sprintf("Attached data:\n```json\n%s\n```", b))
+		default:
+			return "", fmt.Errorf("unsupported part type %T", part)
+		}
+	}
+
+	if len(sections) == 0 {
+		return "", fmt.Errorf("input message must not be empty")
+	}
+
+	return strings.Join(sections, "\n\n"), nil
+}
+
+// renderFilePart attaches the content of text files, or the URI of files which are not embedded.
+// Agents only process text, so embedded binary files are rejected.
+func renderFilePart(part protocol.FilePart) (string, error) {
+	name := "file"
+	if part.File.Name != nil && *part.File.Name != "" {
+		name = *part.File.Name
+	}
+	mimeType := ""
+	if part.File.MimeType != nil {
+		mimeType = *part.File.MimeType
+	}
+
+	if part.File.Bytes == nil {
+		if part.File.URI == nil || *part.File.URI == "" {
+			return "", fmt.Errorf("file %s has neither content nor URI", name)
+		}
+		return fmt.Sprintf("Attached file %s: %s", describeFile(name, mimeType), *part.File.URI), nil
+	}
+
+	content, err := base64.StdEncoding.DecodeString(*part.File.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode file %s: %w", name, err)
+	}
+	if !isTextMimeType(mimeType) || !utf8.Valid(content) {
+		return "", fmt.Errorf("file %s is not a text file", describeFile(name, mimeType))
+	}
+
+	return fmt.Sprintf("Attached file %s:\n```\n%s\n```", describeFile(name, mimeType), strings.TrimSuffix(string(content), "\n")), nil
+}
+
+func describeFile(name, mimeType string) string {
+	if mimeType == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, mimeType)
+}
+
+// isTextMimeType reports whether files of the MIME type contain text.
+// Files without a MIME type are assumed to be text, their content is validated instead.
+func isTextMimeType(mimeType string) bool {
+	if mimeType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/x-ndjson", "application/xml", "application/yaml", "application/x-yaml", "application/toml":
+		return true
+	}
+	return strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml")
+}
+
+// resultToParts converts the result of a task into the parts returned to the client.
+// Structured results are returned as data parts if the agent outputs data, and as JSON text otherwise.
+// Agents which only output data have their JSON text results returned as data parts as well.
+func resultToParts(result *TaskResult, outputModes []string) ([]protocol.Part, error) {
+	outputsData := slices.Contains(outputModes, DataMode)
+	if result.Data != nil {
+		if outputsData {
+			return []protocol.Part{newDataPart(result.Data)}, nil
+		}
+		b, err := json.Marshal(result.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+		return []protocol.Part{protocol.NewTextPart(string(b))}, nil
+	}
+
+	if outputsData && !slices.Contains(outputModes, TextMode) {
+		var data any
+		if err := json.Unmarshal([]byte(result.Text), &data); err == nil {
+			return []protocol.Part{newDataPart(data)}, nil
+		}
+	}
+	return []protocol.Part{protocol.NewTextPart(result.Text)}, nil
+}
+
+func newDataPart(data any) protocol.DataPart {
+	return protocol.DataPart{
+		Type: protocol.PartTypeData,
+		Data: data,
+	}
+}
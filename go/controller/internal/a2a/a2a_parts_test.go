@@ -0,0 +1,126 @@
+package a2a
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+func TestMessageToTask(t *testing.T) {
+	allModes := []string{TextMode, FileMode, DataMode}
+	logFile := protocol.FilePart{
+		Type: protocol.PartTypeFile,
+		File: protocol.FileContent{
+			Name:     common.MakePtr("pod.log"),
+			MimeType: common.MakePtr("text/plain"),
+			Bytes:    common.MakePtr(base64.StdEncoding.EncodeToString([]byte("OOMKilled\n"))),
+		},
+	}
+
+	t.Run("should attach files and data", func(t *testing.T) {
+		task, err := messageToTask(protocol.Message{
+			Parts: []protocol.Part{
+				protocol.NewTextPart("Why did the pod crash?"),
+				logFile,
+				newDataPart(map[string]any{"restarts": 3}),
+			},
+		}, allModes)
+		require.NoError(t, err)
+		assert.Equal(t, "Why did the pod crash?\n\n"+
+			"Attached file pod.log (text/plain):\n```\nOOMKilled\n```\n\n"+
+			"Attached data:\n```json\n{\n  \"restarts\": 3\n}\n```", task)
+	})
+
+	t.Run("should reference files by URI", func(t *testing.T) {
+		task, err := messageToTask(protocol.Message{
+			Parts: []protocol.Part{protocol.FilePart{
+				Type: protocol.PartTypeFile,
+				File: protocol.FileContent{URI: common.MakePtr("https://example.com/bundle.tar.gz")},
+			}},
+		}, allModes)
+		require.NoError(t, err)
+		assert.Equal(t, "Attached file file: https://example.com/bundle.tar.gz", task)
+	})
+
+	t.Run("should reject binary files", func(t *testing.T) {
+		_, err := messageToTask(protocol.Message{
+			Parts: []protocol.Part{protocol.FilePart{
+				Type: protocol.PartTypeFile,
+				File: protocol.FileContent{
+					MimeType: common.MakePtr("application/gzip"),
+					Bytes:    common.MakePtr(base64.StdEncoding.EncodeToString([]byte{0x1f, 0x8b})),
+				},
+			}},
+		}, allModes)
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject parts of modes not accepted by the agent", func(t *testing.T) {
+		_, err := messageToTask(protocol.Message{
+			Parts: []protocol.Part{protocol.NewTextPart("Why did the pod crash?"), logFile},
+		}, defaultInputModes)
+		assert.EqualError(t, err, "agent does not accept file input")
+	})
+
+	t.Run("should reject empty messages", func(t *testing.T) {
+		_, err := messageToTask(protocol.Message{
+			Parts: []protocol.Part{protocol.NewTextPart("")},
+		}, defaultInputModes)
+		assert.Error(t, err)
+	})
+}
+
+func TestResultToParts(t *testing.T) {
+	data := map[string]any{"cause": "OOMKilled"}
+
+	tests := []struct {
+		name        string
+		result      *TaskResult
+		outputModes []string
+		expected    []protocol.Part
+	}{
+		{
+			name:        "text result",
+			result:      &TaskResult{Text: "The pod ran out of memory."},
+			outputModes: defaultOutputModes,
+			expected:    []protocol.Part{protocol.NewTextPart("The pod ran out of memory.")},
+		},
+		{
+			name:        "structured result of an agent outputting data",
+			result:      &TaskResult{Data: data},
+			outputModes: []string{TextMode, DataMode},
+			expected:    []protocol.Part{newDataPart(data)},
+		},
+		{
+			name:        "structured result of an agent outputting text",
+			result:      &TaskResult{Data: data},
+			outputModes: defaultOutputModes,
+			expected:    []protocol.Part{protocol.NewTextPart(`{"cause":"OOMKilled"}`)},
+		},
+		{
+			name:        "JSON text result of an agent only outputting data",
+			result:      &TaskResult{Text: `{"cause":"OOMKilled"}`},
+			outputModes: []string{DataMode},
+			expected:    []protocol.Part{newDataPart(data)},
+		},
+		{
+			name:        "JSON text result of an agent outputting text and data",
+			result:      &TaskResult{Text: `{"cause":"OOMKilled"}`},
+			outputModes: []string{TextMode, DataMode},
+			expected:    []protocol.Part{protocol.NewTextPart(`{"cause":"OOMKilled"}`)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := resultToParts(tt.result, tt.outputModes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, parts)
+		})
+	}
+}
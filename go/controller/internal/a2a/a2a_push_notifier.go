@@ -0,0 +1,330 @@
+package a2a
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+var (
+	pushNotifierLog = ctrl.Log.WithName("a2a_push_notifier")
+)
+
+const (
+	// PushNotificationTokenHeader carries the token set by the client in the push notification config.
+	PushNotificationTokenHeader = "X-A2A-Notification-Token"
+
+	pushNotificationJWKSURLKey = "jwksUrl"
+)
+
+// PushNotifierConfig configures the delivery of A2A push notifications.
+type PushNotifierConfig struct {
+	// AllowedHosts are the callback hosts notifications may be sent to.
+	// Entries are either a host name or a wildcard like *.example.com.
+	// Push notifications are disabled if no host is allowed.
+	AllowedHosts []string
+	// MaxAttempts is the number of delivery attempts per notification.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, doubled on every further retry.
+	InitialBackoff time.Duration
+	// Timeout is the timeout of a single delivery attempt.
+	Timeout time.Duration
+	// SigningKeyPath is the path of a PEM encoded RSA private key used to sign notifications.
+	// If it is empty a key is generated, in which case every replica signs with a different key.
+	SigningKeyPath string
+}
+
+// PushNotifier delivers signed A2A push notifications to the callback URLs of tasks.
+// Notifications carry a JWT in the Authorization header, which clients verify with the
+// keys served at the JWKS endpoint of the agent.
+type PushNotifier struct {
+	config     PushNotifierConfig
+	httpClient *http.Client
+	privateKey *rsa.PrivateKey
+	keyID      string
+	keySet     jwk.Set
+
+	// ctx is cancelled when the manager stops, aborting pending deliveries
+	ctx        context.Context
+	cancel     context.CancelFunc
+	deliveries sync.WaitGroup
+}
+
+func NewPushNotifier(config PushNotifierConfig) (*PushNotifier, error) {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 1
+	}
+
+	privateKey, err := loadSigningKey(config.SigningKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := jwk.FromRaw(privateKey.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWK from public key: %w", err)
+	}
+	// derive the key ID from the key, so that replicas sharing a key also share its ID
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute key thumbprint: %w", err)
+	}
+	keyID := base64.RawURLEncoding.EncodeToString(thumbprint)
+	for k, v := range map[string]interface{}{
+		jwk.KeyIDKey:     keyID,
+		jwk.KeyUsageKey:  "sig",
+		jwk.AlgorithmKey: "RS256",
+	} {
+		if err := key.Set(k, v); err != nil {
+			return nil, fmt.Errorf("failed to set %s of JWK: %w", k, err)
+		}
+	}
+	keySet := jwk.NewSet()
+	if err := keySet.AddKey(key); err != nil {
+		return nil, fmt.Errorf("failed to add JWK to key set: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &PushNotifier{
+		config: config,
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+			// redirects are refused, as only the callback URL itself was checked against the allowlist
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		privateKey: privateKey,
+		keyID:      keyID,
+		keySet:     keySet,
+		ctx:        ctx,
+		cancel:     cancel,
+	}, nil
+}
+
+// Start implements controller-runtime's Runnable interface.
+// It aborts pending deliveries and waits for them to return once the manager stops.
+func (p *PushNotifier) Start(ctx context.Context) error {
+	<-ctx.Done()
+	p.cancel()
+	p.deliveries.Wait()
+	return nil
+}
+
+// NeedLeaderElection implements controller-runtime's LeaderElectionRunnable interface
+func (p *PushNotifier) NeedLeaderElection() bool {
+	// every replica delivers the notifications of the tasks it processes
+	return false
+}
+
+func loadSigningKey(path string) (*rsa.PrivateKey, error) {
+	if path == "" {
+		pushNotifierLog.Info("No push notification signing key configured, generating one")
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate push notification signing key: %w", err)
+		}
+		return privateKey, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read push notification signing key: %w", err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("push notification signing key %s is not PEM encoded", path)
+	}
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse push notification signing key: %w", err)
+	}
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("push notification signing key %s is not an RSA key", path)
+	}
+	return privateKey, nil
+}
+
+// Enabled returns whether notifications can be sent to any host.
+func (p *PushNotifier) Enabled() bool {
+	return len(p.config.AllowedHosts) > 0
+}
+
+// ValidateURL returns an error if notifications must not be sent to the given callback URL.
+func (p *PushNotifier) ValidateURL(rawURL string) error {
+	if !p.Enabled() {
+		return fmt.Errorf("push notifications are not enabled")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid push notification url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("push notification url must use http or https")
+	}
+	host := u.Hostname()
+	for _, allowed := range p.config.AllowedHosts {
+		if hostMatches(allowed, host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("push notification host %s is not allowed", host)
+}
+
+func hostMatches(pattern string, host string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	host = strings.ToLower(host)
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return strings.HasSuffix(host, "."+suffix)
+	}
+	// allow host:port entries, the port is not part of the check
+	if h, _, err := net.SplitHostPort(pattern); err == nil {
+		pattern = h
+	}
+	return pattern == host
+}
+
+// PrepareConfig adds the authentication details clients need to verify notifications.
+func (p *PushNotifier) PrepareConfig(config *protocol.PushNotificationConfig, jwksURL string) {
+	if config.Authentication == nil {
+		config.Authentication = &protocol.AuthenticationInfo{}
+	}
+	hasBearer := false
+	for _, scheme := range config.Authentication.Schemes {
+		if strings.EqualFold(scheme, "bearer") {
+			hasBearer = true
+		}
+	}
+	if !hasBearer {
+		config.Authentication.Schemes = append(config.Authentication.Schemes, "bearer")
+	}
+	if config.Metadata == nil {
+		config.Metadata = make(map[string]interface{})
+	}
+	config.Metadata[pushNotificationJWKSURLKey] = jwksURL
+}
+
+// Notify sends the task to the callback URL in the background, retrying failed deliveries.
+func (p *PushNotifier) Notify(config protocol.PushNotificationConfig, task *protocol.Task) {
+	payload, err := json.Marshal(task)
+	if err != nil {
+		pushNotifierLog.Error(err, "Failed to marshal push notification", "taskID", task.ID)
+		return
+	}
+
+	p.deliveries.Add(1)
+	go func() {
+		defer p.deliveries.Done()
+		backoff := p.config.InitialBackoff
+		for attempt := 1; ; attempt++ {
+			retryable, err := p.send(p.ctx, config, payload)
+			if err == nil {
+				return
+			}
+			if !retryable || attempt >= p.config.MaxAttempts || p.ctx.Err() != nil {
+				pushNotifierLog.Error(err, "Failed to deliver push notification", "taskID", task.ID, "attempts", attempt)
+				return
+			}
+			pushNotifierLog.Info("Retrying push notification", "taskID", task.ID, "attempt", attempt, "error", err.Error())
+			select {
+			case <-p.ctx.Done():
+				pushNotifierLog.Info("Stopped retrying push notification on shutdown", "taskID", task.ID)
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	}()
+}
+
+// send delivers the payload once, returning whether a failed delivery should be retried.
+func (p *PushNotifier) send(ctx context.Context, config protocol.PushNotificationConfig, payload []byte) (bool, error) {
+	// the allowlist may have changed since the config was set
+	if err := p.ValidateURL(config.URL); err != nil {
+		return false, err
+	}
+
+	token, err := p.sign(payload)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, fmt.Errorf("failed to create push notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	if config.Token != "" {
+		req.Header.Set(PushNotificationTokenHeader, config.Token)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send push notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return retryable, fmt.Errorf("push notification failed with status: %s", resp.Status)
+	}
+
+	return false, nil
+}
+
+// sign creates a JWT over the payload hash, compatible with the verification of the A2A client libraries.
+func (p *PushNotifier) sign(payload []byte) (string, error) {
+	hash := sha256.Sum256(payload)
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iat":                 time.Now().Unix(),
+		"request_body_sha256": fmt.Sprintf("%x", hash),
+	})
+	token.Header["kid"] = p.keyID
+	signed, err := token.SignedString(p.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign push notification: %w", err)
+	}
+	return signed, nil
+}
+
+// HandleJWKS serves the public key used to sign notifications.
+func (p *PushNotifier) HandleJWKS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	b, err := json.Marshal(p.keySet)
+	if err != nil {
+		http.Error(w, "Failed to marshal key set", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
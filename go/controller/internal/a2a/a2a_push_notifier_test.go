@@ -0,0 +1,134 @@
+package a2a
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+func TestPushNotifierValidateURL(t *testing.T) {
+	notifier, err := NewPushNotifier(PushNotifierConfig{
+		AllowedHosts: []string{"hooks.example.com", "*.internal.example.com"},
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, notifier.ValidateURL("https://hooks.example.com/callback"))
+	assert.NoError(t, notifier.ValidateURL("http://a.internal.example.com:8080/callback"))
+	assert.Error(t, notifier.ValidateURL("https://internal.example.com/callback"))
+	assert.Error(t, notifier.ValidateURL("https://evil.com/callback"))
+	assert.Error(t, notifier.ValidateURL("file:///etc/passwd"))
+
+	disabled, err := NewPushNotifier(PushNotifierConfig{})
+	require.NoError(t, err)
+	assert.False(t, disabled.Enabled())
+	assert.Error(t, disabled.ValidateURL("https://hooks.example.com/callback"))
+}
+
+func TestPushNotifierNotify(t *testing.T) {
+	var attempts atomic.Int32
+	delivered := make(chan *http.Request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		delivered <- r
+	}))
+	defer srv.Close()
+
+	notifier, err := NewPushNotifier(PushNotifierConfig{
+		AllowedHosts:   []string{"127.0.0.1"},
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		Timeout:        time.Second,
+	})
+	require.NoError(t, err)
+
+	notifier.Notify(protocol.PushNotificationConfig{
+		URL:   srv.URL,
+		Token: "client-token",
+	}, protocol.NewTask("task-1", nil))
+
+	select {
+	case r := <-delivered:
+		assert.Equal(t, "client-token", r.Header.Get(PushNotificationTokenHeader))
+		tokenString := r.Header.Get("Authorization")[len("Bearer "):]
+		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			return notifier.privateKey.Public(), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, notifier.keyID, token.Header["kid"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("push notification was not delivered")
+	}
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestPushNotifierRefusesRedirects(t *testing.T) {
+	var redirected atomic.Bool
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected.Store(true)
+	}))
+	defer internal.Close()
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		http.Redirect(w, r, internal.URL, http.StatusTemporaryRedirect)
+	}))
+	defer srv.Close()
+
+	notifier, err := NewPushNotifier(PushNotifierConfig{
+		AllowedHosts: []string{"127.0.0.1"},
+		MaxAttempts:  3,
+		Timeout:      time.Second,
+	})
+	require.NoError(t, err)
+
+	retryable, err := notifier.send(context.Background(), protocol.PushNotificationConfig{URL: srv.URL}, []byte("{}"))
+	assert.Error(t, err)
+	assert.False(t, retryable)
+	assert.False(t, redirected.Load())
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestPushNotifierStopsRetryingOnShutdown(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	notifier, err := NewPushNotifier(PushNotifierConfig{
+		AllowedHosts:   []string{"127.0.0.1"},
+		MaxAttempts:    10,
+		InitialBackoff: time.Hour,
+		Timeout:        time.Second,
+	})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		assert.NoError(t, notifier.Start(ctx))
+		close(stopped)
+	}()
+
+	notifier.Notify(protocol.PushNotificationConfig{URL: srv.URL}, protocol.NewTask("task-1", nil))
+	require.Eventually(t, func() bool { return attempts.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("push notifier did not stop the pending retry")
+	}
+	assert.Equal(t, int32(1), attempts.Load())
+}
@@ -6,6 +6,7 @@ import (
 	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
 	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
@@ -16,44 +17,49 @@ type A2AReconciler interface {
 	ReconcileAutogenAgent(
 		ctx context.Context,
 		agent *v1alpha1.Agent,
-		autogenTeam *autogen_client.Team,
 	) error
 
 	ReconcileAutogenAgentDeletion(
+		ctx context.Context,
 		agentNamespace string,
 		agentName string,
-	)
+	) error
 }
 
 type a2aReconciler struct {
 	a2aTranslator AutogenA2ATranslator
 	autogenClient autogen_client.Client
 	a2aHandler    A2AHandlerMux
+	taskStore     TaskStore
 }
 
 func NewAutogenReconciler(
 	autogenClient autogen_client.Client,
+	kube client.Client,
 	a2aHandler A2AHandlerMux,
 	a2aBaseUrl string,
+	taskStore TaskStore,
 ) A2AReconciler {
 	return &a2aReconciler{
-		a2aTranslator: NewAutogenA2ATranslator(a2aBaseUrl, autogenClient),
+		a2aTranslator: NewAutogenA2ATranslator(a2aBaseUrl, autogenClient, kube),
 		autogenClient: autogenClient,
 		a2aHandler:    a2aHandler,
+		taskStore:     taskStore,
 	}
 }
 
 func (a *a2aReconciler) ReconcileAutogenAgent(
 	ctx context.Context,
 	agent *v1alpha1.Agent,
-	autogenTeam *autogen_client.Team,
 ) error {
-	params, err := a.a2aTranslator.TranslateHandlerForAgent(ctx, agent, autogenTeam)
+	params, err := a.a2aTranslator.TranslateHandlerForAgent(ctx, agent)
 	if err != nil {
 		return err
 	}
 	if params == nil {
 		reconcileLog.Info("No a2a handler found for agent, a2a will be disabled", "agent", agent.Name)
+		// a2a may have been disabled on an existing agent, its tasks are kept in case it is enabled again
+		a.a2aHandler.RemoveAgentHandler(agent.Namespace, agent.Name)
 		return nil
 	}
 
@@ -64,10 +70,13 @@ func (a *a2aReconciler) ReconcileAutogenAgent(
 }
 
 func (a *a2aReconciler) ReconcileAutogenAgentDeletion(
+	ctx context.Context,
 	agentNamespace string,
 	agentName string,
-) {
+) error {
 	a.a2aHandler.RemoveAgentHandler(
 		agentNamespace, agentName,
 	)
+
+	return a.taskStore.DeleteTasks(ctx, makeHandlerName(agentNamespace, agentName))
 }
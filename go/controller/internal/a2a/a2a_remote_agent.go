@@ -0,0 +1,225 @@
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/utils/a2autils"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+)
+
+const (
+	// DefaultRemoteAgentTimeout is used when a RemoteAgent does not set a timeout.
+	DefaultRemoteAgentTimeout = 5 * time.Minute
+
+	remoteAgentRequestTimeout = 30 * time.Second
+	remoteAgentPollInterval   = 2 * time.Second
+)
+
+// RemoteAgentClient sends tasks to agents served by external A2A servers,
+// as referenced by RemoteAgent resources.
+type RemoteAgentClient struct {
+	kube       client.Client
+	httpClient *http.Client
+	// taskClient has no timeout, as tasks/send blocks until the remote task finished,
+	// task requests are bounded by the timeout of the RemoteAgent instead
+	taskClient *http.Client
+}
+
+func NewRemoteAgentClient(kube client.Client) *RemoteAgentClient {
+	return &RemoteAgentClient{
+		kube: kube,
+		httpClient: &http.Client{
+			Timeout: remoteAgentRequestTimeout,
+		},
+		taskClient: &http.Client{},
+	}
+}
+
+// FetchAgentCard fetches the agent card published by the remote agent.
+func (c *RemoteAgentClient) FetchAgentCard(ctx context.Context, remoteAgent *v1alpha1.RemoteAgent) (*server.AgentCard, error) {
+	token, err := c.token(ctx, remoteAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	cardURL := strings.TrimSuffix(remoteAgent.Spec.URL, "/") + protocol.AgentCardPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cardURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent card request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch agent card from %s: %w", cardURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch agent card from %s: %s", cardURL, resp.Status)
+	}
+
+	card := &server.AgentCard{}
+	if err := json.NewDecoder(resp.Body).Decode(card); err != nil {
+		return nil, fmt.Errorf("failed to decode agent card from %s: %w", cardURL, err)
+	}
+	if card.Name == "" {
+		return nil, fmt.Errorf("agent card from %s has no name", cardURL)
+	}
+
+	return card, nil
+}
+
+// SendTask sends the given text to the remote agent and waits for the task to complete.
+// It returns the text of the artifacts produced by the task.
+func (c *RemoteAgentClient) SendTask(ctx context.Context, remoteAgent *v1alpha1.RemoteAgent, text string) (string, error) {
+	token, err := c.token(ctx, remoteAgent)
+	if err != nil {
+		return "", err
+	}
+
+	opts := []a2aclient.Option{a2aclient.WithHTTPClient(c.taskClient)}
+	if token != "" {
+		opts = append(opts, a2aclient.WithAPIKeyAuth("Bearer "+token, "Authorization"))
+	}
+	a2aClient, err := a2aclient.NewA2AClient(remoteAgent.Spec.URL, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	timeout := DefaultRemoteAgentTimeout
+	if remoteAgent.Spec.Timeout != nil {
+		timeout = remoteAgent.Spec.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	task, err := a2aClient.SendTasks(ctx, protocol.SendTaskParams{
+		ID: "kagent-task-" + uuid.NewString(),
+		Message: protocol.Message{
+			Role:  protocol.MessageRoleUser,
+			Parts: []protocol.Part{protocol.NewTextPart(text)},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to send task to remote agent %s: %w", remoteAgent.Name, err)
+	}
+
+	task, err = waitForRemoteTask(ctx, a2aClient, task)
+	if err != nil {
+		// the task is abandoned, so there is no point in the remote agent finishing it
+		cancelCtx, cancelCancel := context.WithTimeout(context.Background(), remoteAgentRequestTimeout)
+		defer cancelCancel()
+		_, _ = a2aClient.CancelTasks(cancelCtx, protocol.TaskIDParams{ID: task.ID})
+		return "", fmt.Errorf("failed to wait for task of remote agent %s: %w", remoteAgent.Name, err)
+	}
+
+	switch task.Status.State {
+	case protocol.TaskStateCompleted:
+		return extractArtifactsText(task), nil
+	case protocol.TaskStateInputRequired:
+		// the agent can not ask for input when used as a tool, so its question is the result
+		if task.Status.Message != nil {
+			return a2autils.ExtractText(*task.Status.Message), nil
+		}
+		return extractArtifactsText(task), nil
+	default:
+		message := ""
+		if task.Status.Message != nil {
+			message = a2autils.ExtractText(*task.Status.Message)
+		}
+		return "", fmt.Errorf("task of remote agent %s ended in state %s: %s", remoteAgent.Name, task.Status.State, message)
+	}
+}
+
+// waitForRemoteTask polls the task until it reached a state in which the remote agent stops working on it.
+func waitForRemoteTask(ctx context.Context, a2aClient *a2aclient.A2AClient, task *protocol.Task) (*protocol.Task, error) {
+	ticker := time.NewTicker(remoteAgentPollInterval)
+	defer ticker.Stop()
+	for {
+		switch task.Status.State {
+		case protocol.TaskStateSubmitted, protocol.TaskStateWorking:
+		default:
+			return task, nil
+		}
+
+		select {
+		case <-ticker.C:
+			current, err := a2aClient.GetTasks(ctx, protocol.TaskQueryParams{ID: task.ID})
+			if err != nil {
+				return task, err
+			}
+			task = current
+		case <-ctx.Done():
+			return task, ctx.Err()
+		}
+	}
+}
+
+func extractArtifactsText(task *protocol.Task) string {
+	var texts []string
+	for _, artifact := range task.Artifacts {
+		texts = append(texts, a2autils.ExtractPartsText(artifact.Parts))
+	}
+	return strings.Join(texts, "\n")
+}
+
+// token returns the bearer token sent to the remote agent, or an empty string if none is configured.
+// The Secret is read on every request, so that rotated tokens take effect immediately.
+func (c *RemoteAgentClient) token(ctx context.Context, remoteAgent *v1alpha1.RemoteAgent) (string, error) {
+	if remoteAgent.Spec.Authentication == nil || remoteAgent.Spec.Authentication.Bearer == nil {
+		return "", nil
+	}
+	bearer := remoteAgent.Spec.Authentication.Bearer
+	secretKey := bearer.SecretKey
+	if secretKey == "" {
+		secretKey = defaultBearerSecretKey
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, client.ObjectKey{Namespace: remoteAgent.Namespace, Name: bearer.SecretRef}, secret); err != nil {
+		return "", fmt.Errorf("failed to get token secret %s/%s: %w", remoteAgent.Namespace, bearer.SecretRef, err)
+	}
+	token := strings.TrimSpace(string(secret.Data[secretKey]))
+	if token == "" {
+		return "", fmt.Errorf("token secret %s/%s has no key %s", remoteAgent.Namespace, bearer.SecretRef, secretKey)
+	}
+
+	return token, nil
+}
+
+// ConvertAgentCard converts an agent card into the form recorded in the status of a RemoteAgent.
+func ConvertAgentCard(card *server.AgentCard) *v1alpha1.RemoteAgentCard {
+	converted := &v1alpha1.RemoteAgentCard{
+		Name:               card.Name,
+		URL:                card.URL,
+		Version:            card.Version,
+		Streaming:          card.Capabilities.Streaming,
+		DefaultInputModes:  card.DefaultInputModes,
+		DefaultOutputModes: card.DefaultOutputModes,
+	}
+	if card.Description != nil {
+		converted.Description = *card.Description
+	}
+	for _, skill := range card.Skills {
+		converted.Skills = append(converted.Skills, v1alpha1.AgentSkill(skill))
+	}
+	if card.Authentication != nil {
+		converted.AuthenticationSchemes = card.Authentication.Schemes
+	}
+	return converted
+}
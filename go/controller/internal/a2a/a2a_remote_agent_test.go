@@ -0,0 +1,129 @@
+package a2a
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/utils/a2autils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+)
+
+type echoProcessor struct {
+	delay time.Duration
+}
+
+func (p echoProcessor) Process(_ context.Context, _ string, msg protocol.Message, handle taskmanager.TaskHandle) error {
+	time.Sleep(p.delay)
+	if err := handle.AddArtifact(protocol.Artifact{
+		Parts: []protocol.Part{protocol.NewTextPart("echo: " + a2autils.ExtractText(msg))},
+	}); err != nil {
+		return err
+	}
+	return handle.UpdateStatus(protocol.TaskStateCompleted, nil)
+}
+
+// newEchoServer serves an echo agent which replies after the delay. It accepts the
+// same token via the bearer authentication of kagent agents.
+func newEchoServer(t *testing.T, kubeClient client.Client, delay time.Duration) *httptest.Server {
+	authProvider, authInfo := translateAuthentication(kubeClient, "kagent", &v1alpha1.A2AAuthentication{
+		Bearer: &v1alpha1.A2ABearerAuthentication{SecretRef: "remote-token"},
+	})
+	taskManager, err := taskmanager.NewMemoryTaskManager(echoProcessor{delay: delay})
+	require.NoError(t, err)
+	description := "Echoes the task"
+	a2aServer, err := server.NewA2AServer(server.AgentCard{
+		Name:           "echo",
+		Description:    &description,
+		Version:        "1.0.0",
+		Authentication: authInfo,
+		Skills: []server.AgentSkill{
+			{ID: "echo", Name: "Echo"},
+		},
+	}, taskManager, server.WithAuthProvider(authProvider))
+	require.NoError(t, err)
+	return httptest.NewServer(a2aServer.Handler())
+}
+
+func TestRemoteAgentClient(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "remote-token",
+			Namespace: "kagent",
+		},
+		Data: map[string][]byte{
+			"token": []byte("remote-secret\n"),
+		},
+	}).Build()
+
+	ts := newEchoServer(t, kubeClient, 0)
+	defer ts.Close()
+
+	remoteAgent := &v1alpha1.RemoteAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "echo",
+			Namespace: "kagent",
+		},
+		Spec: v1alpha1.RemoteAgentSpec{
+			URL: ts.URL,
+			Authentication: &v1alpha1.RemoteAgentAuthentication{
+				Bearer: &v1alpha1.RemoteAgentBearerAuthentication{SecretRef: "remote-token"},
+			},
+		},
+	}
+	remoteClient := NewRemoteAgentClient(kubeClient)
+
+	t.Run("should fetch the agent card", func(t *testing.T) {
+		card, err := remoteClient.FetchAgentCard(context.Background(), remoteAgent)
+		require.NoError(t, err)
+
+		converted := ConvertAgentCard(card)
+		assert.Equal(t, "echo", converted.Name)
+		assert.Equal(t, "Echoes the task", converted.Description)
+		assert.Equal(t, []string{BearerAuthScheme}, converted.AuthenticationSchemes)
+		require.Len(t, converted.Skills, 1)
+		assert.Equal(t, "Echo", converted.Skills[0].Name)
+	})
+
+	t.Run("should return the artifacts of the task", func(t *testing.T) {
+		result, err := remoteClient.SendTask(context.Background(), remoteAgent, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, "echo: hello", result)
+	})
+
+	t.Run("should wait for tasks longer than the request timeout", func(t *testing.T) {
+		slow := newEchoServer(t, kubeClient, 200*time.Millisecond)
+		defer slow.Close()
+		slowAgent := remoteAgent.DeepCopy()
+		slowAgent.Spec.URL = slow.URL
+		slowClient := NewRemoteAgentClient(kubeClient)
+		slowClient.httpClient.Timeout = 50 * time.Millisecond
+
+		result, err := slowClient.SendTask(context.Background(), slowAgent, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, "echo: hello", result)
+
+		// the timeout of the remote agent still applies
+		slowAgent.Spec.Timeout = &metav1.Duration{Duration: 50 * time.Millisecond}
+		_, err = slowClient.SendTask(context.Background(), slowAgent, "hello")
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail without a valid token", func(t *testing.T) {
+		unauthenticated := remoteAgent.DeepCopy()
+		unauthenticated.Spec.Authentication = nil
+		_, err := remoteClient.SendTask(context.Background(), unauthenticated, "hello")
+		assert.Error(t, err)
+	})
+}
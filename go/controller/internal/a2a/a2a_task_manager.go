@@ -0,0 +1,681 @@
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+)
+
+var (
+	taskManagerLog = ctrl.Log.WithName("a2a_task_manager")
+)
+
+const (
+	// maxTaskHistory is the number of messages kept in the history of a stored task.
+	maxTaskHistory = 100
+	// maxTaskSize keeps stored tasks well below the 1MiB size limit of ConfigMaps.
+	maxTaskSize = 768 * 1024
+	// maxTaskSaveAttempts bounds the retries of task writes which conflict with other replicas.
+	maxTaskSaveAttempts = 5
+	// defaultCancelPollInterval is how often running tasks are checked for cancellation by other replicas.
+	defaultCancelPollInterval = 2 * time.Second
+)
+
+// storeTaskManager implements taskmanager.TaskManager on top of a TaskStore.
+// The task state is persisted in the store, while subscribers and the cancellation
+// functions of running tasks only exist on the replica processing the task.
+// Streamed chunks and repeated working updates are only sent to subscribers, the store
+// is written when the state of a task changes and when its artifacts are complete.
+// Any replica may cancel a task by storing the canceled state, which the replica processing
+// the task polls for. Tasks never leave a final state unless a new message is sent to them.
+type storeTaskManager struct {
+	agent    string
+	store    TaskStore
+	notifier *PushNotifier
+	// jwksURL is where clients find the keys to verify push notifications of this agent
+	jwksURL string
+
+	// taskLock serializes read-modify-write cycles of tasks in the store
+	taskLock sync.Mutex
+	// cancelPollInterval is how often running tasks are checked for cancellation
+	cancelPollInterval time.Duration
+
+	lock        sync.RWMutex
+	processor   taskmanager.TaskProcessor
+	subscribers map[string][]chan<- protocol.TaskEvent
+	cancelFuncs map[string]context.CancelFunc
+	// persistedStates are the last stored states of the tasks processed by this replica
+	persistedStates map[string]protocol.TaskState
+	// pendingChunks are the artifact chunks streamed since the last write of a task
+	pendingChunks map[string][]protocol.Artifact
+}
+
+var _ taskmanager.TaskManager = &storeTaskManager{}
+
+func newStoreTaskManager(
+	agent string,
+	store TaskStore,
+	notifier *PushNotifier,
+	jwksURL string,
+	processor taskmanager.TaskProcessor,
+) *storeTaskManager {
+	return &storeTaskManager{
+		agent:              agent,
+		store:              store,
+		notifier:           notifier,
+		jwksURL:            jwksURL,
+		processor:          processor,
+		cancelPollInterval: defaultCancelPollInterval,
+		subscribers:        make(map[string][]chan<- protocol.TaskEvent),
+		cancelFuncs:        make(map[string]context.CancelFunc),
+		persistedStates:    make(map[string]protocol.TaskState),
+		pendingChunks:      make(map[string][]protocol.Artifact),
+	}
+}
+
+// setProcessor replaces the processor used for new tasks. Running tasks keep their processor.
+func (m *storeTaskManager) setProcessor(processor taskmanager.TaskProcessor) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.processor = processor
+}
+
+func (m *storeTaskManager) getProcessor() taskmanager.TaskProcessor {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.processor
+}
+
+func (m *storeTaskManager) OnSendTask(ctx context.Context, params protocol.SendTaskParams) (*protocol.Task, error) {
+	task, err := m.upsertTask(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	m.setCancelFunc(params.ID, cancel)
+	defer m.finishTask(params.ID)
+	go m.watchCancellation(taskCtx, params.ID, cancel)
+
+	processErr := m.process(taskCtx, task, params.Message)
+
+	finalTask, err := m.getTask(ctx, params.ID, nil)
+	if err != nil {
+		taskManagerLog.Error(err, "Failed to get task after processing", "taskID", params.ID)
+	}
+
+	return finalTask, processErr
+}
+
+func (m *storeTaskManager) OnSendTaskSubscribe(ctx context.Context, params protocol.SendTaskParams) (<-chan protocol.TaskEvent, error) {
+	task, err := m.upsertTask(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	eventChan := make(chan protocol.TaskEvent, 10)
+	m.addSubscriber(params.ID, eventChan)
+
+	processorCtx, cancel := context.WithCancel(ctx)
+	m.setCancelFunc(params.ID, cancel)
+	go m.watchCancellation(processorCtx, params.ID, cancel)
+
+	go func() {
+		defer cancel()
+		if err := m.process(processorCtx, task, params.Message); err != nil {
+			taskManagerLog.Error(err, "Failed to process task", "taskID", params.ID)
+		}
+		m.finishTask(params.ID)
+		m.closeSubscribers(params.ID)
+	}()
+
+	return eventChan, nil
+}
+
+func (m *storeTaskManager) OnGetTask(ctx context.Context, params protocol.TaskQueryParams) (*protocol.Task, error) {
+	return m.getTask(ctx, params.ID, params.HistoryLength)
+}
+
+func (m *storeTaskManager) OnCancelTask(ctx context.Context, params protocol.TaskIDParams) (*protocol.Task, error) {
+	task, err := m.getTask(ctx, params.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if isFinalState(task.Status.State) {
+		return task, taskmanager.ErrTaskFinalState(params.ID, task.Status.State)
+	}
+
+	// the canceled state is stored first, so that the run cannot end the task in another final state
+	cancelMsg := protocol.NewMessage(
+		protocol.MessageRoleAgent,
+		[]protocol.Part{protocol.NewTextPart(fmt.Sprintf("Task %s was canceled by user request", params.ID))},
+	)
+	if err := m.UpdateTaskStatus(ctx, params.ID, protocol.TaskStateCanceled, &cancelMsg); err != nil {
+		return nil, err
+	}
+
+	// a task processed by another replica is stopped once that replica notices the stored state
+	m.lock.RLock()
+	cancel, ok := m.cancelFuncs[params.ID]
+	m.lock.RUnlock()
+	if ok {
+		cancel()
+	}
+
+	return m.getTask(ctx, params.ID, nil)
+}
+
+func (m *storeTaskManager) OnPushNotificationSet(
+	ctx context.Context,
+	params protocol.TaskPushNotificationConfig,
+) (*protocol.TaskPushNotificationConfig, error) {
+	if err := m.notifier.ValidateURL(params.PushNotificationConfig.URL); err != nil {
+		return nil, err
+	}
+	m.notifier.PrepareConfig(&params.PushNotificationConfig, m.jwksURL)
+
+	if _, err := m.updateTask(ctx, params.ID, nil, func(task *StoredTask) error {
+		task.PushNotification = &params.PushNotificationConfig
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+func (m *storeTaskManager) OnPushNotificationGet(
+	ctx context.Context,
+	params protocol.TaskIDParams,
+) (*protocol.TaskPushNotificationConfig, error) {
+	task, err := m.loadTask(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if task.PushNotification == nil {
+		return nil, taskmanager.ErrPushNotificationNotConfigured(params.ID)
+	}
+	return &protocol.TaskPushNotificationConfig{
+		ID:                     params.ID,
+		PushNotificationConfig: *task.PushNotification,
+	}, nil
+}
+
+func (m *storeTaskManager) OnResubscribe(ctx context.Context, params protocol.TaskIDParams) (<-chan protocol.TaskEvent, error) {
+	task, err := m.getTask(ctx, params.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	eventChan := make(chan protocol.TaskEvent, 10)
+	statusEvent := protocol.TaskStatusUpdateEvent{
+		ID:     task.ID,
+		Status: task.Status,
+		Final:  isFinalState(task.Status.State),
+	}
+
+	if statusEvent.Final {
+		eventChan <- statusEvent
+		close(eventChan)
+		return eventChan, nil
+	}
+
+	// only the replica processing the task sends further events
+	eventChan <- statusEvent
+	m.addSubscriber(params.ID, eventChan)
+	go func() {
+		<-ctx.Done()
+		m.removeSubscriber(params.ID, eventChan)
+	}()
+
+	return eventChan, nil
+}
+
+// UpdateTaskStatus updates the state of the task and notifies its subscribers.
+// Working updates of a task which is already stored as working, e.g. for tool events,
+// are only sent to subscribers. Tasks in a final state are not updated, so that e.g. the
+// result of a run does not overwrite its cancellation by another replica.
+func (m *storeTaskManager) UpdateTaskStatus(
+	ctx context.Context,
+	taskID string,
+	state protocol.TaskState,
+	message *protocol.Message,
+) error {
+	status := protocol.TaskStatus{
+		State:     state,
+		Message:   message,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	var task *StoredTask
+	if state != protocol.TaskStateWorking || m.getPersistedState(taskID) != protocol.TaskStateWorking {
+		chunks := m.takePendingChunks(taskID)
+		var err error
+		task, err = m.updateTask(ctx, taskID, nil, func(task *StoredTask) error {
+			if isFinalState(task.Task.Status.State) {
+				return taskmanager.ErrTaskFinalState(taskID, task.Task.Status.State)
+			}
+			task.Task.Status = status
+			task.Task.Artifacts = mergeArtifacts(task.Task.Artifacts, chunks)
+			if message != nil {
+				task.History = append(task.History, *message)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		m.setPersistedState(taskID, state)
+	}
+
+	if processor, ok := m.getProcessor().(taskmanager.TaskProcessorWithStatusUpdate); ok {
+		if err := processor.OnTaskStatusUpdate(ctx, taskID, state, message); err != nil {
+			taskManagerLog.Error(err, "Failed to handle task status update", "taskID", taskID)
+		}
+	}
+
+	m.notifySubscribers(taskID, protocol.TaskStatusUpdateEvent{
+		ID:     taskID,
+		Status: status,
+		Final:  isFinalState(state),
+	})
+
+	// push notifications are only sent when the client has to act
+	if task != nil && task.PushNotification != nil && (isFinalState(state) || state == protocol.TaskStateInputRequired) {
+		m.notifier.Notify(*task.PushNotification, &task.Task)
+	}
+
+	return nil
+}
+
+// AddArtifact adds an artifact to the task and notifies its subscribers.
+// Chunks are kept in memory until the last chunk or the next status update is stored.
+func (m *storeTaskManager) AddArtifact(ctx context.Context, taskID string, artifact protocol.Artifact) error {
+	if isPendingChunk(artifact) {
+		m.addPendingChunk(taskID, artifact)
+	} else {
+		chunks := append(m.takePendingChunks(taskID), artifact)
+		if _, err := m.updateTask(ctx, taskID, nil, func(task *StoredTask) error {
+			if isFinalState(task.Task.Status.State) {
+				return taskmanager.ErrTaskFinalState(taskID, task.Task.Status.State)
+			}
+			task.Task.Artifacts = mergeArtifacts(task.Task.Artifacts, chunks)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	// the terminal status update, not the last artifact chunk, ends the stream
+	m.notifySubscribers(taskID, protocol.TaskArtifactUpdateEvent{
+		ID:       taskID,
+		Artifact: artifact,
+	})
+
+	return nil
+}
+
+func (m *storeTaskManager) process(ctx context.Context, task *StoredTask, message protocol.Message) error {
+	handle := &storeTaskHandle{
+		taskID:    task.Task.ID,
+		sessionID: task.Task.SessionID,
+		manager:   m,
+	}
+
+	if err := m.UpdateTaskStatus(ctx, task.Task.ID, protocol.TaskStateWorking, nil); err != nil {
+		return fmt.Errorf("failed to set initial working status: %w", err)
+	}
+
+	processErr := m.getProcessor().Process(ctx, task.Task.ID, message, handle)
+	if processErr == nil || ctx.Err() == context.Canceled {
+		return processErr
+	}
+
+	// the processor may already have reported the failure itself
+	current, err := m.getTask(ctx, task.Task.ID, nil)
+	if err == nil && isFinalState(current.Status.State) {
+		return processErr
+	}
+	errMsg := protocol.NewMessage(
+		protocol.MessageRoleAgent,
+		[]protocol.Part{protocol.NewTextPart(processErr.Error())},
+	)
+	if err := m.UpdateTaskStatus(ctx, task.Task.ID, protocol.TaskStateFailed, &errMsg); err != nil {
+		taskManagerLog.Error(err, "Failed to update task status to failed", "taskID", task.Task.ID)
+	}
+
+	return processErr
+}
+
+// upsertTask creates the task if it does not exist yet and records the new message.
+func (m *storeTaskManager) upsertTask(ctx context.Context, params protocol.SendTaskParams) (*StoredTask, error) {
+	newTask := func() *StoredTask {
+		taskManagerLog.Info("Created new task", "taskID", params.ID, "agent", m.agent)
+		return &StoredTask{
+			Agent: m.agent,
+			Task:  *protocol.NewTask(params.ID, params.SessionID),
+		}
+	}
+
+	return m.updateTask(ctx, params.ID, newTask, func(task *StoredTask) error {
+		// a new message starts a new run of a finished task
+		if isFinalState(task.Task.Status.State) {
+			task.Task.Status = protocol.TaskStatus{
+				State:     protocol.TaskStateSubmitted,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+			}
+		}
+		if params.Metadata != nil {
+			if task.Task.Metadata == nil {
+				task.Task.Metadata = make(map[string]interface{})
+			}
+			for k, v := range params.Metadata {
+				task.Task.Metadata[k] = v
+			}
+		}
+		task.History = append(task.History, params.Message)
+		return nil
+	})
+}
+
+// updateTask applies the update to the stored task and saves it, starting over if another
+// replica saved the task in the meantime. The task is created with newTask if it does not
+// exist and newTask is not nil.
+func (m *storeTaskManager) updateTask(
+	ctx context.Context,
+	taskID string,
+	newTask func() *StoredTask,
+	update func(task *StoredTask) error,
+) (*StoredTask, error) {
+	m.taskLock.Lock()
+	defer m.taskLock.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		task, err := m.store.GetTask(ctx, m.agent, taskID)
+		if errors.Is(err, ErrTaskNotFound) && newTask != nil {
+			task, err = newTask(), nil
+		}
+		if err != nil {
+			if errors.Is(err, ErrTaskNotFound) {
+				return nil, taskmanager.ErrTaskNotFound(taskID)
+			}
+			return nil, err
+		}
+
+		if err := update(task); err != nil {
+			return nil, err
+		}
+		truncateTask(task)
+
+		err = m.store.SaveTask(ctx, task)
+		if err == nil {
+			return task, nil
+		}
+		if !errors.Is(err, ErrTaskConflict) || attempt >= maxTaskSaveAttempts {
+			return nil, fmt.Errorf("failed to save task %s: %w", taskID, err)
+		}
+	}
+}
+
+// loadTask reads the task from the store, translating a missing task into the A2A error.
+func (m *storeTaskManager) loadTask(ctx context.Context, taskID string) (*StoredTask, error) {
+	task, err := m.store.GetTask(ctx, m.agent, taskID)
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, taskmanager.ErrTaskNotFound(taskID)
+		}
+		return nil, err
+	}
+	return task, nil
+}
+
+// getTask returns the task including the most recent historyLength messages.
+// A nil historyLength omits the history and 0 includes all of it.
+func (m *storeTaskManager) getTask(ctx context.Context, taskID string, historyLength *int) (*protocol.Task, error) {
+	stored, err := m.loadTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	task := stored.Task
+	task.History = nil
+	if historyLength != nil {
+		history := stored.History
+		if *historyLength > 0 && *historyLength < len(history) {
+			history = history[len(history)-*historyLength:]
+		}
+		task.History = history
+	}
+
+	return &task, nil
+}
+
+func (m *storeTaskManager) setCancelFunc(taskID string, cancel context.CancelFunc) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.cancelFuncs[taskID] = cancel
+}
+
+// watchCancellation cancels the processing of a task once it is stored as canceled,
+// as the cancellation may have been requested from another replica.
+func (m *storeTaskManager) watchCancellation(ctx context.Context, taskID string, cancel context.CancelFunc) {
+	ticker := time.NewTicker(m.cancelPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		task, err := m.store.GetTask(ctx, m.agent, taskID)
+		if err != nil {
+			if ctx.Err() == nil {
+				taskManagerLog.Error(err, "Failed to check task for cancellation", "taskID", taskID)
+			}
+			continue
+		}
+		if task.Task.Status.State == protocol.TaskStateCanceled {
+			taskManagerLog.Info("Stopping canceled task", "taskID", taskID)
+			cancel()
+			return
+		}
+	}
+}
+
+// finishTask forgets the in-memory state of a task once this replica stopped processing it.
+func (m *storeTaskManager) finishTask(taskID string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.cancelFuncs, taskID)
+	delete(m.persistedStates, taskID)
+	delete(m.pendingChunks, taskID)
+}
+
+func (m *storeTaskManager) getPersistedState(taskID string) protocol.TaskState {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.persistedStates[taskID]
+}
+
+func (m *storeTaskManager) setPersistedState(taskID string, state protocol.TaskState) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	// only tasks processed by this replica are tracked
+	if _, ok := m.cancelFuncs[taskID]; ok {
+		m.persistedStates[taskID] = state
+	}
+}
+
+func (m *storeTaskManager) addPendingChunk(taskID string, chunk protocol.Artifact) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.pendingChunks[taskID] = append(m.pendingChunks[taskID], chunk)
+}
+
+func (m *storeTaskManager) takePendingChunks(taskID string) []protocol.Artifact {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	chunks := m.pendingChunks[taskID]
+	delete(m.pendingChunks, taskID)
+	return chunks
+}
+
+func (m *storeTaskManager) addSubscriber(taskID string, ch chan<- protocol.TaskEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.subscribers[taskID] = append(m.subscribers[taskID], ch)
+}
+
+func (m *storeTaskManager) removeSubscriber(taskID string, ch chan<- protocol.TaskEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var remaining []chan<- protocol.TaskEvent
+	for _, existing := range m.subscribers[taskID] {
+		if existing != ch {
+			remaining = append(remaining, existing)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(m.subscribers, taskID)
+	} else {
+		m.subscribers[taskID] = remaining
+	}
+}
+
+// closeSubscribers closes and removes all subscribers of a task once it is no longer processed.
+func (m *storeTaskManager) closeSubscribers(taskID string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, ch := range m.subscribers[taskID] {
+		close(ch)
+	}
+	delete(m.subscribers, taskID)
+}
+
+func (m *storeTaskManager) hasSubscribers(taskID string) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return len(m.subscribers[taskID]) > 0
+}
+
+func (m *storeTaskManager) notifySubscribers(taskID string, event protocol.TaskEvent) {
+	// hold the lock while sending so that subscribers cannot be closed concurrently
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, ch := range m.subscribers[taskID] {
+		// never block the processor on a slow subscriber
+		select {
+		case ch <- event:
+		default:
+			taskManagerLog.Info("Dropping event for slow subscriber", "taskID", taskID)
+		}
+	}
+}
+
+// storeTaskHandle is the taskmanager.TaskHandle passed to the processor of a task.
+type storeTaskHandle struct {
+	taskID    string
+	sessionID *string
+	manager   *storeTaskManager
+}
+
+var _ taskmanager.TaskHandle = &storeTaskHandle{}
+
+func (h *storeTaskHandle) UpdateStatus(state protocol.TaskState, msg *protocol.Message) error {
+	return h.manager.UpdateTaskStatus(context.Background(), h.taskID, state, msg)
+}
+
+func (h *storeTaskHandle) AddArtifact(artifact protocol.Artifact) error {
+	return h.manager.AddArtifact(context.Background(), h.taskID, artifact)
+}
+
+func (h *storeTaskHandle) IsStreamingRequest() bool {
+	return h.manager.hasSubscribers(h.taskID)
+}
+
+func (h *storeTaskHandle) GetSessionID() *string {
+	return h.sessionID
+}
+
+// mergeArtifacts adds the artifacts to the given artifacts in order.
+func mergeArtifacts(artifacts []protocol.Artifact, added []protocol.Artifact) []protocol.Artifact {
+	for _, artifact := range added {
+		artifacts = mergeArtifact(artifacts, artifact)
+	}
+	return artifacts
+}
+
+// isPendingChunk returns whether the artifact is a chunk which is followed by further chunks.
+func isPendingChunk(artifact protocol.Artifact) bool {
+	return artifact.Append != nil && *artifact.Append &&
+		(artifact.LastChunk == nil || !*artifact.LastChunk)
+}
+
+// truncateTask keeps the stored task within maxTaskHistory messages and maxTaskSize bytes.
+// The oldest messages are dropped first, the parts of the artifacts and the status message
+// are only replaced if the task is still too large without its history.
+func truncateTask(task *StoredTask) {
+	if len(task.History) > maxTaskHistory {
+		task.History = task.History[len(task.History)-maxTaskHistory:]
+	}
+	for len(task.History) > 0 && taskSize(task) > maxTaskSize {
+		task.History = task.History[1:]
+	}
+	if taskSize(task) <= maxTaskSize {
+		return
+	}
+
+	truncated := []protocol.Part{protocol.NewTextPart("[truncated: the result exceeds the size limit of stored tasks]")}
+	for i := range task.Task.Artifacts {
+		task.Task.Artifacts[i].Parts = truncated
+	}
+	if task.Task.Status.Message != nil {
+		message := *task.Task.Status.Message
+		message.Parts = truncated
+		task.Task.Status.Message = &message
+	}
+}
+
+func taskSize(task *StoredTask) int {
+	b, err := json.Marshal(task)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// mergeArtifact adds the artifact to the given artifacts. Chunks with the append hint are
+// appended to the artifact with the same index, other artifacts replace it.
+func mergeArtifact(artifacts []protocol.Artifact, artifact protocol.Artifact) []protocol.Artifact {
+	for i := range artifacts {
+		if artifacts[i].Index != artifact.Index {
+			continue
+		}
+		if artifact.Append != nil && *artifact.Append {
+			artifacts[i].Parts = append(artifacts[i].Parts, artifact.Parts...)
+			artifacts[i].LastChunk = artifact.LastChunk
+		} else {
+			artifacts[i] = artifact
+		}
+		return artifacts
+	}
+	return append(artifacts, artifact)
+}
+
+func isFinalState(state protocol.TaskState) bool {
+	return state == protocol.TaskStateCompleted ||
+		state == protocol.TaskStateFailed ||
+		state == protocol.TaskStateCanceled
+}
@@ -0,0 +1,188 @@
+package a2a
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+)
+
+type processorFunc func(ctx context.Context, taskID string, message protocol.Message, handle taskmanager.TaskHandle) error
+
+func (f processorFunc) Process(ctx context.Context, taskID string, message protocol.Message, handle taskmanager.TaskHandle) error {
+	return f(ctx, taskID, message, handle)
+}
+
+// countingTaskStore counts the writes to the wrapped store.
+type countingTaskStore struct {
+	TaskStore
+	saves atomic.Int32
+}
+
+func (c *countingTaskStore) SaveTask(ctx context.Context, task *StoredTask) error {
+	c.saves.Add(1)
+	return c.TaskStore.SaveTask(ctx, task)
+}
+
+func newTestTaskManager(t *testing.T, store TaskStore, processor taskmanager.TaskProcessor) *storeTaskManager {
+	notifier, err := NewPushNotifier(PushNotifierConfig{})
+	require.NoError(t, err)
+	return newStoreTaskManager("kagent/k8s-agent", store, notifier, "", processor)
+}
+
+func TestTaskManagerOnlyStoresStateChanges(t *testing.T) {
+	ctx := context.Background()
+	store := &countingTaskStore{TaskStore: NewMemoryTaskStore()}
+	manager := newTestTaskManager(t, store, processorFunc(func(_ context.Context, _ string, _ protocol.Message, handle taskmanager.TaskHandle) error {
+		for _, tool := range []string{"get_pods", "get_events"} {
+			msg := protocol.NewMessage(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart("calling " + tool)})
+			require.NoError(t, handle.UpdateStatus(protocol.TaskStateWorking, &msg))
+		}
+		for _, chunk := range []string{"all ", "pods ", "are "} {
+			require.NoError(t, handle.AddArtifact(protocol.Artifact{
+				Index:     0,
+				Parts:     []protocol.Part{protocol.NewTextPart(chunk)},
+				Append:    common.MakePtr(true),
+				LastChunk: common.MakePtr(false),
+			}))
+		}
+		require.NoError(t, handle.AddArtifact(protocol.Artifact{
+			Index:     0,
+			Parts:     []protocol.Part{protocol.NewTextPart("running")},
+			Append:    common.MakePtr(true),
+			LastChunk: common.MakePtr(true),
+		}))
+		msg := protocol.NewMessage(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart("all pods are running")})
+		return handle.UpdateStatus(protocol.TaskStateCompleted, &msg)
+	}))
+
+	task, err := manager.OnSendTask(ctx, protocol.SendTaskParams{
+		ID:      "task-1",
+		Message: protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart("are my pods running?")}),
+	})
+	require.NoError(t, err)
+
+	// the new task, working, the last chunk and completed
+	assert.Equal(t, int32(4), store.saves.Load())
+	assert.Equal(t, protocol.TaskStateCompleted, task.Status.State)
+	require.Len(t, task.Artifacts, 1)
+	assert.Len(t, task.Artifacts[0].Parts, 4)
+	assert.Empty(t, manager.pendingChunks)
+	assert.Empty(t, manager.persistedStates)
+
+	stored, err := store.GetTask(ctx, "kagent/k8s-agent", "task-1")
+	require.NoError(t, err)
+	assert.Len(t, stored.History, 2)
+}
+
+func TestTruncateTask(t *testing.T) {
+	task := &StoredTask{
+		Agent: "kagent/k8s-agent",
+		Task:  *protocol.NewTask("task-1", nil),
+	}
+	for range maxTaskHistory + 10 {
+		task.History = append(task.History, protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart("hello")}))
+	}
+	truncateTask(task)
+	assert.Len(t, task.History, maxTaskHistory)
+
+	// large messages are dropped from the history first
+	large := strings.Repeat("a", maxTaskSize/2)
+	task.History = append(task.History,
+		protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart(large)}),
+		protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart(large)}),
+	)
+	truncateTask(task)
+	assert.Len(t, task.History, 1)
+	assert.LessOrEqual(t, taskSize(task), maxTaskSize)
+
+	// the result is replaced if it does not fit on its own
+	task.Task.Artifacts = []protocol.Artifact{{Parts: []protocol.Part{protocol.NewTextPart(large + large)}}}
+	truncateTask(task)
+	assert.Empty(t, task.History)
+	assert.LessOrEqual(t, taskSize(task), maxTaskSize)
+	assert.Contains(t, task.Task.Artifacts[0].Parts[0].(protocol.TextPart).Text, "truncated")
+}
+
+func TestTaskManagerDisconnectCancelsTask(t *testing.T) {
+	ctx, disconnect := context.WithCancel(context.Background())
+	store := NewMemoryTaskStore()
+	manager := newTestTaskManager(t, store, newA2ATaskProcessor(func(ctx context.Context, _ string, _ *string, onUpdate func(TaskUpdate)) (*TaskResult, error) {
+		onUpdate(TaskUpdate{Message: "calling get_pods"})
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, nil, nil))
+
+	events, err := manager.OnSendTaskSubscribe(ctx, protocol.SendTaskParams{
+		ID:      "task-1",
+		Message: protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart("watch my pods")}),
+	})
+	require.NoError(t, err)
+
+	// the client disconnects once the run started
+	<-events
+	disconnect()
+	for range events {
+	}
+
+	stored, err := store.GetTask(context.Background(), "kagent/k8s-agent", "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCanceled, stored.Task.Status.State)
+}
+
+func TestTaskManagerCancelFromOtherReplica(t *testing.T) {
+	ctx := context.Background()
+	kube := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	store := NewConfigMapTaskStore(kube, kube)
+
+	started := make(chan struct{})
+	processing := newTestTaskManager(t, store, processorFunc(func(ctx context.Context, _ string, _ protocol.Message, handle taskmanager.TaskHandle) error {
+		close(started)
+		<-ctx.Done()
+		// a run which ignores the cancellation cannot overwrite it
+		msg := protocol.NewMessage(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart("done")})
+		assert.Error(t, handle.UpdateStatus(protocol.TaskStateCompleted, &msg))
+		return ctx.Err()
+	}))
+	processing.cancelPollInterval = 10 * time.Millisecond
+	other := newTestTaskManager(t, store, nil)
+
+	events, err := processing.OnSendTaskSubscribe(ctx, protocol.SendTaskParams{
+		ID:      "task-1",
+		Message: protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart("watch my pods")}),
+	})
+	require.NoError(t, err)
+	<-started
+
+	task, err := other.OnCancelTask(ctx, protocol.TaskIDParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCanceled, task.Status.State)
+
+	// the stream of the processing replica ends once it noticed the cancellation
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case _, ok := <-events:
+			done = !ok
+		case <-timeout:
+			t.Fatal("processing replica did not stop the canceled task")
+		}
+	}
+
+	task, err = other.OnGetTask(ctx, protocol.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCanceled, task.Status.State)
+
+	// a canceled task cannot be canceled again
+	_, err = other.OnCancelTask(ctx, protocol.TaskIDParams{ID: "task-1"})
+	assert.Error(t, err)
+}
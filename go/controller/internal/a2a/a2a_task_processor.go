@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
-	"github.com/kagent-dev/kagent/go/controller/utils/a2autils"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
@@ -15,21 +14,41 @@ var (
 	processorLog = ctrl.Log.WithName("a2a_task_processor")
 )
 
-type TaskHandler func(ctx context.Context, task string, sessionID *string) (string, error)
+// TaskUpdate is an intermediate update reported by a TaskHandler while it works on a task.
+type TaskUpdate struct {
+	// Message is reported to the client as a working status, e.g. for tool calls.
+	Message string
+	// Chunk is streamed model output, appended to the result artifact.
+	Chunk string
+}
+
+// TaskHandler runs the task and returns its result, reporting progress through onUpdate.
+type TaskHandler func(ctx context.Context, task string, sessionID *string, onUpdate func(TaskUpdate)) (*TaskResult, error)
 
 type a2aTaskProcessor struct {
 	// handleTask is a function that processes the input text.
 	// in production this is done by handing off the input text by a call to
 	// the underlying agentic framework (e.g.: autogen)
 	handleTask TaskHandler
+	// the modes of the agent card, deciding which parts are accepted and returned
+	inputModes  []string
+	outputModes []string
 }
 
 var _ taskmanager.TaskProcessor = &a2aTaskProcessor{}
 
 // newA2ATaskProcessor creates a new A2A task processor.
-func newA2ATaskProcessor(handleTask TaskHandler) taskmanager.TaskProcessor {
+func newA2ATaskProcessor(handleTask TaskHandler, inputModes, outputModes []string) taskmanager.TaskProcessor {
+	if len(inputModes) == 0 {
+		inputModes = defaultInputModes
+	}
+	if len(outputModes) == 0 {
+		outputModes = defaultOutputModes
+	}
 	return &a2aTaskProcessor{
-		handleTask: handleTask,
+		handleTask:  handleTask,
+		inputModes:  inputModes,
+		outputModes: outputModes,
 	}
 }
 
@@ -40,41 +59,34 @@ func (a *a2aTaskProcessor) Process(
 	handle taskmanager.TaskHandle,
 ) error {
 
-	// Extract text from the incoming message.
-	text := a2autils.ExtractText(message)
-	if text == "" {
-		err := fmt.Errorf("input message must contain text")
-		a.handleErr(taskID, err, handle)
+	// Render the parts of the incoming message into the task.
+	text, err := messageToTask(message, a.inputModes)
+	if err != nil {
+		a.handleErr(ctx, taskID, err, handle)
 		return err
 	}
 
 	processorLog.Info("Processing task", "taskID", taskID, "text", text)
 
-	// Process the input text (in this simple example, we'll just reverse it).
 	sessionID := handle.GetSessionID()
-	result, err := a.handleTask(ctx, text, sessionID)
+	result, err := a.handleTask(ctx, text, sessionID, a.makeUpdateHandler(taskID, handle))
 	if err != nil {
-		a.handleErr(taskID, err, handle)
+		a.handleErr(ctx, taskID, err, handle)
 		return err
 	}
-
-	// Create response message.
-	responseMessage := protocol.NewMessage(
-		protocol.MessageRoleAgent,
-		[]protocol.Part{protocol.NewTextPart(fmt.Sprintf("Processed result: %s", result))},
-	)
-
-	// Update task status to completed.
-	if err := handle.UpdateStatus(protocol.TaskStateCompleted, &responseMessage); err != nil {
-		return fmt.Errorf("failed to update task status: %w", err)
+	parts, err := resultToParts(result, a.outputModes)
+	if err != nil {
+		a.handleErr(ctx, taskID, err, handle)
+		return err
 	}
 
-	// Add the processed text as an artifact.
+	// Add the result as an artifact, replacing any chunks streamed before.
+	// This happens before the final status update, as clients stop reading after it.
 	artifact := protocol.Artifact{
 		Name:        common.MakePtr("Task Result"),
 		Description: common.MakePtr("The result of the task processing"),
 		Index:       0,
-		Parts:       []protocol.Part{protocol.NewTextPart(result)},
+		Parts:       parts,
 		LastChunk:   common.MakePtr(true),
 	}
 
@@ -82,22 +94,74 @@ func (a *a2aTaskProcessor) Process(
 		processorLog.Error(err, "Error adding artifact", "taskID", taskID)
 	}
 
+	// Create response message.
+	responseMessage := protocol.NewMessage(protocol.MessageRoleAgent, parts)
+
+	// Update task status to completed.
+	if err := handle.UpdateStatus(protocol.TaskStateCompleted, &responseMessage); err != nil {
+		return fmt.Errorf("failed to update task status: %w", err)
+	}
+
 	return nil
 }
 
+// makeUpdateHandler maps intermediate task updates onto working status updates and artifact chunks.
+// Chunks are only sent to streaming clients, as they are superseded by the final artifact.
+func (a *a2aTaskProcessor) makeUpdateHandler(
+	taskID string,
+	handle taskmanager.TaskHandle,
+) func(TaskUpdate) {
+	streaming := handle.IsStreamingRequest()
+	return func(update TaskUpdate) {
+		if update.Message != "" {
+			workingMessage := protocol.NewMessage(
+				protocol.MessageRoleAgent,
+				[]protocol.Part{protocol.NewTextPart(update.Message)},
+			)
+			if err := handle.UpdateStatus(protocol.TaskStateWorking, &workingMessage); err != nil {
+				processorLog.Error(err, "Failed to update task status", "taskID", taskID)
+			}
+		}
+
+		if update.Chunk != "" && streaming {
+			chunk := protocol.Artifact{
+				Name:      common.MakePtr("Task Result"),
+				Index:     0,
+				Parts:     []protocol.Part{protocol.NewTextPart(update.Chunk)},
+				Append:    common.MakePtr(true),
+				LastChunk: common.MakePtr(false),
+			}
+			if err := handle.AddArtifact(chunk); err != nil {
+				processorLog.Error(err, "Error adding artifact chunk", "taskID", taskID)
+			}
+		}
+	}
+}
+
+// handleErr ends the task as failed, or as canceled if the run was canceled,
+// e.g. because the client disconnected or the task was canceled.
 func (a a2aTaskProcessor) handleErr(
+	ctx context.Context,
 	taskID string,
 	err error,
 	handle taskmanager.TaskHandle,
 ) {
-	processorLog.Error(err, "Task failed", "taskID", taskID)
+	state := protocol.TaskStateFailed
+	text := err.Error()
+	if ctx.Err() != nil {
+		processorLog.Info("Task canceled", "taskID", taskID, "reason", err.Error())
+		state = protocol.TaskStateCanceled
+		text = fmt.Sprintf("Task %s was canceled", taskID)
+	} else {
+		processorLog.Error(err, "Task failed", "taskID", taskID)
+	}
 
-	// Update status to Failed via handle.
+	// Update status to Failed or Canceled via handle.
 	failedMessage := protocol.NewMessage(
 		protocol.MessageRoleAgent,
-		[]protocol.Part{protocol.NewTextPart(err.Error())},
+		[]protocol.Part{protocol.NewTextPart(text)},
 	)
-	updateStatusErr := handle.UpdateStatus(protocol.TaskStateFailed, &failedMessage)
+	updateStatusErr := handle.UpdateStatus(state, &failedMessage)
 	if updateStatusErr != nil {
 		processorLog.Error(updateStatusErr, "Failed to update task status", "taskID", taskID)
 	}
@@ -0,0 +1,212 @@
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+var (
+	taskStoreLog = ctrl.Log.WithName("a2a_task_store")
+)
+
+// maxTaskGCInterval is the longest interval between two runs of the TaskGarbageCollector.
+const maxTaskGCInterval = 10 * time.Minute
+
+var (
+	// ErrTaskNotFound is returned by a TaskStore when the requested task does not exist.
+	ErrTaskNotFound = errors.New("task not found")
+	// ErrTaskConflict is returned by a TaskStore when the task was saved since it was read.
+	ErrTaskConflict = errors.New("task was modified concurrently")
+)
+
+// StoredTask is the persisted state of an A2A task.
+type StoredTask struct {
+	// Agent is the <namespace>/<name> of the agent which owns the task.
+	Agent string `json:"agent"`
+	// Task is the current state of the task, without its history.
+	Task protocol.Task `json:"task"`
+	// History is the full message history of the task.
+	History []protocol.Message `json:"history,omitempty"`
+	// PushNotification is the push notification config of the task, if one was set.
+	PushNotification *protocol.PushNotificationConfig `json:"pushNotification,omitempty"`
+	// Version is the version of the task when it was read, empty for new tasks.
+	// It is set by stores which detect concurrent writes, see SaveTask.
+	Version string `json:"-"`
+}
+
+// TaskStore persists A2A tasks so that they survive handler re-registration,
+// controller restarts and can be served by every controller replica.
+type TaskStore interface {
+	// GetTask returns the task with the given ID owned by the given agent.
+	// It returns ErrTaskNotFound if the task does not exist.
+	GetTask(ctx context.Context, agent string, taskID string) (*StoredTask, error)
+	// SaveTask creates or replaces the given task. Stores shared between replicas return
+	// ErrTaskConflict if the task was saved since it was read at its Version, or if a new
+	// task already exists. On success the Version of the task is updated.
+	SaveTask(ctx context.Context, task *StoredTask) error
+	// DeleteTasks deletes all tasks owned by the given agent.
+	DeleteTasks(ctx context.Context, agent string) error
+	// DeleteExpiredTasks deletes all tasks which were last saved before the given time.
+	DeleteExpiredTasks(ctx context.Context, before time.Time) error
+}
+
+// TaskStoreType is the type of backend used to persist A2A tasks.
+type TaskStoreType string
+
+const (
+	MemoryTaskStoreType    TaskStoreType = "memory"
+	ConfigMapTaskStoreType TaskStoreType = "configmap"
+	FileTaskStoreType      TaskStoreType = "file"
+)
+
+type memoryTaskStore struct {
+	tasks map[string]map[string]memoryTask
+	lock  sync.RWMutex
+}
+
+type memoryTask struct {
+	// data is the serialized task, so that callers never share state with the store
+	data    []byte
+	version uint64
+	savedAt time.Time
+}
+
+var _ TaskStore = &memoryTaskStore{}
+
+// NewMemoryTaskStore returns a TaskStore which keeps tasks in memory.
+// Tasks survive handler re-registration but not controller restarts.
+func NewMemoryTaskStore() TaskStore {
+	return &memoryTaskStore{
+		tasks: make(map[string]map[string]memoryTask),
+	}
+}
+
+func (m *memoryTaskStore) GetTask(_ context.Context, agent string, taskID string) (*StoredTask, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	stored, ok := m.tasks[agent][taskID]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	task, err := unmarshalStoredTask(stored.data)
+	if err != nil {
+		return nil, err
+	}
+	task.Version = strconv.FormatUint(stored.version, 10)
+
+	return task, nil
+}
+
+func (m *memoryTaskStore) SaveTask(_ context.Context, task *StoredTask) error {
+	b, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task %s: %w", task.Task.ID, err)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.tasks[task.Agent] == nil {
+		m.tasks[task.Agent] = make(map[string]memoryTask)
+	}
+	current, exists := m.tasks[task.Agent][task.Task.ID]
+	expectedVersion := ""
+	if exists {
+		expectedVersion = strconv.FormatUint(current.version, 10)
+	}
+	if task.Version != expectedVersion {
+		return ErrTaskConflict
+	}
+	m.tasks[task.Agent][task.Task.ID] = memoryTask{
+		data:    b,
+		version: current.version + 1,
+		savedAt: time.Now(),
+	}
+	task.Version = strconv.FormatUint(current.version+1, 10)
+
+	return nil
+}
+
+func (m *memoryTaskStore) DeleteTasks(_ context.Context, agent string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.tasks, agent)
+
+	return nil
+}
+
+func (m *memoryTaskStore) DeleteExpiredTasks(_ context.Context, before time.Time) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for agent, tasks := range m.tasks {
+		for taskID, stored := range tasks {
+			if stored.savedAt.Before(before) {
+				delete(tasks, taskID)
+			}
+		}
+		if len(tasks) == 0 {
+			delete(m.tasks, agent)
+		}
+	}
+
+	return nil
+}
+
+func unmarshalStoredTask(b []byte) (*StoredTask, error) {
+	var task StoredTask
+	if err := json.Unmarshal(b, &task); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task: %w", err)
+	}
+	return &task, nil
+}
+
+// TaskGarbageCollector periodically deletes tasks which were not updated within their TTL,
+// as tasks are otherwise only deleted together with their agent.
+type TaskGarbageCollector struct {
+	store    TaskStore
+	ttl      time.Duration
+	interval time.Duration
+}
+
+func NewTaskGarbageCollector(store TaskStore, ttl time.Duration) *TaskGarbageCollector {
+	return &TaskGarbageCollector{
+		store:    store,
+		ttl:      ttl,
+		interval: min(ttl, maxTaskGCInterval),
+	}
+}
+
+// Start implements controller-runtime's Runnable interface
+func (g *TaskGarbageCollector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := g.store.DeleteExpiredTasks(ctx, time.Now().Add(-g.ttl)); err != nil {
+			taskStoreLog.Error(err, "Failed to delete expired tasks")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements controller-runtime's LeaderElectionRunnable interface
+func (g *TaskGarbageCollector) NeedLeaderElection() bool {
+	// file and memory stores are not shared between replicas, so every replica collects its tasks
+	return false
+}
@@ -0,0 +1,163 @@
+package a2a
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// TaskStoreAgentLabel is set on every ConfigMap holding an A2A task to the name of the owning agent.
+	TaskStoreAgentLabel = "kagent.dev/a2a-task-agent"
+	// taskStoreSavedAtAnnotation is the RFC3339 time a task ConfigMap was last saved, used to expire tasks.
+	taskStoreSavedAtAnnotation = "kagent.dev/a2a-task-saved-at"
+
+	taskStoreConfigMapKey    = "task.json"
+	taskStoreConfigMapPrefix = "a2a-task-"
+)
+
+type configMapTaskStore struct {
+	// reader is expected to bypass the cache, as task ConfigMaps are not watched
+	reader client.Reader
+	writer client.Writer
+}
+
+var _ TaskStore = &configMapTaskStore{}
+
+// NewConfigMapTaskStore returns a TaskStore which persists every task in a ConfigMap
+// in the namespace of the owning agent. Tasks are limited to the 1MiB ConfigMap size.
+func NewConfigMapTaskStore(reader client.Reader, writer client.Writer) TaskStore {
+	return &configMapTaskStore{
+		reader: reader,
+		writer: writer,
+	}
+}
+
+func (c *configMapTaskStore) GetTask(ctx context.Context, agent string, taskID string) (*StoredTask, error) {
+	ref := common.ParseRefString(agent, "")
+	configMap := &corev1.ConfigMap{}
+	if err := c.reader.Get(ctx, client.ObjectKey{
+		Namespace: ref.Namespace,
+		Name:      taskConfigMapName(agent, taskID),
+	}, configMap); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to get task %s: %w", taskID, err)
+	}
+
+	task, err := unmarshalStoredTask([]byte(configMap.Data[taskStoreConfigMapKey]))
+	if err != nil {
+		return nil, err
+	}
+	task.Version = configMap.ResourceVersion
+
+	return task, nil
+}
+
+func (c *configMapTaskStore) SaveTask(ctx context.Context, task *StoredTask) error {
+	b, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task %s: %w", task.Task.ID, err)
+	}
+
+	ref := common.ParseRefString(task.Agent, "")
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      taskConfigMapName(task.Agent, task.Task.ID),
+			Namespace: ref.Namespace,
+			Labels: map[string]string{
+				TaskStoreAgentLabel: ref.Name,
+			},
+			Annotations: map[string]string{
+				taskStoreSavedAtAnnotation: time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+		Data: map[string]string{
+			taskStoreConfigMapKey: string(b),
+		},
+	}
+
+	// every replica may write a task, e.g. to cancel it, so stale writes are rejected by the API server
+	if task.Version == "" {
+		if err := c.writer.Create(ctx, configMap); err != nil {
+			if k8s_errors.IsAlreadyExists(err) {
+				return ErrTaskConflict
+			}
+			return fmt.Errorf("failed to create task %s: %w", task.Task.ID, err)
+		}
+	} else {
+		configMap.ResourceVersion = task.Version
+		if err := c.writer.Update(ctx, configMap); err != nil {
+			if k8s_errors.IsConflict(err) || k8s_errors.IsNotFound(err) {
+				return ErrTaskConflict
+			}
+			return fmt.Errorf("failed to update task %s: %w", task.Task.ID, err)
+		}
+	}
+	task.Version = configMap.ResourceVersion
+
+	return nil
+}
+
+func (c *configMapTaskStore) DeleteTasks(ctx context.Context, agent string) error {
+	ref := common.ParseRefString(agent, "")
+	var configMaps corev1.ConfigMapList
+	if err := c.reader.List(
+		ctx,
+		&configMaps,
+		client.InNamespace(ref.Namespace),
+		client.MatchingLabels{TaskStoreAgentLabel: ref.Name},
+	); err != nil {
+		return fmt.Errorf("failed to list tasks of agent %s: %w", agent, err)
+	}
+
+	for i := range configMaps.Items {
+		if err := c.writer.Delete(ctx, &configMaps.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete task %s: %w", configMaps.Items[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *configMapTaskStore) DeleteExpiredTasks(ctx context.Context, before time.Time) error {
+	var configMaps corev1.ConfigMapList
+	if err := c.reader.List(ctx, &configMaps, client.HasLabels{TaskStoreAgentLabel}); err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		savedAt := configMap.CreationTimestamp.Time
+		if t, err := time.Parse(time.RFC3339, configMap.Annotations[taskStoreSavedAtAnnotation]); err == nil {
+			savedAt = t
+		}
+		if !savedAt.Before(before) {
+			continue
+		}
+		// do not delete tasks which were saved again since they were listed
+		if err := c.writer.Delete(ctx, configMap, client.Preconditions{
+			ResourceVersion: &configMap.ResourceVersion,
+		}); client.IgnoreNotFound(err) != nil && !k8s_errors.IsConflict(err) {
+			return fmt.Errorf("failed to delete task %s: %w", configMap.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// taskConfigMapName derives a valid ConfigMap name from the client provided task ID.
+func taskConfigMapName(agent string, taskID string) string {
+	sum := sha256.Sum256([]byte(agent + "/" + taskID))
+	return taskStoreConfigMapPrefix + hex.EncodeToString(sum[:16])
+}
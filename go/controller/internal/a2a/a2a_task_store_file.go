@@ -0,0 +1,128 @@
+package a2a
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type fileTaskStore struct {
+	dir  string
+	lock sync.RWMutex
+}
+
+var _ TaskStore = &fileTaskStore{}
+
+// NewFileTaskStore returns a TaskStore which persists every task as a JSON file below dir.
+// Tasks survive controller restarts as long as dir is backed by a persistent volume,
+// but are only shared between replicas if the volume is. Concurrent writes of replicas
+// sharing a volume are not detected, use the ConfigMap store to run several replicas.
+func NewFileTaskStore(dir string) (TaskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create task store directory %s: %w", dir, err)
+	}
+	return &fileTaskStore{
+		dir: dir,
+	}, nil
+}
+
+func (f *fileTaskStore) GetTask(_ context.Context, agent string, taskID string) (*StoredTask, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	b, err := os.ReadFile(f.taskPath(agent, taskID))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to read task %s: %w", taskID, err)
+	}
+
+	return unmarshalStoredTask(b)
+}
+
+func (f *fileTaskStore) SaveTask(_ context.Context, task *StoredTask) error {
+	b, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task %s: %w", task.Task.ID, err)
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	path := f.taskPath(task.Agent, task.Task.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create task directory: %w", err)
+	}
+
+	// write to a temporary file first so that readers never see a partial task
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".task-*")
+	if err != nil {
+		return fmt.Errorf("failed to create task file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write task %s: %w", task.Task.ID, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write task %s: %w", task.Task.ID, err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *fileTaskStore) DeleteTasks(_ context.Context, agent string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return os.RemoveAll(f.agentDir(agent))
+}
+
+func (f *fileTaskStore) DeleteExpiredTasks(_ context.Context, before time.Time) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// the modification time of a task file is the time the task was last saved
+	return filepath.WalkDir(f.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if info.ModTime().Before(before) {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to delete task file %s: %w", path, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (f *fileTaskStore) agentDir(agent string) string {
+	// agent refs are <namespace>/<name>, both of which are valid path elements
+	return filepath.Join(f.dir, filepath.FromSlash(agent))
+}
+
+// taskPath derives a file name from the client provided task ID, which may contain any character.
+func (f *fileTaskStore) taskPath(agent string, taskID string) string {
+	sum := sha256.Sum256([]byte(taskID))
+	return filepath.Join(f.agentDir(agent), hex.EncodeToString(sum[:])+".json")
+}
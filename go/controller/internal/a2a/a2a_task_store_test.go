@@ -0,0 +1,106 @@
+package a2a
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+func TestTaskStores(t *testing.T) {
+	stores := []struct {
+		name     string
+		newStore func(t *testing.T) TaskStore
+		// detectsConflicts is set for the stores which can be shared between replicas
+		detectsConflicts bool
+	}{
+		{
+			name: "memory",
+			newStore: func(t *testing.T) TaskStore {
+				return NewMemoryTaskStore()
+			},
+			detectsConflicts: true,
+		},
+		{
+			name: "configmap",
+			newStore: func(t *testing.T) TaskStore {
+				kube := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+				return NewConfigMapTaskStore(kube, kube)
+			},
+			detectsConflicts: true,
+		},
+		{
+			name: "file",
+			newStore: func(t *testing.T) TaskStore {
+				store, err := NewFileTaskStore(t.TempDir())
+				require.NoError(t, err)
+				return store
+			},
+		},
+	}
+
+	for _, tc := range stores {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := tc.newStore(t)
+
+			_, err := store.GetTask(ctx, "kagent/k8s-agent", "task-1")
+			assert.ErrorIs(t, err, ErrTaskNotFound)
+
+			task := &StoredTask{
+				Agent:   "kagent/k8s-agent",
+				Task:    *protocol.NewTask("task-1", nil),
+				History: []protocol.Message{protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart("hello")})},
+			}
+			require.NoError(t, store.SaveTask(ctx, task))
+			other := &StoredTask{
+				Agent: "kagent/other-agent",
+				Task:  *protocol.NewTask("task-1", nil),
+			}
+			require.NoError(t, store.SaveTask(ctx, other))
+
+			// saving again replaces the task
+			task.Task.Status.State = protocol.TaskStateCompleted
+			require.NoError(t, store.SaveTask(ctx, task))
+			stored, err := store.GetTask(ctx, "kagent/k8s-agent", "task-1")
+			require.NoError(t, err)
+			assert.Equal(t, protocol.TaskStateCompleted, stored.Task.Status.State)
+			assert.Len(t, stored.History, 1)
+
+			if tc.detectsConflicts {
+				// a replica writing a task it read before another replica saved it is rejected
+				stale, err := store.GetTask(ctx, "kagent/k8s-agent", "task-1")
+				require.NoError(t, err)
+				stored.Task.Status.State = protocol.TaskStateCanceled
+				require.NoError(t, store.SaveTask(ctx, stored))
+				stale.Task.Status.State = protocol.TaskStateWorking
+				assert.ErrorIs(t, store.SaveTask(ctx, stale), ErrTaskConflict)
+				assert.ErrorIs(t, store.SaveTask(ctx, &StoredTask{Agent: "kagent/k8s-agent", Task: *protocol.NewTask("task-1", nil)}), ErrTaskConflict)
+
+				current, err := store.GetTask(ctx, "kagent/k8s-agent", "task-1")
+				require.NoError(t, err)
+				assert.Equal(t, protocol.TaskStateCanceled, current.Task.Status.State)
+			}
+
+			// tasks saved since the cutoff are kept
+			require.NoError(t, store.DeleteExpiredTasks(ctx, time.Now().Add(-time.Hour)))
+			_, err = store.GetTask(ctx, "kagent/k8s-agent", "task-1")
+			require.NoError(t, err)
+
+			require.NoError(t, store.DeleteTasks(ctx, "kagent/k8s-agent"))
+			_, err = store.GetTask(ctx, "kagent/k8s-agent", "task-1")
+			assert.ErrorIs(t, err, ErrTaskNotFound)
+			_, err = store.GetTask(ctx, "kagent/other-agent", "task-1")
+			require.NoError(t, err)
+
+			require.NoError(t, store.DeleteExpiredTasks(ctx, time.Now().Add(time.Hour)))
+			_, err = store.GetTask(ctx, "kagent/other-agent", "task-1")
+			assert.ErrorIs(t, err, ErrTaskNotFound)
+		})
+	}
+}
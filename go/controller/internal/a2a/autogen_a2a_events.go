@@ -0,0 +1,140 @@
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+)
+
+const (
+	autogenTaskResultEvent = "task_result"
+	autogenErrorEventType  = "error"
+
+	modelClientStreamingChunkEventType = "ModelClientStreamingChunkEvent"
+	toolCallRequestEventType           = "ToolCallRequestEvent"
+	toolCallExecutionEventType         = "ToolCallExecutionEvent"
+)
+
+// autogenEvent is the part of an autogen agentchat event needed to report task progress.
+type autogenEvent struct {
+	Type    string          `json:"type"`
+	Source  string          `json:"source"`
+	Content json.RawMessage `json:"content"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type autogenFunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+type autogenFunctionExecutionResult struct {
+	CallID  string `json:"call_id"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	IsError bool   `json:"is_error"`
+}
+
+// consumeAutogenEvents reads the events of an autogen run until its task result,
+// reporting streamed model output and tool calls through onUpdate.
+func consumeAutogenEvents(
+	ctx context.Context,
+	events <-chan *autogen_client.SseEvent,
+	onUpdate func(TaskUpdate),
+) (*autogen_client.TaskResult, error) {
+	// drain the remaining events so that the reading goroutine of the client can exit
+	defer func() {
+		go func() {
+			for range events {
+			}
+		}()
+	}()
+
+	var taskResult *autogen_client.TaskResult
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				if taskResult == nil {
+					return nil, fmt.Errorf("autogen stream ended without a task result")
+				}
+				return taskResult, nil
+			}
+
+			if event.Event == autogenTaskResultEvent {
+				var result autogen_client.InvokeTaskResult
+				if err := json.Unmarshal(event.Data, &result); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal task result: %w", err)
+				}
+				taskResult = &result.TaskResult
+				continue
+			}
+
+			var autogenEvt autogenEvent
+			if err := json.Unmarshal(event.Data, &autogenEvt); err != nil {
+				// unknown events must not fail the task
+				continue
+			}
+			if autogenEvt.Type == autogenErrorEventType {
+				var errData struct {
+					Message string `json:"message"`
+				}
+				if err := json.Unmarshal(autogenEvt.Data, &errData); err != nil || errData.Message == "" {
+					return nil, fmt.Errorf("autogen run failed: %s", string(autogenEvt.Data))
+				}
+				return nil, fmt.Errorf("autogen run failed: %s", errData.Message)
+			}
+
+			if update, ok := translateAutogenEvent(&autogenEvt); ok {
+				onUpdate(update)
+			}
+		}
+	}
+}
+
+// translateAutogenEvent maps an autogen event onto a task update, if it is of interest to A2A clients.
+func translateAutogenEvent(event *autogenEvent) (TaskUpdate, bool) {
+	switch event.Type {
+	case modelClientStreamingChunkEventType:
+		var chunk string
+		if err := json.Unmarshal(event.Content, &chunk); err != nil || chunk == "" {
+			return TaskUpdate{}, false
+		}
+		return TaskUpdate{Chunk: chunk}, true
+	case toolCallRequestEventType:
+		var calls []autogenFunctionCall
+		if err := json.Unmarshal(event.Content, &calls); err != nil || len(calls) == 0 {
+			return TaskUpdate{}, false
+		}
+		var lines []string
+		for _, call := range calls {
+			lines = append(lines, fmt.Sprintf("%s is calling tool %s with arguments %s", event.Source, call.Name, call.Arguments))
+		}
+		return TaskUpdate{Message: strings.Join(lines, "\n")}, true
+	case toolCallExecutionEventType:
+		var results []autogenFunctionExecutionResult
+		if err := json.Unmarshal(event.Content, &results); err != nil || len(results) == 0 {
+			return TaskUpdate{}, false
+		}
+		var lines []string
+		for _, result := range results {
+			name := result.Name
+			if name == "" {
+				name = result.CallID
+			}
+			if result.IsError {
+				lines = append(lines, fmt.Sprintf("Tool %s failed: %s", name, result.Content))
+			} else {
+				lines = append(lines, fmt.Sprintf("Tool %s returned: %s", name, result.Content))
+			}
+		}
+		return TaskUpdate{Message: strings.Join(lines, "\n")}, true
+	default:
+		return TaskUpdate{}, false
+	}
+}
@@ -0,0 +1,51 @@
+package a2a
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+)
+
+func TestConsumeAutogenEvents(t *testing.T) {
+	t.Run("should report chunks and tool calls and return the task result", func(t *testing.T) {
+		events := make(chan *autogen_client.SseEvent, 5)
+		events <- &autogen_client.SseEvent{Event: "event", Data: []byte(`{"type":"ToolCallRequestEvent","source":"k8s_agent","content":[{"id":"1","name":"get_pods","arguments":"{}"}]}`)}
+		events <- &autogen_client.SseEvent{Event: "event", Data: []byte(`{"type":"ToolCallExecutionEvent","source":"k8s_agent","content":[{"call_id":"1","name":"get_pods","content":"pod-a"}]}`)}
+		events <- &autogen_client.SseEvent{Event: "event", Data: []byte(`{"type":"ModelClientStreamingChunkEvent","source":"k8s_agent","content":"pod-"}`)}
+		events <- &autogen_client.SseEvent{Event: "task_result", Data: []byte(`{"task_result":{"messages":[{"content":"pod-a"}]}}`)}
+		close(events)
+
+		var updates []TaskUpdate
+		result, err := consumeAutogenEvents(context.Background(), events, func(update TaskUpdate) {
+			updates = append(updates, update)
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Messages, 1)
+		assert.Equal(t, []TaskUpdate{
+			{Message: "k8s_agent is calling tool get_pods with arguments {}"},
+			{Message: "Tool get_pods returned: pod-a"},
+			{Chunk: "pod-"},
+		}, updates)
+	})
+
+	t.Run("should fail on error events", func(t *testing.T) {
+		events := make(chan *autogen_client.SseEvent, 1)
+		events <- &autogen_client.SseEvent{Event: "error", Data: []byte(`{"type":"error","data":{"message":"model unavailable"}}`)}
+		close(events)
+
+		_, err := consumeAutogenEvents(context.Background(), events, func(TaskUpdate) {})
+		require.ErrorContains(t, err, "model unavailable")
+	})
+
+	t.Run("should fail if the stream ends without a task result", func(t *testing.T) {
+		events := make(chan *autogen_client.SseEvent)
+		close(events)
+
+		_, err := consumeAutogenEvents(context.Background(), events, func(TaskUpdate) {})
+		require.Error(t, err)
+	})
+}
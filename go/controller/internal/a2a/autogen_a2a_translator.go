@@ -2,13 +2,13 @@ package a2a
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 
 	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
 	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
 	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"trpc.group/trpc-go/trpc-a2a-go/server"
 )
 
@@ -17,13 +17,13 @@ type AutogenA2ATranslator interface {
 	TranslateHandlerForAgent(
 		ctx context.Context,
 		agent *v1alpha1.Agent,
-		autogenTeam *autogen_client.Team,
 	) (*A2AHandlerParams, error)
 }
 
 type autogenA2ATranslator struct {
 	a2aBaseUrl    string
 	autogenClient autogen_client.Client
+	kube          client.Client
 }
 
 var _ AutogenA2ATranslator = &autogenA2ATranslator{}
@@ -31,17 +31,18 @@ var _ AutogenA2ATranslator = &autogenA2ATranslator{}
 func NewAutogenA2ATranslator(
 	a2aBaseUrl string,
 	autogenClient autogen_client.Client,
+	kube client.Client,
 ) AutogenA2ATranslator {
 	return &autogenA2ATranslator{
 		a2aBaseUrl:    a2aBaseUrl,
 		autogenClient: autogenClient,
+		kube:          kube,
 	}
 }
 
 func (a *autogenA2ATranslator) TranslateHandlerForAgent(
 	ctx context.Context,
 	agent *v1alpha1.Agent,
-	autogenTeam *autogen_client.Team,
 ) (*A2AHandlerParams, error) {
 	card, err := a.translateCardForAgent(ctx, agent)
 	if err != nil {
@@ -51,14 +52,19 @@ func (a *autogenA2ATranslator) TranslateHandlerForAgent(
 		return nil, nil
 	}
 
-	handler, err := a.makeHandlerForTeam(autogenTeam)
+	// the autogen team of an agent is labeled with the agent name
+	handler, err := a.makeHandlerForTeam(agent.Name)
 	if err != nil {
 		return nil, err
 	}
 
+	authProvider, authInfo := translateAuthentication(a.kube, agent.Namespace, agent.Spec.A2AConfig.Authentication)
+	card.Authentication = authInfo
+
 	return &A2AHandlerParams{
-		AgentCard:  *card,
-		HandleTask: handler,
+		AgentCard:    *card,
+		HandleTask:   handler,
+		AuthProvider: authProvider,
 	}, nil
 }
 
@@ -78,6 +84,14 @@ func (a *autogenA2ATranslator) translateCardForAgent(
 	for _, skill := range skills {
 		convertedSkills = append(convertedSkills, server.AgentSkill(skill))
 	}
+	inputModes := a2AConfig.DefaultInputModes
+	if len(inputModes) == 0 {
+		inputModes = defaultInputModes
+	}
+	outputModes := a2AConfig.DefaultOutputModes
+	if len(outputModes) == 0 {
+		outputModes = defaultOutputModes
+	}
 	return &server.AgentCard{
 		Name:        agent.Name,
 		Description: common.MakePtr(agent.Spec.Description),
@@ -85,66 +99,78 @@ func (a *autogenA2ATranslator) translateCardForAgent(
 		//Provider:           nil,
 		Version: fmt.Sprintf("%v", agent.Generation),
 		//DocumentationURL:   nil,
-		//Capabilities:       server.AgentCapabilities{},
-		//Authentication:     nil,
-		DefaultInputModes:  []string{"text"},
-		DefaultOutputModes: []string{"text"},
+		Capabilities: server.AgentCapabilities{
+			Streaming: true,
+		},
+		DefaultInputModes:  inputModes,
+		DefaultOutputModes: outputModes,
 		Skills:             convertedSkills,
 	}, nil
 }
 
+// makeHandlerForTeam returns a handler which invokes the autogen team with the given label.
+// The team is looked up on every invocation, so that replicas which do not reconcile
+// the team themselves always invoke the version last pushed to the backend.
 func (a *autogenA2ATranslator) makeHandlerForTeam(
-	autogenTeam *autogen_client.Team,
+	teamLabel string,
 ) (TaskHandler, error) {
-	return func(ctx context.Context, task string, sessionID *string) (string, error) {
-		var taskResult *autogen_client.TaskResult
+	return func(ctx context.Context, task string, sessionID *string, onUpdate func(TaskUpdate)) (*TaskResult, error) {
+		autogenTeam, err := a.autogenClient.GetTeam(ctx, teamLabel, common.GetGlobalUserID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team %s: %w", teamLabel, err)
+		}
+		if autogenTeam == nil {
+			return nil, fmt.Errorf("team %s not found", teamLabel)
+		}
+
+		var events <-chan *autogen_client.SseEvent
 		if sessionID != nil && *sessionID != "" {
-			session, err := a.autogenClient.GetSession(*sessionID, common.GetGlobalUserID())
+			session, err := a.autogenClient.GetSession(ctx, *sessionID, common.GetGlobalUserID())
 			if err != nil {
 				if errors.Is(err, autogen_client.NotFoundError) {
-					session, err = a.autogenClient.CreateSession(&autogen_client.CreateSession{
+					session, err = a.autogenClient.CreateSession(ctx, &autogen_client.CreateSession{
 						Name:   *sessionID,
 						UserID: common.GetGlobalUserID(),
 						TeamID: autogenTeam.Id,
 					})
 					if err != nil {
-						return "", fmt.Errorf("failed to create session: %w", err)
+						return nil, fmt.Errorf("failed to create session: %w", err)
 					}
 				} else {
-					return "", fmt.Errorf("failed to get session: %w", err)
+					return nil, fmt.Errorf("failed to get session: %w", err)
 				}
 			}
-			resp, err := a.autogenClient.InvokeSession(session.ID, common.GetGlobalUserID(), task)
+			events, err = a.autogenClient.InvokeSessionStream(ctx, session.ID, common.GetGlobalUserID(), task)
 			if err != nil {
-				return "", fmt.Errorf("failed to invoke task: %w", err)
+				return nil, fmt.Errorf("failed to invoke task: %w", err)
 			}
-			taskResult = &resp.TaskResult
 		} else {
-
-			resp, err := a.autogenClient.InvokeTask(&autogen_client.InvokeTaskRequest{
+			events, err = a.autogenClient.InvokeTaskStream(ctx, &autogen_client.InvokeTaskRequest{
 				Task:       task,
 				TeamConfig: autogenTeam.Component,
 			})
 			if err != nil {
-				return "", fmt.Errorf("failed to invoke task: %w", err)
+				return nil, fmt.Errorf("failed to invoke task: %w", err)
 			}
-			taskResult = &resp.TaskResult
 		}
 
-		var lastMessageContent string
-		for _, msg := range taskResult.Messages {
-			switch msg["content"].(type) {
+		taskResult, err := consumeAutogenEvents(ctx, events, onUpdate)
+		if err != nil {
+			return nil, err
+		}
+
+		// structured content, e.g. of structured messages, is returned as data
+		result := &TaskResult{}
+		if len(taskResult.Messages) > 0 {
+			switch content := taskResult.Messages[len(taskResult.Messages)-1]["content"].(type) {
 			case string:
-				lastMessageContent = msg["content"].(string)
+				result.Text = content
+			case nil:
 			default:
-				b, err := json.Marshal(msg["content"])
-				if err != nil {
-					return "", fmt.Errorf("failed to marshal message content: %w", err)
-				}
-				lastMessageContent = string(b)
+				result.Data = content
 			}
 		}
 
-		return lastMessageContent, nil
+		return result, nil
 	}, nil
 }
@@ -0,0 +1,76 @@
+package a2a
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	autogen_fake "github.com/kagent-dev/kagent/go/autogen/fake"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAutogenA2ATranslatorHandleTask(t *testing.T) {
+	backend := autogen_fake.NewServer()
+	defer backend.Close()
+	backend.SetAgent("k8s-agent", autogen_fake.Script(
+		&autogen_fake.Reply{
+			Events: []autogen_client.TaskMessageMap{
+				autogen_fake.ToolCallRequest("k8s_agent", "1", "get_pods", "{}"),
+				autogen_fake.ToolCallExecution("k8s_agent", "1", "get_pods", "pod-a"),
+			},
+			Messages: []autogen_client.TaskMessageMap{autogen_fake.TextMessage("k8s_agent", "found pod-a")},
+		},
+		autogen_fake.TextReply("k8s_agent", "still pod-a"),
+	))
+	autogenClient := backend.Client()
+	ctx := context.Background()
+
+	require.NoError(t, autogenClient.CreateTeam(ctx, &autogen_client.Team{
+		BaseObject: autogen_client.BaseObject{UserID: common.GetGlobalUserID()},
+		Component:  &api.Component{Label: "k8s-agent"},
+	}))
+
+	agent := &v1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent"},
+		Spec: v1alpha1.AgentSpec{
+			A2AConfig: &v1alpha1.A2AConfig{
+				Skills: []v1alpha1.AgentSkill{{ID: "pods", Name: "pods"}},
+			},
+		},
+	}
+	translator := NewAutogenA2ATranslator("http://localhost:8083/api/a2a", autogenClient, fake.NewClientBuilder().Build())
+	params, err := translator.TranslateHandlerForAgent(ctx, agent)
+	require.NoError(t, err)
+	require.NotNil(t, params)
+
+	var updates []TaskUpdate
+	result, err := params.HandleTask(ctx, "list pods", nil, func(update TaskUpdate) {
+		updates = append(updates, update)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "found pod-a", result.Text)
+	assert.Equal(t, []TaskUpdate{
+		{Message: "k8s_agent is calling tool get_pods with arguments {}"},
+		{Message: "Tool get_pods returned: pod-a"},
+	}, updates)
+
+	// tasks with a session id run in an autogen session of that name
+	sessionID := "a2a-session"
+	result, err = params.HandleTask(ctx, "list pods again", &sessionID, func(TaskUpdate) {})
+	require.NoError(t, err)
+	assert.Equal(t, "still pod-a", result.Text)
+
+	session, err := autogenClient.GetSession(ctx, sessionID, common.GetGlobalUserID())
+	require.NoError(t, err)
+	runs, err := autogenClient.ListSessionRuns(ctx, session.ID, common.GetGlobalUserID())
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "complete", runs[0].Status)
+}
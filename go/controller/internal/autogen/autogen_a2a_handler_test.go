@@ -0,0 +1,74 @@
+package autogen_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	autogen_fake "github.com/kagent-dev/kagent/go/autogen/fake"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/a2a"
+	"github.com/kagent-dev/kagent/go/controller/internal/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestA2AHandlersFollowAgents checks that the A2A handlers of a replica are added and removed
+// as the agent informer reports changes, independently of the autogen reconciler.
+func TestA2AHandlersFollowAgents(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, v1alpha1.AddToScheme(scheme.Scheme))
+	agent := &v1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent", Generation: 1},
+		Spec: v1alpha1.AgentSpec{
+			Description: "Kubernetes agent",
+			A2AConfig: &v1alpha1.A2AConfig{
+				Skills: []v1alpha1.AgentSkill{{ID: "get-pods", Name: "Get pods"}},
+			},
+		},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(agent).Build()
+	backend := autogen_fake.NewServer()
+	defer backend.Close()
+
+	taskStore := a2a.NewMemoryTaskStore()
+	pushNotifier, err := a2a.NewPushNotifier(a2a.PushNotifierConfig{})
+	require.NoError(t, err)
+	mux := a2a.NewA2AHttpMux("/api/a2a", taskStore, pushNotifier)
+	reconciler := &controller.A2AAgentReconciler{
+		Client:     kube,
+		Scheme:     scheme.Scheme,
+		Reconciler: a2a.NewAutogenReconciler(backend.Client(), kube, mux, "http://kagent/api/a2a", taskStore),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "kagent", Name: "k8s-agent"}}
+
+	_, err = reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+	cards := mux.ListAgentCards(a2a.AgentCatalogFilter{})
+	require.Len(t, cards, 1)
+	assert.Equal(t, "http://kagent/api/a2a/kagent/k8s-agent", cards[0].Card.URL)
+
+	// disabling A2A removes the handler
+	agent.Spec.A2AConfig = nil
+	require.NoError(t, kube.Update(ctx, agent))
+	_, err = reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, mux.ListAgentCards(a2a.AgentCatalogFilter{}))
+
+	agent.Spec.A2AConfig = &v1alpha1.A2AConfig{Skills: []v1alpha1.AgentSkill{{ID: "get-pods", Name: "Get pods"}}}
+	require.NoError(t, kube.Update(ctx, agent))
+	_, err = reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, mux.ListAgentCards(a2a.AgentCatalogFilter{}), 1)
+
+	// deleted agents are removed
+	require.NoError(t, kube.Delete(ctx, agent))
+	_, err = reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, mux.ListAgentCards(a2a.AgentCatalogFilter{}))
+}
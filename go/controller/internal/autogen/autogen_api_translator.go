@@ -15,9 +15,12 @@ import (
 	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 var (
@@ -102,7 +105,7 @@ func (a *apiTranslator) getConfigMapValue(ctx context.Context, source *v1alpha1.
 		namespace,
 	)
 	if err != nil {
-		return "", fmt.Errorf("failed to find ConfigMap for %s: %v", source.ValueRef, err)
+		return "", fmt.Errorf("failed to find ConfigMap for %s: %w", source.ValueRef, err)
 	}
 
 	value, exists := configMap.Data[source.Key]
@@ -127,7 +130,7 @@ func (a *apiTranslator) getSecretValue(ctx context.Context, source *v1alpha1.Val
 		namespace,
 	)
 	if err != nil {
-		return "", fmt.Errorf("failed to find Secret for %s: %v", source.ValueRef, err)
+		return "", fmt.Errorf("failed to find Secret for %s: %w", source.ValueRef, err)
 	}
 
 	value, exists := secret.Data[source.Key]
@@ -154,7 +157,7 @@ func (a *apiTranslator) translateToolServerConfig(ctx context.Context, config v1
 					value, err := a.resolveValueSource(ctx, envVar.ValueFrom, namespace)
 
 					if err != nil {
-						return "", nil, fmt.Errorf("failed to resolve environment variable %s: %v", envVar.Name, err)
+						return "", nil, fmt.Errorf("failed to resolve environment variable %s: %w", envVar.Name, err)
 					}
 
 					env[envVar.Name] = value
@@ -183,7 +186,7 @@ func (a *apiTranslator) translateToolServerConfig(ctx context.Context, config v1
 					value, err := a.resolveValueSource(ctx, header.ValueFrom, namespace)
 
 					if err != nil {
-						return "", nil, fmt.Errorf("failed to resolve header %s: %v", header.Name, err)
+						return "", nil, fmt.Errorf("failed to resolve header %s: %w", header.Name, err)
 					}
 
 					headers[header.Name] = value
@@ -312,44 +315,16 @@ func (a *apiTranslator) translateGroupChatForTeam(
 
 	modelConfigRef := a.defaultModelConfig
 	if team.Spec.ModelConfig != "" {
-		modelConfigRef = types.NamespacedName{
-			Name:      team.Spec.ModelConfig,
-			Namespace: team.Namespace,
-		}
+		modelConfigRef = getRefFromString(team.Spec.ModelConfig, team.Namespace)
 	}
-	modelConfig := &v1alpha1.ModelConfig{}
-	err := fetchObjKube(
-		ctx,
-		a.kube,
-		modelConfig,
-		modelConfigRef.Name,
-		modelConfigRef.Namespace,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	modelClientWithStreaming, err := a.createModelClientForProvider(ctx, modelConfig, true)
-	if err != nil {
-		return nil, err
-	}
-
-	modelClientWithoutStreaming, err := a.createModelClientForProvider(ctx, modelConfig, false)
+	// participants which share a model config share its model clients
+	modelClientsCache := map[types.NamespacedName]*modelClients{}
+	teamModelClients, err := a.getModelClients(ctx, modelConfigRef, modelClientsCache)
 	if err != nil {
 		return nil, err
 	}
 
-	modelContext := &api.Component{
-		Provider:      "autogen_core.model_context.UnboundedChatCompletionContext",
-		ComponentType: "chat_completion_context",
-		Version:       1,
-		Description:   "An unbounded chat completion context that keeps a view of the all the messages.",
-		Label:         "UnboundedChatCompletionContext",
-		Config:        map[string]interface{}{},
-	}
-
-	var participants []*api.Component
-
+	var agents []*v1alpha1.Agent
 	for _, agentName := range team.Spec.Participants {
 		agent := &v1alpha1.Agent{}
 		err := fetchObjKube(
@@ -362,14 +337,38 @@ func (a *apiTranslator) translateGroupChatForTeam(
 		if err != nil {
 			return nil, err
 		}
+		agents = append(agents, agent)
+	}
+
+	withPlanningAgent := swarmTeamConfig != nil && !swarmTeamConfig.DisablePlanningAgent
+	var participants []*api.Component
+
+	for _, agent := range agents {
+		agentName := agent.Name
+
+		var handoffs []api.Handoff
+		if swarmTeamConfig != nil {
+			handoffs, err = translateHandoffs(agent, agents, withPlanningAgent)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		participantModelClients := teamModelClients
+		if team.Spec.ModelConfigOverride != v1alpha1.ModelConfigOverrideAll && agent.Spec.ModelConfig != "" {
+			participantModelClients, err = a.getModelClients(ctx, getRefFromString(agent.Spec.ModelConfig, agent.Namespace), modelClientsCache)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create model client for participant %s: %w", agentName, err)
+			}
+		}
 
 		participant, err := a.translateAssistantAgent(
 			ctx,
 			agent,
-			modelConfig,
-			modelClientWithStreaming,
-			modelClientWithoutStreaming,
-			modelContext,
+			participantModelClients.modelConfig,
+			participantModelClients.streaming,
+			participantModelClients.nonStreaming,
+			handoffs,
 			opts,
 			state,
 		)
@@ -380,17 +379,20 @@ func (a *apiTranslator) translateGroupChatForTeam(
 		participants = append(participants, participant)
 	}
 
-	if swarmTeamConfig != nil {
+	if withPlanningAgent {
 		planningAgent := MakeBuiltinPlanningAgent(
-			"planning_agent",
+			planningAgentName,
 			participants,
-			modelClientWithStreaming,
+			teamModelClients.streaming,
 		)
 		// prepend builtin planning agent when using swarm mode
 		participants = append(
 			[]*api.Component{planningAgent},
 			participants...,
 		)
+	} else if swarmTeamConfig != nil && len(agents) > 0 && len(agents[0].Spec.Handoffs) == 0 {
+		// the swarm starts with its first participant, which has to hand off to the others
+		return nil, fmt.Errorf("the first participant %s of swarm team %s must have handoffs when the planning agent is disabled", agents[0].Name, team.Name)
 	}
 
 	terminationCondition, err := translateTerminationCondition(team.Spec.TerminationCondition)
@@ -422,6 +424,7 @@ func (a *apiTranslator) translateGroupChatForTeam(
 			Description:   team.Spec.Description,
 			Config: api.MustToConfig(&api.SelectorGroupChatConfig{
 				CommonTeamConfig: commonTeamConfig,
+				ModelClient:      teamModelClients.nonStreaming,
 				SelectorPrompt:   selectorTeamConfig.SelectorPrompt,
 			}),
 		}
@@ -433,6 +436,7 @@ func (a *apiTranslator) translateGroupChatForTeam(
 			Description:   team.Spec.Description,
 			Config: api.MustToConfig(&api.MagenticOneGroupChatConfig{
 				CommonTeamConfig:  commonTeamConfig,
+				ModelClient:       teamModelClients.nonStreaming,
 				MaxStalls:         magenticOneTeamConfig.MaxStalls,
 				FinalAnswerPrompt: magenticOneTeamConfig.FinalAnswerPrompt,
 			}),
@@ -461,6 +465,55 @@ func (a *apiTranslator) translateGroupChatForTeam(
 	}, nil
 }
 
+// modelClients are the model client components of a model config.
+type modelClients struct {
+	modelConfig  *v1alpha1.ModelConfig
+	streaming    *api.Component
+	nonStreaming *api.Component
+}
+
+// getModelClients creates the model clients of the referenced model config, or returns
+// the ones created before for the same model config.
+func (a *apiTranslator) getModelClients(
+	ctx context.Context,
+	modelConfigRef types.NamespacedName,
+	cache map[types.NamespacedName]*modelClients,
+) (*modelClients, error) {
+	if clients, ok := cache[modelConfigRef]; ok {
+		return clients, nil
+	}
+
+	modelConfig := &v1alpha1.ModelConfig{}
+	err := fetchObjKube(
+		ctx,
+		a.kube,
+		modelConfig,
+		modelConfigRef.Name,
+		modelConfigRef.Namespace,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	streaming, err := a.createModelClient(ctx, modelConfig, true)
+	if err != nil {
+		return nil, err
+	}
+
+	nonStreaming, err := a.createModelClient(ctx, modelConfig, false)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := &modelClients{
+		modelConfig:  modelConfig,
+		streaming:    streaming,
+		nonStreaming: nonStreaming,
+	}
+	cache[modelConfigRef] = clients
+	return clients, nil
+}
+
 func (a *apiTranslator) simpleRoundRobinTeam(ctx context.Context, agent *v1alpha1.Agent, name string) (*v1alpha1.Team, error) {
 
 	modelConfig := a.defaultModelConfig
@@ -471,7 +524,7 @@ func (a *apiTranslator) simpleRoundRobinTeam(ctx context.Context, agent *v1alpha
 			Namespace: agent.Namespace,
 		}
 	}
-	if err := a.kube.Get(ctx, modelConfig, &v1alpha1.ModelConfig{}); err != nil {
+	if err := fetchObjKube(ctx, a.kube, &v1alpha1.ModelConfig{}, modelConfig.Name, modelConfig.Namespace); err != nil {
 		return nil, err
 	}
 	// generate an internal round robin "team" for the society of mind agent
@@ -505,7 +558,7 @@ func (a *apiTranslator) translateAssistantAgent(
 	modelConfig *v1alpha1.ModelConfig,
 	modelClientWithStreaming *api.Component,
 	modelClientWithoutStreaming *api.Component,
-	modelContext *api.Component,
+	handoffs []api.Handoff,
 	opts *teamOptions,
 	state *tState,
 ) (*api.Component, error) {
@@ -587,11 +640,28 @@ func (a *apiTranslator) translateAssistantAgent(
 
 			tools = append(tools, tool)
 
+		case tool.RemoteAgent != nil:
+			autogenTool, err := translateRemoteAgentTool(
+				ctx,
+				a.kube,
+				tool.RemoteAgent.Ref,
+				agent.Namespace,
+			)
+			if err != nil {
+				return nil, err
+			}
+			tools = append(tools, autogenTool)
+
 		default:
 			return nil, fmt.Errorf("tool must have a provider or tool server")
 		}
 	}
 
+	modelContext, err := translateModelContext(agent.Spec.ModelContext, modelConfig, modelClientWithoutStreaming)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate model context of agent %s: %w", agent.Name, err)
+	}
+
 	sysMsg := agent.Spec.SystemMessage
 
 	cfg := &api.AssistantAgentConfig{
@@ -603,6 +673,7 @@ func (a *apiTranslator) translateAssistantAgent(
 		SystemMessage:         sysMsg,
 		ReflectOnToolUse:      false,
 		ToolCallSummaryFormat: "\nTool: \n{tool_name}\n\nArguments:\n\n{arguments}\n\nResult: \n{result}\n",
+		Handoffs:              handoffs,
 	}
 
 	if opts.stream {
@@ -691,7 +762,7 @@ func (a *apiTranslator) translateBuiltinTool(
 		if (modelConfig.Spec.Provider != v1alpha1.OpenAI) && modelConfig.Spec.Provider != v1alpha1.AzureOpenAI {
 			return nil, fmt.Errorf("tool %s requires OpenAI API key, but model config is not OpenAI", tool.Name)
 		}
-		apiKey, err := a.getModelConfigApiKey(ctx, modelConfig)
+		apiKey, err := getModelConfigApiKey(ctx, a.kube, modelConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get model config api key: %v", err)
 		}
@@ -742,6 +813,61 @@ func translateToolServerTool(
 	return nil, fmt.Errorf("tool %v not found in discovered tools in ToolServer %v", toolName, toolServer.Name)
 }
 
+// translateRemoteAgentTool creates a tool which sends tasks to a remote agent.
+// The tool calls the controller, which talks A2A to the remote agent on its behalf.
+func translateRemoteAgentTool(
+	ctx context.Context,
+	kube client.Client,
+	remoteAgentRef string,
+	agentNamespace string,
+) (*api.Component, error) {
+	remoteAgent := &v1alpha1.RemoteAgent{}
+	err := fetchObjKube(
+		ctx,
+		kube,
+		remoteAgent,
+		remoteAgentRef,
+		agentNamespace,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// requires the agent card to have been fetched
+	card := remoteAgent.Status.AgentCard
+	if card == nil {
+		return nil, fmt.Errorf("agent card of RemoteAgent %v has not been fetched", remoteAgent.Name)
+	}
+
+	description := remoteAgent.Spec.Description
+	if description == "" {
+		description = card.Description
+	}
+	if len(card.Skills) > 0 {
+		description += "\n\nSkills:"
+		for _, skill := range card.Skills {
+			description += "\n- " + skill.Name
+			if skill.Description != nil && *skill.Description != "" {
+				description += ": " + *skill.Description
+			}
+		}
+	}
+	description = strings.TrimSpace(description)
+
+	return &api.Component{
+		Provider:      "kagent.tools.a2a.RemoteAgentTool",
+		ComponentType: "tool",
+		Version:       1,
+		Description:   description,
+		Label:         remoteAgent.Name,
+		Config: api.MustToConfig(&api.RemoteAgentToolConfig{
+			Name:        convertToPythonIdentifier(remoteAgent.Name),
+			Description: description,
+			RemoteAgent: types.NamespacedName{Namespace: remoteAgent.Namespace, Name: remoteAgent.Name}.String(),
+		}),
+	}, nil
+}
+
 func convertComponent(component v1alpha1.Component) (*api.Component, error) {
 	config, err := convertMapFromAnytype(component.Config)
 	if err != nil {
@@ -784,20 +910,21 @@ func convertMapFromAnytype(config map[string]v1alpha1.AnyType) (map[string]inter
 func translateTerminationCondition(terminationCondition v1alpha1.TerminationCondition) (*api.Component, error) {
 	// ensure only one termination condition is set
 	var conditionsSet int
-	if terminationCondition.MaxMessageTermination != nil {
-		conditionsSet++
-	}
-	if terminationCondition.TextMentionTermination != nil {
-		conditionsSet++
-	}
-	if terminationCondition.OrTermination != nil {
-		conditionsSet++
-	}
-	if terminationCondition.StopMessageTermination != nil {
-		conditionsSet++
-	}
-	if terminationCondition.TextMessageTermination != nil {
-		conditionsSet++
+	for _, set := range []bool{
+		terminationCondition.MaxMessageTermination != nil,
+		terminationCondition.TextMentionTermination != nil,
+		terminationCondition.TextMessageTermination != nil,
+		terminationCondition.StopMessageTermination != nil,
+		terminationCondition.TimeoutTermination != nil,
+		terminationCondition.TokenUsageTermination != nil,
+		terminationCondition.HandoffTermination != nil,
+		terminationCondition.SourceMatchTermination != nil,
+		terminationCondition.AndTermination != nil,
+		terminationCondition.OrTermination != nil,
+	} {
+		if set {
+			conditionsSet++
+		}
 	}
 	if conditionsSet != 1 {
 		return nil, fmt.Errorf("exactly one termination condition must be set")
@@ -834,22 +961,77 @@ func translateTerminationCondition(terminationCondition v1alpha1.TerminationCond
 				Source: terminationCondition.TextMessageTermination.Source,
 			}),
 		}, nil
+	case terminationCondition.TimeoutTermination != nil:
+		timeout := terminationCondition.TimeoutTermination.Timeout.Duration
+		if timeout <= 0 {
+			return nil, fmt.Errorf("timeout termination must have a positive timeout")
+		}
+		return &api.Component{
+			Provider:      "autogen_agentchat.conditions.TimeoutTermination",
+			ComponentType: "termination",
+			Version:       1,
+			Config: api.MustToConfig(&api.TimeoutTerminationConfig{
+				TimeoutSeconds: timeout.Seconds(),
+			}),
+		}, nil
+	case terminationCondition.TokenUsageTermination != nil:
+		tokenUsage := terminationCondition.TokenUsageTermination
+		if tokenUsage.MaxTotalTokens == nil && tokenUsage.MaxPromptTokens == nil && tokenUsage.MaxCompletionTokens == nil {
+			return nil, fmt.Errorf("token usage termination must have at least one token limit")
+		}
+		return &api.Component{
+			Provider:      "autogen_agentchat.conditions.TokenUsageTermination",
+			ComponentType: "termination",
+			Version:       1,
+			Config: api.MustToConfig(&api.TokenUsageTerminationConfig{
+				MaxTotalToken:      tokenUsage.MaxTotalTokens,
+				MaxPromptToken:     tokenUsage.MaxPromptTokens,
+				MaxCompletionToken: tokenUsage.MaxCompletionTokens,
+			}),
+		}, nil
+	case terminationCondition.HandoffTermination != nil:
+		return &api.Component{
+			Provider:      "autogen_agentchat.conditions.HandoffTermination",
+			ComponentType: "termination",
+			Version:       1,
+			Config: api.MustToConfig(&api.HandoffTerminationConfig{
+				Target: convertToPythonIdentifier(terminationCondition.HandoffTermination.Target),
+			}),
+		}, nil
+	case terminationCondition.SourceMatchTermination != nil:
+		// the sources are the names of agents, which are python identifiers in autogen
+		var sources []string
+		for _, source := range terminationCondition.SourceMatchTermination.Sources {
+			sources = append(sources, convertToPythonIdentifier(source))
+		}
+		return &api.Component{
+			Provider:      "autogen_agentchat.conditions.SourceMatchTermination",
+			ComponentType: "termination",
+			Version:       1,
+			Config: api.MustToConfig(&api.SourceMatchTerminationConfig{
+				Sources: sources,
+			}),
+		}, nil
+	case terminationCondition.AndTermination != nil:
+		conditions, err := translateTerminationConditions(terminationCondition.AndTermination.Conditions)
+		if err != nil {
+			return nil, err
+		}
+		return &api.Component{
+			Provider:      "autogen_agentchat.base.AndTerminationCondition",
+			ComponentType: "termination",
+			Version:       1,
+			Config: api.MustToConfig(&api.AndTerminationConfig{
+				Conditions: conditions,
+			}),
+		}, nil
 	case terminationCondition.OrTermination != nil:
-		var conditions []*api.Component
-		for _, c := range terminationCondition.OrTermination.Conditions {
-			subConditon := v1alpha1.TerminationCondition{
-				MaxMessageTermination:  c.MaxMessageTermination,
-				TextMentionTermination: c.TextMentionTermination,
-			}
-
-			condition, err := translateTerminationCondition(subConditon)
-			if err != nil {
-				return nil, err
-			}
-			conditions = append(conditions, condition)
+		conditions, err := translateTerminationConditions(terminationCondition.OrTermination.Conditions)
+		if err != nil {
+			return nil, err
 		}
 		return &api.Component{
-			Provider:      "autogen_agentchat.conditions.OrTerminationCondition",
+			Provider:      "autogen_agentchat.base.OrTerminationCondition",
 			ComponentType: "termination",
 			Version:       1,
 			//ComponentVersion: 1,
@@ -871,12 +1053,42 @@ func translateTerminationCondition(terminationCondition v1alpha1.TerminationCond
 	return nil, fmt.Errorf("unsupported termination condition")
 }
 
+// translateTerminationConditions translates the nested conditions of an and or or termination condition.
+func translateTerminationConditions(terminationConditions []v1alpha1.TerminationCondition) ([]*api.Component, error) {
+	if len(terminationConditions) == 0 {
+		return nil, fmt.Errorf("at least one nested termination condition must be set")
+	}
+	var conditions []*api.Component
+	for _, terminationCondition := range terminationConditions {
+		condition, err := translateTerminationCondition(terminationCondition)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// fetchObjKube fetches the referenced object. Objects which are being deleted are
+// reported as not found so that dependents stop using them before they are gone.
 func fetchObjKube(ctx context.Context, kube client.Client, obj client.Object, objName, objNamespace string) error {
 	ref := getRefFromString(objName, objNamespace)
 	err := kube.Get(ctx, ref, obj)
 	if err != nil {
 		return err
 	}
+	if obj.GetDeletionTimestamp() != nil {
+		gvk, err := apiutil.GVKForObject(obj, kube.Scheme())
+		if err != nil {
+			return err
+		}
+		// the error must stay a NotFound error, so the resource is guessed if it cannot be mapped
+		resource, _ := meta.UnsafeGuessKindToResource(gvk)
+		if mapping, err := kube.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+			resource = mapping.Resource
+		}
+		return k8s_errors.NewNotFound(resource.GroupResource(), ref.Name)
+	}
 	return nil
 }
 
@@ -931,12 +1143,87 @@ func addOpenaiApiKeyToConfig(
 	return nil
 }
 
+// createModelClient creates the model client component of a model config. Requests are distributed
+// across its API keys, and fall back to the model clients of its fallbacks in order if they fail.
+func (a *apiTranslator) createModelClient(ctx context.Context, modelConfig *v1alpha1.ModelConfig, stream bool) (*api.Component, error) {
+	modelClient, err := a.createLoadBalancedModelClient(ctx, modelConfig, stream)
+	if err != nil {
+		return nil, err
+	}
+	if len(modelConfig.Spec.Fallbacks) == 0 {
+		return modelClient, nil
+	}
+
+	modelClients := []*api.Component{modelClient}
+	for _, fallback := range modelConfig.Spec.Fallbacks {
+		fallbackConfig := &v1alpha1.ModelConfig{}
+		if err := fetchObjKube(ctx, a.kube, fallbackConfig, fallback, modelConfig.Namespace); err != nil {
+			return nil, fmt.Errorf("failed to fetch fallback model config %s: %w", fallback, err)
+		}
+		// the fallbacks of fallbacks are not followed, which rules out cycles
+		fallbackClient, err := a.createLoadBalancedModelClient(ctx, fallbackConfig, stream)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create model client for fallback model config %s: %w", fallback, err)
+		}
+		modelClients = append(modelClients, fallbackClient)
+	}
+	return failoverModelClient(modelClients, false), nil
+}
+
+// createLoadBalancedModelClient creates a model client component which distributes requests
+// round-robin across the API keys of the model config.
+func (a *apiTranslator) createLoadBalancedModelClient(ctx context.Context, modelConfig *v1alpha1.ModelConfig, stream bool) (*api.Component, error) {
+	if len(modelConfig.Spec.APIKeySecrets) == 0 {
+		return a.createModelClientForProvider(ctx, modelConfig, stream)
+	}
+
+	var modelClients []*api.Component
+	for _, apiKeyConfig := range modelConfigsPerAPIKey(modelConfig) {
+		modelClient, err := a.createModelClientForProvider(ctx, apiKeyConfig, stream)
+		if err != nil {
+			return nil, err
+		}
+		modelClients = append(modelClients, modelClient)
+	}
+	return failoverModelClient(modelClients, true), nil
+}
+
+// modelConfigsPerAPIKey returns a copy of the model config for each of its API keys,
+// starting with the key of apiKeySecretRef if it is set.
+func modelConfigsPerAPIKey(modelConfig *v1alpha1.ModelConfig) []*v1alpha1.ModelConfig {
+	var modelConfigs []*v1alpha1.ModelConfig
+	if modelConfig.Spec.APIKeySecretRef != "" {
+		modelConfigs = append(modelConfigs, modelConfig)
+	}
+	for _, apiKeySecret := range modelConfig.Spec.APIKeySecrets {
+		apiKeyConfig := modelConfig.DeepCopy()
+		apiKeyConfig.Spec.APIKeySecretRef = apiKeySecret.Name
+		apiKeyConfig.Spec.APIKeySecretKey = apiKeySecret.Key
+		apiKeyConfig.Spec.APIKeySecrets = nil
+		modelConfigs = append(modelConfigs, apiKeyConfig)
+	}
+	return modelConfigs
+}
+
+func failoverModelClient(modelClients []*api.Component, roundRobin bool) *api.Component {
+	config := &api.FailoverClientConfig{
+		ModelClients: modelClients,
+		RoundRobin:   roundRobin,
+	}
+	return &api.Component{
+		Provider:      "kagent.models.FailoverChatCompletionClient",
+		ComponentType: "model",
+		Version:       1,
+		Config:        api.MustToConfig(config),
+	}
+}
+
 // createModelClientForProvider creates a model client component based on the model provider
 func (a *apiTranslator) createModelClientForProvider(ctx context.Context, modelConfig *v1alpha1.ModelConfig, stream bool) (*api.Component, error) {
 
 	switch modelConfig.Spec.Provider {
 	case v1alpha1.Anthropic:
-		apiKey, err := a.getModelConfigApiKey(ctx, modelConfig)
+		apiKey, err := getModelConfigApiKey(ctx, a.kube, modelConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -989,7 +1276,7 @@ func (a *apiTranslator) createModelClientForProvider(ctx context.Context, modelC
 		}, nil
 
 	case v1alpha1.AzureOpenAI:
-		apiKey, err := a.getModelConfigApiKey(ctx, modelConfig)
+		apiKey, err := getModelConfigApiKey(ctx, a.kube, modelConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -1039,7 +1326,7 @@ func (a *apiTranslator) createModelClientForProvider(ctx context.Context, modelC
 		}, nil
 
 	case v1alpha1.OpenAI:
-		apiKey, err := a.getModelConfigApiKey(ctx, modelConfig)
+		apiKey, err := getModelConfigApiKey(ctx, a.kube, modelConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -1136,11 +1423,142 @@ func (a *apiTranslator) createModelClientForProvider(ctx context.Context, modelC
 			Config:        api.MustToConfig(config),
 		}, nil
 
+	case v1alpha1.Gemini:
+		apiKey, err := getModelConfigApiKey(ctx, a.kube, modelConfig)
+		if err != nil {
+			return nil, err
+		}
+		config := &api.GeminiClientConfig{
+			Model:     modelConfig.Spec.Model,
+			APIKey:    string(apiKey),
+			ModelInfo: translateModelInfo(modelConfig.Spec.ModelInfo),
+		}
+
+		if modelConfig.Spec.Gemini != nil {
+			geminiConfig := modelConfig.Spec.Gemini
+
+			config.BaseURL = geminiConfig.BaseURL
+			config.MaxOutputTokens = geminiConfig.MaxOutputTokens
+			config.TopK = geminiConfig.TopK
+			config.CandidateCount = geminiConfig.CandidateCount
+
+			if geminiConfig.Temperature != "" {
+				temp, err := strconv.ParseFloat(geminiConfig.Temperature, 64)
+				if err == nil {
+					config.Temperature = &temp
+				}
+			}
+
+			if geminiConfig.TopP != "" {
+				topP, err := strconv.ParseFloat(geminiConfig.TopP, 64)
+				if err == nil {
+					config.TopP = &topP
+				}
+			}
+
+			for _, setting := range geminiConfig.SafetySettings {
+				config.SafetySettings = append(config.SafetySettings, api.GeminiSafetySetting(setting))
+			}
+		}
+
+		config.DefaultHeaders = modelConfig.Spec.DefaultHeaders
+		return &api.Component{
+			Provider:      "kagent.models.GeminiChatCompletionClient",
+			ComponentType: "model",
+			Version:       1,
+			Config:        api.MustToConfig(config),
+		}, nil
+
+	case v1alpha1.OpenAICompatible:
+		if modelConfig.Spec.OpenAICompatible == nil {
+			return nil, fmt.Errorf("openAICompatible configuration of model config %s/%s is missing", modelConfig.Namespace, modelConfig.Name)
+		}
+		// autogen rejects models it does not know without model info, so the
+		// client is only created once the capabilities of the model are known
+		modelInfo := modelConfig.Spec.ModelInfo
+		if modelInfo == nil {
+			modelInfo = modelConfig.Status.ModelInfo
+		}
+		if modelInfo == nil {
+			return nil, fmt.Errorf("model info of model config %s/%s has not been detected yet", modelConfig.Namespace, modelConfig.Name)
+		}
+
+		apiKey, err := getModelConfigApiKey(ctx, a.kube, modelConfig)
+		if err != nil {
+			return nil, err
+		}
+		compatibleConfig := modelConfig.Spec.OpenAICompatible
+		config := &api.OpenAIClientConfig{
+			BaseOpenAIClientConfig: api.BaseOpenAIClientConfig{
+				Model:     modelConfig.Spec.Model,
+				APIKey:    string(apiKey),
+				ModelInfo: translateModelInfo(modelInfo),
+			},
+			BaseURL: &compatibleConfig.BaseURL,
+		}
+
+		if stream {
+			config.StreamOptions = &api.StreamOptions{
+				IncludeUsage: true,
+			}
+		}
+
+		if compatibleConfig.MaxTokens > 0 {
+			config.MaxTokens = compatibleConfig.MaxTokens
+		}
+		if compatibleConfig.Seed != nil {
+			config.Seed = *compatibleConfig.Seed
+		}
+		config.Temperature = parseFloatOrZero(compatibleConfig.Temperature)
+		config.TopP = parseFloatOrZero(compatibleConfig.TopP)
+		config.FrequencyPenalty = parseFloatOrZero(compatibleConfig.FrequencyPenalty)
+		config.PresencePenalty = parseFloatOrZero(compatibleConfig.PresencePenalty)
+
+		config.DefaultHeaders = modelConfig.Spec.DefaultHeaders
+		return &api.Component{
+			Provider:      "autogen_ext.models.openai.OpenAIChatCompletionClient",
+			ComponentType: "model",
+			Version:       1,
+			Config:        api.MustToConfig(config),
+		}, nil
+
+	case v1alpha1.Mock:
+		script, err := a.getMockScript(ctx, modelConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		config, err := translateMockScript(script)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate mock script of model config %s/%s: %w", modelConfig.Namespace, modelConfig.Name, err)
+		}
+		config.Model = modelConfig.Spec.Model
+		config.ModelInfo = translateModelInfo(modelConfig.Spec.ModelInfo)
+
+		return &api.Component{
+			Provider:      "kagent.models.MockChatCompletionClient",
+			ComponentType: "model",
+			Version:       1,
+			Config:        api.MustToConfig(config),
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported model provider: %s", modelConfig.Spec.Provider)
 	}
 }
 
+// parseFloatOrZero parses optional sampling parameters, which are validated by the webhook.
+func parseFloatOrZero(value string) float64 {
+	if value == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
 func translateModelInfo(modelInfo *v1alpha1.ModelInfo) *api.ModelInfo {
 	if modelInfo == nil {
 		return nil
@@ -1181,7 +1599,7 @@ func (a *apiTranslator) getMemoryApiKey(ctx context.Context, memory *v1alpha1.Me
 	return memoryApiKey, nil
 }
 
-func (a *apiTranslator) getModelConfigApiKey(ctx context.Context, modelConfig *v1alpha1.ModelConfig) ([]byte, error) {
+func getModelConfigApiKey(ctx context.Context, kube client.Client, modelConfig *v1alpha1.ModelConfig) ([]byte, error) {
 	// Only retrieve the secret if APIKeySecretRef is provided
 	if modelConfig.Spec.APIKeySecretRef == "" {
 		return []byte(""), nil
@@ -1190,7 +1608,7 @@ func (a *apiTranslator) getModelConfigApiKey(ctx context.Context, modelConfig *v
 	modelApiKeySecret := &v1.Secret{}
 	err := fetchObjKube(
 		ctx,
-		a.kube,
+		kube,
 		modelApiKeySecret,
 		modelConfig.Spec.APIKeySecretRef,
 		modelConfig.Namespace,
@@ -1211,21 +1629,5 @@ func (a *apiTranslator) getModelConfigApiKey(ctx context.Context, modelConfig *v
 }
 
 func getRefFromString(ref string, parentNamespace string) types.NamespacedName {
-	parts := strings.Split(ref, "/")
-	var (
-		namespace string
-		name      string
-	)
-	if len(parts) == 2 {
-		namespace = parts[0]
-		name = parts[1]
-	} else {
-		namespace = parentNamespace
-		name = ref
-	}
-
-	return types.NamespacedName{
-		Namespace: namespace,
-		Name:      name,
-	}
+	return common.ParseRefString(ref, parentNamespace)
 }
@@ -2,7 +2,6 @@ package autogen
 
 import (
 	_ "embed"
-	"fmt"
 
 	"github.com/kagent-dev/kagent/go/autogen/api"
 )
@@ -12,6 +11,9 @@ import (
 //go:embed planning-agent-system-prompt.txt
 var planningAgentSystemPrompt string
 
+// planningAgentName is the name of the builtin planning agent, which participants of swarm teams can hand off to.
+const planningAgentName = "planning_agent"
+
 const planningAgentDescription = "The Planning Agent is responsible for planning and scheduling tasks. The planning agent is also responsible for deciding when the user task has been accomplished and terminating the conversation."
 
 func MakeBuiltinPlanningAgent(
@@ -23,13 +25,7 @@ func MakeBuiltinPlanningAgent(
 	for _, participant := range teamParticipants {
 		assistantAgent := &api.AssistantAgentConfig{}
 		api.MustFromConfig(assistantAgent, participant.Config)
-		targetName := assistantAgent.Name
-		handoffs = append(handoffs, api.Handoff{
-			Target:      targetName,
-			Description: fmt.Sprintf("Handoff to %s. %s", targetName, assistantAgent.Description),
-			Name:        fmt.Sprintf("transfer_to_%s", targetName),
-			Message:     fmt.Sprintf("Transferred to %s, adopting the role of %s immediately.", targetName, targetName),
-		})
+		handoffs = append(handoffs, makeHandoff(assistantAgent.Name, assistantAgent.Description, ""))
 	}
 	return &api.Component{
 		Provider:      "autogen_agentchat.agents.AssistantAgent",
@@ -0,0 +1,57 @@
+package autogen
+
+import (
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+// translateHandoffs translates the handoffs of a participant of a swarm team. The targets must be
+// other participants of the team, or the builtin planning agent if the team has one.
+func translateHandoffs(agent *v1alpha1.Agent, participants []*v1alpha1.Agent, withPlanningAgent bool) ([]api.Handoff, error) {
+	var handoffs []api.Handoff
+	for _, handoff := range agent.Spec.Handoffs {
+		if handoff.Target == agent.Name {
+			return nil, fmt.Errorf("agent %s cannot hand off to itself", agent.Name)
+		}
+
+		targetDescription := planningAgentDescription
+		if handoff.Target != planningAgentName || !withPlanningAgent {
+			target := findParticipant(participants, handoff.Target)
+			if target == nil {
+				return nil, fmt.Errorf("handoff target %s of agent %s is not a participant of the team", handoff.Target, agent.Name)
+			}
+			targetDescription = target.Spec.Description
+		}
+
+		description := handoff.Description
+		if description == "" {
+			description = targetDescription
+		}
+		handoffs = append(handoffs, makeHandoff(convertToPythonIdentifier(handoff.Target), description, handoff.Message))
+	}
+	return handoffs, nil
+}
+
+func findParticipant(participants []*v1alpha1.Agent, name string) *v1alpha1.Agent {
+	for _, participant := range participants {
+		if participant.Name == name {
+			return participant
+		}
+	}
+	return nil
+}
+
+// makeHandoff creates a handoff to the target agent, which is named by its python identifier.
+func makeHandoff(target, description, message string) api.Handoff {
+	if message == "" {
+		message = fmt.Sprintf("Transferred to %s, adopting the role of %s immediately.", target, target)
+	}
+	return api.Handoff{
+		Target:      target,
+		Description: fmt.Sprintf("Handoff to %s. %s", target, description),
+		Name:        fmt.Sprintf("transfer_to_%s", target),
+		Message:     message,
+	}
+}
@@ -0,0 +1,107 @@
+package autogen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSwarmHandoffs(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, v1alpha1.AddToScheme(scheme.Scheme))
+	modelConfig := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "ollama", Namespace: "kagent"},
+		Spec: v1alpha1.ModelConfigSpec{
+			Model:    "llama3.2",
+			Provider: v1alpha1.Ollama,
+			Ollama:   &v1alpha1.OllamaConfig{Host: "http://ollama:11434"},
+		},
+	}
+	newAgent := func(name string, handoffs ...v1alpha1.Handoff) *v1alpha1.Agent {
+		return &v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kagent"},
+			Spec:       v1alpha1.AgentSpec{Description: "The " + name + " agent", SystemMessage: "You are " + name, Handoffs: handoffs},
+		}
+	}
+	triage := newAgent("triage", v1alpha1.Handoff{Target: "k8s-agent"})
+	k8s := newAgent("k8s-agent",
+		v1alpha1.Handoff{Target: "network", Description: "Escalate network issues.", Message: "Escalated to network."},
+		v1alpha1.Handoff{Target: "triage"},
+	)
+	network := newAgent("network", v1alpha1.Handoff{Target: "k8s-agent"})
+	team := &v1alpha1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "escalation", Namespace: "kagent"},
+		Spec: v1alpha1.TeamSpec{
+			Participants:    []string{"triage", "k8s-agent", "network"},
+			ModelConfig:     "ollama",
+			SwarmTeamConfig: &v1alpha1.SwarmTeamConfig{DisablePlanningAgent: true},
+			TerminationCondition: v1alpha1.TerminationCondition{
+				MaxMessageTermination: &v1alpha1.MaxMessageTermination{MaxMessages: 10},
+			},
+		},
+	}
+	translator := &apiTranslator{kube: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(modelConfig, triage, k8s, network).Build()}
+
+	participantHandoffs := func() map[string][]api.Handoff {
+		autogenTeam, err := translator.TranslateGroupChatForTeam(ctx, team)
+		require.NoError(t, err)
+		config := &api.SwarmTeamConfig{}
+		require.NoError(t, config.FromConfig(autogenTeam.Component.Config))
+		handoffs := map[string][]api.Handoff{}
+		for _, participant := range config.Participants {
+			agentConfig := &api.AssistantAgentConfig{}
+			require.NoError(t, agentConfig.FromConfig(participant.Config))
+			handoffs[agentConfig.Name] = agentConfig.Handoffs
+		}
+		return handoffs
+	}
+
+	handoffs := participantHandoffs()
+	require.Len(t, handoffs, 3)
+	assert.Equal(t, []api.Handoff{{
+		Target:      "k8s_agent",
+		Description: "Handoff to k8s_agent. The k8s-agent agent",
+		Name:        "transfer_to_k8s_agent",
+		Message:     "Transferred to k8s_agent, adopting the role of k8s_agent immediately.",
+	}}, handoffs["triage"])
+	assert.Equal(t, api.Handoff{
+		Target:      "network",
+		Description: "Handoff to network. Escalate network issues.",
+		Name:        "transfer_to_network",
+		Message:     "Escalated to network.",
+	}, handoffs["k8s_agent"][0])
+
+	// the planning agent hands off to every participant, and the participants may hand off back to it
+	team.Spec.SwarmTeamConfig.DisablePlanningAgent = false
+	network.Spec.Handoffs = append(network.Spec.Handoffs, v1alpha1.Handoff{Target: planningAgentName})
+	require.NoError(t, translator.kube.Update(ctx, network))
+	handoffs = participantHandoffs()
+	require.Len(t, handoffs, 4)
+	assert.Len(t, handoffs[planningAgentName], 3)
+	assert.Equal(t, "transfer_to_planning_agent", handoffs["network"][1].Name)
+
+	// handoffs must target participants of the team
+	team.Spec.Participants = []string{"triage", "network"}
+	_, err := translator.TranslateGroupChatForTeam(ctx, team)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "handoff target k8s-agent of agent triage is not a participant of the team")
+
+	// without the planning agent, the swarm starts with its first participant which must hand off
+	team.Spec.Participants = []string{"triage", "k8s-agent", "network"}
+	team.Spec.SwarmTeamConfig.DisablePlanningAgent = true
+	network.Spec.Handoffs = network.Spec.Handoffs[:1]
+	require.NoError(t, translator.kube.Update(ctx, network))
+	triage.Spec.Handoffs = nil
+	require.NoError(t, translator.kube.Update(ctx, triage))
+	_, err = translator.TranslateGroupChatForTeam(ctx, team)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must have handoffs when the planning agent is disabled")
+}
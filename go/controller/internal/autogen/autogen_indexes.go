@@ -0,0 +1,162 @@
+package autogen
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Field indexes used to look up the dependents of a referenced object.
+// Every index value is the fully qualified "<namespace>/<name>" of the reference.
+const (
+	AgentModelConfigIndex = "agent.spec.modelConfig"
+	AgentMemoryIndex      = "agent.spec.memory"
+	AgentToolServerIndex  = "agent.spec.tools.mcpServer.toolServer"
+	AgentAgentToolIndex   = "agent.spec.tools.agent.ref"
+	AgentRemoteAgentIndex = "agent.spec.tools.remoteAgent.ref"
+
+	TeamModelConfigIndex = "team.spec.modelConfig"
+	TeamParticipantIndex = "team.spec.participants"
+
+	ModelConfigApiKeySecretIndex = "modelconfig.spec.apiKeySecretRef"
+	ModelConfigMockScriptIndex   = "modelconfig.spec.mock.scriptConfigMapRef"
+	ModelConfigFallbackIndex     = "modelconfig.spec.fallbacks"
+)
+
+type fieldIndex struct {
+	obj     client.Object
+	field   string
+	indexFn client.IndexerFunc
+}
+
+var fieldIndexes = []fieldIndex{
+	{
+		obj:   &v1alpha1.Agent{},
+		field: AgentModelConfigIndex,
+		indexFn: func(obj client.Object) []string {
+			agent := obj.(*v1alpha1.Agent)
+			return refIndexValues(agent.Namespace, agent.Spec.ModelConfig)
+		},
+	},
+	{
+		obj:   &v1alpha1.Agent{},
+		field: AgentMemoryIndex,
+		indexFn: func(obj client.Object) []string {
+			agent := obj.(*v1alpha1.Agent)
+			return refIndexValues(agent.Namespace, agent.Spec.Memory...)
+		},
+	},
+	{
+		obj:   &v1alpha1.Agent{},
+		field: AgentToolServerIndex,
+		indexFn: func(obj client.Object) []string {
+			agent := obj.(*v1alpha1.Agent)
+			var refs []string
+			for _, tool := range agent.Spec.Tools {
+				if tool.McpServer != nil {
+					refs = append(refs, tool.McpServer.ToolServer)
+				}
+			}
+			return refIndexValues(agent.Namespace, refs...)
+		},
+	},
+	{
+		obj:   &v1alpha1.Agent{},
+		field: AgentAgentToolIndex,
+		indexFn: func(obj client.Object) []string {
+			agent := obj.(*v1alpha1.Agent)
+			var refs []string
+			for _, tool := range agent.Spec.Tools {
+				if tool.Agent != nil {
+					refs = append(refs, tool.Agent.Ref)
+				}
+			}
+			return refIndexValues(agent.Namespace, refs...)
+		},
+	},
+	{
+		obj:   &v1alpha1.Agent{},
+		field: AgentRemoteAgentIndex,
+		indexFn: func(obj client.Object) []string {
+			agent := obj.(*v1alpha1.Agent)
+			var refs []string
+			for _, tool := range agent.Spec.Tools {
+				if tool.RemoteAgent != nil {
+					refs = append(refs, tool.RemoteAgent.Ref)
+				}
+			}
+			return refIndexValues(agent.Namespace, refs...)
+		},
+	},
+	{
+		obj:   &v1alpha1.Team{},
+		field: TeamModelConfigIndex,
+		indexFn: func(obj client.Object) []string {
+			team := obj.(*v1alpha1.Team)
+			return refIndexValues(team.Namespace, team.Spec.ModelConfig)
+		},
+	},
+	{
+		obj:   &v1alpha1.Team{},
+		field: TeamParticipantIndex,
+		indexFn: func(obj client.Object) []string {
+			team := obj.(*v1alpha1.Team)
+			return refIndexValues(team.Namespace, team.Spec.Participants...)
+		},
+	},
+	{
+		obj:   &v1alpha1.ModelConfig{},
+		field: ModelConfigApiKeySecretIndex,
+		indexFn: func(obj client.Object) []string {
+			modelConfig := obj.(*v1alpha1.ModelConfig)
+			refs := []string{modelConfig.Spec.APIKeySecretRef}
+			for _, apiKeySecret := range modelConfig.Spec.APIKeySecrets {
+				refs = append(refs, apiKeySecret.Name)
+			}
+			return refIndexValues(modelConfig.Namespace, refs...)
+		},
+	},
+	{
+		obj:   &v1alpha1.ModelConfig{},
+		field: ModelConfigMockScriptIndex,
+		indexFn: func(obj client.Object) []string {
+			modelConfig := obj.(*v1alpha1.ModelConfig)
+			if modelConfig.Spec.Mock == nil {
+				return nil
+			}
+			return refIndexValues(modelConfig.Namespace, modelConfig.Spec.Mock.ScriptConfigMapRef)
+		},
+	},
+	{
+		obj:   &v1alpha1.ModelConfig{},
+		field: ModelConfigFallbackIndex,
+		indexFn: func(obj client.Object) []string {
+			modelConfig := obj.(*v1alpha1.ModelConfig)
+			return refIndexValues(modelConfig.Namespace, modelConfig.Spec.Fallbacks...)
+		},
+	},
+}
+
+// SetupIndexes registers the reference indexes used by the autogen reconciler
+// with the given field indexer, usually the manager's.
+func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	for _, idx := range fieldIndexes {
+		if err := indexer.IndexField(ctx, idx.obj, idx.field, idx.indexFn); err != nil {
+			return fmt.Errorf("failed to index field %s: %w", idx.field, err)
+		}
+	}
+	return nil
+}
+
+func refIndexValues(namespace string, refs ...string) []string {
+	var values []string
+	for _, ref := range refs {
+		if ref == "" {
+			continue
+		}
+		values = append(values, getRefFromString(ref, namespace).String())
+	}
+	return values
+}
@@ -0,0 +1,128 @@
+package autogen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const defaultMockScriptKey = "script.yaml"
+
+// mockScript is the script of a Mock model provider, as documented on v1alpha1.MockConfig.
+type mockScript struct {
+	Rules   []mockScriptRule  `json:"rules"`
+	Default *mockScriptResult `json:"default,omitempty"`
+}
+
+type mockScriptRule struct {
+	Match string `json:"match"`
+	mockScriptResult
+}
+
+type mockScriptResult struct {
+	Content   string               `json:"content,omitempty"`
+	ToolCalls []mockScriptToolCall `json:"toolCalls,omitempty"`
+}
+
+type mockScriptToolCall struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// getMockScript fetches the script of a Mock model config from its ConfigMap.
+func (a *apiTranslator) getMockScript(ctx context.Context, modelConfig *v1alpha1.ModelConfig) (string, error) {
+	if modelConfig.Spec.Mock == nil {
+		return "", fmt.Errorf("mock configuration of model config %s/%s is missing", modelConfig.Namespace, modelConfig.Name)
+	}
+	key := modelConfig.Spec.Mock.ScriptKey
+	if key == "" {
+		key = defaultMockScriptKey
+	}
+
+	configMap := &v1.ConfigMap{}
+	err := fetchObjKube(
+		ctx,
+		a.kube,
+		configMap,
+		modelConfig.Spec.Mock.ScriptConfigMapRef,
+		modelConfig.Namespace,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch mock script ConfigMap %s: %w", modelConfig.Spec.Mock.ScriptConfigMapRef, err)
+	}
+
+	script, ok := configMap.Data[key]
+	if !ok {
+		return "", fmt.Errorf("mock script not found in ConfigMap %s/%s with key %s", configMap.Namespace, configMap.Name, key)
+	}
+	return script, nil
+}
+
+// translateMockScript parses the script of a Mock model provider into the configuration of the mock client.
+func translateMockScript(script string) (*api.MockChatCompletionClientConfig, error) {
+	var parsed mockScript
+	if err := yaml.UnmarshalStrict([]byte(script), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid mock script: %w", err)
+	}
+
+	config := &api.MockChatCompletionClientConfig{
+		Rules: make([]api.MockRule, 0, len(parsed.Rules)),
+	}
+	for i, rule := range parsed.Rules {
+		if _, err := regexp.Compile(rule.Match); err != nil {
+			return nil, fmt.Errorf("invalid match of mock script rule %d: %w", i, err)
+		}
+		response, err := translateMockResult(&rule.mockScriptResult)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mock script rule %d: %w", i, err)
+		}
+		config.Rules = append(config.Rules, api.MockRule{
+			Match:    rule.Match,
+			Response: *response,
+		})
+	}
+
+	if parsed.Default != nil {
+		response, err := translateMockResult(parsed.Default)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default response of mock script: %w", err)
+		}
+		config.DefaultResponse = response
+	}
+
+	return config, nil
+}
+
+func translateMockResult(result *mockScriptResult) (*api.MockResponse, error) {
+	if result.Content == "" && len(result.ToolCalls) == 0 {
+		return nil, fmt.Errorf("either content or toolCalls must be specified")
+	}
+
+	response := &api.MockResponse{
+		Content: result.Content,
+	}
+	for _, toolCall := range result.ToolCalls {
+		if toolCall.Name == "" {
+			return nil, fmt.Errorf("tool call name must be specified")
+		}
+		arguments := toolCall.Arguments
+		if arguments == nil {
+			arguments = map[string]interface{}{}
+		}
+		b, err := json.Marshal(arguments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal arguments of tool call %s: %w", toolCall.Name, err)
+		}
+		response.ToolCalls = append(response.ToolCalls, api.MockToolCall{
+			Name:      toolCall.Name,
+			Arguments: string(b),
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,121 @@
+package autogen_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const mockScript = `
+rules:
+- match: "list (the )?pods"
+  toolCalls:
+  - name: k8s_get_resources
+    arguments:
+      resource_type: pod
+- match: "pod-"
+  content: "found pod-a"
+default:
+  content: "I don't know"
+`
+
+func TestMockModelProvider(t *testing.T) {
+	ctx := context.Background()
+	scheme := scheme.Scheme
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	namespace := "test-namespace"
+	modelConfig := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mock-model", Namespace: namespace},
+		Spec: v1alpha1.ModelConfigSpec{
+			Model:    "mock",
+			Provider: v1alpha1.Mock,
+			Mock:     &v1alpha1.MockConfig{ScriptConfigMapRef: "mock-script"},
+		},
+	}
+	agent := &v1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: namespace},
+		Spec: v1alpha1.AgentSpec{
+			Description:   "k8s agent",
+			SystemMessage: "You are a k8s agent",
+			ModelConfig:   modelConfig.Name,
+		},
+	}
+	script := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "mock-script", Namespace: namespace},
+		Data:       map[string]string{"script.yaml": mockScript},
+	}
+
+	translateModelClient := func(t *testing.T, objs ...*v1.ConfigMap) (*api.MockChatCompletionClientConfig, error) {
+		builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(modelConfig, agent)
+		for _, obj := range objs {
+			builder = builder.WithObjects(obj)
+		}
+		translator := autogen.NewAutogenApiTranslator(builder.Build(), types.NamespacedName{Namespace: namespace, Name: modelConfig.Name})
+		team, err := translator.TranslateGroupChatForAgent(ctx, agent)
+		if err != nil {
+			return nil, err
+		}
+
+		participants := team.Component.Config["participants"].([]interface{})
+		assistant := participants[0].(map[string]interface{})["config"].(map[string]interface{})
+		modelClient := assistant["model_client"].(map[string]interface{})
+		require.Equal(t, "kagent.models.MockChatCompletionClient", modelClient["provider"])
+
+		config := &api.MockChatCompletionClientConfig{}
+		require.NoError(t, config.FromConfig(modelClient["config"].(map[string]interface{})))
+		return config, nil
+	}
+
+	t.Run("should translate the script", func(t *testing.T) {
+		config, err := translateModelClient(t, script)
+		require.NoError(t, err)
+		assert.Equal(t, "mock", config.Model)
+		assert.Equal(t, []api.MockRule{
+			{
+				Match: "list (the )?pods",
+				Response: api.MockResponse{
+					ToolCalls: []api.MockToolCall{{Name: "k8s_get_resources", Arguments: `{"resource_type":"pod"}`}},
+				},
+			},
+			{
+				Match:    "pod-",
+				Response: api.MockResponse{Content: "found pod-a"},
+			},
+		}, config.Rules)
+		assert.Equal(t, &api.MockResponse{Content: "I don't know"}, config.DefaultResponse)
+	})
+
+	t.Run("should fail if the script does not exist", func(t *testing.T) {
+		_, err := translateModelClient(t)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to fetch mock script ConfigMap mock-script")
+	})
+
+	t.Run("should fail if a rule has an invalid regular expression", func(t *testing.T) {
+		invalid := script.DeepCopy()
+		invalid.Data["script.yaml"] = "rules:\n- match: \"(pods\"\n  content: pods\n"
+		_, err := translateModelClient(t, invalid)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid match of mock script rule 0")
+	})
+
+	t.Run("should fail if a rule has no response", func(t *testing.T) {
+		invalid := script.DeepCopy()
+		invalid.Data["script.yaml"] = "rules:\n- match: pods\n"
+		_, err := translateModelClient(t, invalid)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "either content or toolCalls must be specified")
+	})
+}
@@ -0,0 +1,125 @@
+package autogen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGeminiModelClient(t *testing.T) {
+	ctx := context.Background()
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kagent-gemini", Namespace: "kagent"},
+		Data:       map[string][]byte{"GEMINI_API_KEY": []byte("secret")},
+	}
+	modelConfig := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "gemini", Namespace: "kagent"},
+		Spec: v1alpha1.ModelConfigSpec{
+			Model:           "gemini-2.0-flash",
+			Provider:        v1alpha1.Gemini,
+			APIKeySecretRef: "kagent-gemini",
+			APIKeySecretKey: "GEMINI_API_KEY",
+			Gemini: &v1alpha1.GeminiConfig{
+				Temperature:    "0",
+				TopP:           "0.9",
+				TopK:           40,
+				CandidateCount: 2,
+				SafetySettings: []v1alpha1.GeminiSafetySetting{
+					{Category: "HARM_CATEGORY_HARASSMENT", Threshold: "BLOCK_ONLY_HIGH"},
+				},
+			},
+		},
+	}
+	translator := &apiTranslator{kube: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secret).Build()}
+
+	modelClient, err := translator.createModelClientForProvider(ctx, modelConfig, false)
+	require.NoError(t, err)
+	assert.Equal(t, "kagent.models.GeminiChatCompletionClient", modelClient.Provider)
+
+	config := &api.GeminiClientConfig{}
+	require.NoError(t, config.FromConfig(modelClient.Config))
+	temperature, topP := 0.0, 0.9
+	assert.Equal(t, &api.GeminiClientConfig{
+		Model:          "gemini-2.0-flash",
+		APIKey:         "secret",
+		Temperature:    &temperature,
+		TopP:           &topP,
+		TopK:           40,
+		CandidateCount: 2,
+		SafetySettings: []api.GeminiSafetySetting{
+			{Category: "HARM_CATEGORY_HARASSMENT", Threshold: "BLOCK_ONLY_HIGH"},
+		},
+	}, config)
+}
+
+func TestTeamParticipantModelClients(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, v1alpha1.AddToScheme(scheme.Scheme))
+	newModelConfig := func(name, model string) *v1alpha1.ModelConfig {
+		return &v1alpha1.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kagent"},
+			Spec: v1alpha1.ModelConfigSpec{
+				Model:    model,
+				Provider: v1alpha1.Ollama,
+				Ollama:   &v1alpha1.OllamaConfig{Host: "http://ollama:11434"},
+			},
+		}
+	}
+	newAgent := func(name, modelConfig string) *v1alpha1.Agent {
+		return &v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kagent"},
+			Spec:       v1alpha1.AgentSpec{Description: name, SystemMessage: "You are " + name, ModelConfig: modelConfig},
+		}
+	}
+	team := &v1alpha1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "kagent"},
+		Spec: v1alpha1.TeamSpec{
+			Participants:       []string{"worker", "inheritor"},
+			ModelConfig:        "strong",
+			SelectorTeamConfig: &v1alpha1.SelectorTeamConfig{},
+			TerminationCondition: v1alpha1.TerminationCondition{
+				MaxMessageTermination: &v1alpha1.MaxMessageTermination{MaxMessages: 5},
+			},
+		},
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		newModelConfig("strong", "llama3.3:70b"),
+		newModelConfig("cheap", "llama3.2"),
+		newAgent("worker", "cheap"),
+		newAgent("inheritor", ""),
+	).Build()
+	translator := &apiTranslator{kube: kubeClient}
+
+	translateModels := func() (string, []string) {
+		autogenTeam, err := translator.TranslateGroupChatForTeam(ctx, team)
+		require.NoError(t, err)
+		config := &api.SelectorGroupChatConfig{}
+		require.NoError(t, config.FromConfig(autogenTeam.Component.Config))
+		var participantModels []string
+		for _, participant := range config.Participants {
+			agentConfig := &api.AssistantAgentConfig{}
+			require.NoError(t, agentConfig.FromConfig(participant.Config))
+			participantModels = append(participantModels, agentConfig.ModelClient.Config["model"].(string))
+		}
+		require.NotNil(t, config.ModelClient)
+		return config.ModelClient.Config["model"].(string), participantModels
+	}
+
+	selectorModel, participantModels := translateModels()
+	assert.Equal(t, "llama3.3:70b", selectorModel)
+	assert.Equal(t, []string{"llama3.2", "llama3.3:70b"}, participantModels)
+
+	team.Spec.ModelConfigOverride = v1alpha1.ModelConfigOverrideAll
+	selectorModel, participantModels = translateModels()
+	assert.Equal(t, "llama3.3:70b", selectorModel)
+	assert.Equal(t, []string{"llama3.3:70b", "llama3.3:70b"}, participantModels)
+}
@@ -0,0 +1,153 @@
+package autogen
+
+import (
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+// knownContextWindows are the context windows of well-known models by exact model name, as listed
+// in autogen's model table including its aliases. Other models, e.g. fine-tuned models or newer
+// versions, are not validated unless their model info declares the context window.
+var knownContextWindows = map[string]int{
+	"gpt-4.1":                    1047576,
+	"gpt-4.1-2025-04-14":         1047576,
+	"gpt-4.1-mini":               1047576,
+	"gpt-4.1-mini-2025-04-14":    1047576,
+	"gpt-4.1-nano":               1047576,
+	"gpt-4.1-nano-2025-04-14":    1047576,
+	"gpt-4o":                     128000,
+	"gpt-4o-2024-05-13":          128000,
+	"gpt-4o-2024-08-06":          128000,
+	"gpt-4o-2024-11-20":          128000,
+	"gpt-4o-mini":                128000,
+	"gpt-4o-mini-2024-07-18":     128000,
+	"gpt-4-turbo":                128000,
+	"gpt-4-turbo-2024-04-09":     128000,
+	"gpt-4-turbo-preview":        128000,
+	"gpt-4-0125-preview":         128000,
+	"gpt-4-1106-preview":         128000,
+	"gpt-4":                      8192,
+	"gpt-4-0613":                 8192,
+	"gpt-4-32k":                  32768,
+	"gpt-4-32k-0613":             32768,
+	"gpt-3.5-turbo":              16385,
+	"gpt-3.5-turbo-0125":         16385,
+	"gpt-3.5-turbo-1106":         16385,
+	"o1":                         200000,
+	"o1-2024-12-17":              200000,
+	"o1-preview":                 128000,
+	"o1-preview-2024-09-12":      128000,
+	"o1-mini":                    128000,
+	"o1-mini-2024-09-12":         128000,
+	"o3":                         200000,
+	"o3-2025-04-16":              200000,
+	"o3-mini":                    200000,
+	"o3-mini-2025-01-31":         200000,
+	"o4-mini":                    200000,
+	"o4-mini-2025-04-16":         200000,
+	"claude-3-haiku-20240307":    200000,
+	"claude-3-sonnet-20240229":   200000,
+	"claude-3-opus-20240229":     200000,
+	"claude-3-opus-latest":       200000,
+	"claude-3-5-haiku-20241022":  200000,
+	"claude-3-5-haiku-latest":    200000,
+	"claude-3-5-sonnet-20240620": 200000,
+	"claude-3-5-sonnet-20241022": 200000,
+	"claude-3-5-sonnet-latest":   200000,
+	"claude-3-7-sonnet-20250219": 200000,
+	"claude-3-7-sonnet-latest":   200000,
+	"gemini-1.5-flash":           1048576,
+	"gemini-1.5-flash-8b":        1048576,
+	"gemini-1.5-pro":             2097152,
+	"gemini-2.0-flash":           1048576,
+	"gemini-2.0-flash-lite":      1048576,
+}
+
+// modelContextWindow returns the context window of the model of the model config, or 0 if it is unknown.
+func modelContextWindow(modelConfig *v1alpha1.ModelConfig) int {
+	if modelConfig.Spec.ModelInfo != nil && modelConfig.Spec.ModelInfo.ContextWindow > 0 {
+		return modelConfig.Spec.ModelInfo.ContextWindow
+	}
+	return knownContextWindows[modelConfig.Spec.Model]
+}
+
+// translateModelContext translates the model context of an agent. Token-limited contexts count
+// tokens with the model client of the agent, and their token limit must fit in its context window.
+func translateModelContext(
+	modelContext *v1alpha1.ModelContext,
+	modelConfig *v1alpha1.ModelConfig,
+	modelClient *api.Component,
+) (*api.Component, error) {
+	if modelContext == nil {
+		modelContext = &v1alpha1.ModelContext{Type: v1alpha1.ModelContextType_Unbounded}
+	}
+
+	switch modelContext.Type {
+	case v1alpha1.ModelContextType_Unbounded, "":
+		return &api.Component{
+			Provider:      "autogen_core.model_context.UnboundedChatCompletionContext",
+			ComponentType: "chat_completion_context",
+			Version:       1,
+			Description:   "An unbounded chat completion context that keeps a view of the all the messages.",
+			Label:         "UnboundedChatCompletionContext",
+			Config:        api.MustToConfig(&api.ChatCompletionContextConfig{}),
+		}, nil
+
+	case v1alpha1.ModelContextType_Buffered:
+		if modelContext.Buffered == nil {
+			return nil, fmt.Errorf("buffered model context must be specified for the Buffered type")
+		}
+		return &api.Component{
+			Provider:      "autogen_core.model_context.BufferedChatCompletionContext",
+			ComponentType: "chat_completion_context",
+			Version:       1,
+			Description:   "A buffered chat completion context that keeps a view of the last n messages.",
+			Label:         "BufferedChatCompletionContext",
+			Config: api.MustToConfig(&api.BufferedChatCompletionContextConfig{
+				BufferSize: modelContext.Buffered.BufferSize,
+			}),
+		}, nil
+
+	case v1alpha1.ModelContextType_HeadAndTail:
+		if modelContext.HeadAndTail == nil {
+			return nil, fmt.Errorf("headAndTail model context must be specified for the HeadAndTail type")
+		}
+		return &api.Component{
+			Provider:      "autogen_core.model_context.HeadAndTailChatCompletionContext",
+			ComponentType: "chat_completion_context",
+			Version:       1,
+			Description:   "A chat completion context that keeps a view of the first n and last m messages.",
+			Label:         "HeadAndTailChatCompletionContext",
+			Config: api.MustToConfig(&api.HeadAndTailChatCompletionContextConfig{
+				HeadSize: modelContext.HeadAndTail.HeadSize,
+				TailSize: modelContext.HeadAndTail.TailSize,
+			}),
+		}, nil
+
+	case v1alpha1.ModelContextType_TokenLimited:
+		var tokenLimit *int
+		if modelContext.TokenLimited != nil && modelContext.TokenLimited.TokenLimit != nil {
+			tokenLimit = modelContext.TokenLimited.TokenLimit
+			if contextWindow := modelContextWindow(modelConfig); contextWindow > 0 && *tokenLimit > contextWindow {
+				return nil, fmt.Errorf("token limit %d of the model context exceeds the context window of %d tokens of model %s",
+					*tokenLimit, contextWindow, modelConfig.Spec.Model)
+			}
+		}
+		return &api.Component{
+			Provider:      "autogen_core.model_context.TokenLimitedChatCompletionContext",
+			ComponentType: "chat_completion_context",
+			Version:       1,
+			Description:   "A token limited chat completion context that keeps a view of the last messages which fit in the token limit.",
+			Label:         "TokenLimitedChatCompletionContext",
+			Config: api.MustToConfig(&api.TokenLimitedChatCompletionContextConfig{
+				ModelClient: modelClient,
+				TokenLimit:  tokenLimit,
+			}),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported model context type %s", modelContext.Type)
+	}
+}
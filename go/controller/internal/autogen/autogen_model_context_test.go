@@ -0,0 +1,83 @@
+package autogen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+func TestTranslateModelContext(t *testing.T) {
+	modelConfig := &v1alpha1.ModelConfig{Spec: v1alpha1.ModelConfigSpec{Model: "gpt-4o", Provider: v1alpha1.OpenAI}}
+	modelClient := &api.Component{Provider: "autogen_ext.models.openai.OpenAIChatCompletionClient", ComponentType: "model"}
+	tokenLimit := func(limit int) *v1alpha1.ModelContext {
+		return &v1alpha1.ModelContext{
+			Type:         v1alpha1.ModelContextType_TokenLimited,
+			TokenLimited: &v1alpha1.TokenLimitedModelContext{TokenLimit: &limit},
+		}
+	}
+
+	t.Run("should default to an unbounded context", func(t *testing.T) {
+		modelContext, err := translateModelContext(nil, modelConfig, modelClient)
+		require.NoError(t, err)
+		assert.Equal(t, "autogen_core.model_context.UnboundedChatCompletionContext", modelContext.Provider)
+	})
+
+	t.Run("should translate buffered and head and tail contexts", func(t *testing.T) {
+		modelContext, err := translateModelContext(&v1alpha1.ModelContext{
+			Type:     v1alpha1.ModelContextType_Buffered,
+			Buffered: &v1alpha1.BufferedModelContext{BufferSize: 20},
+		}, modelConfig, modelClient)
+		require.NoError(t, err)
+		assert.Equal(t, "autogen_core.model_context.BufferedChatCompletionContext", modelContext.Provider)
+		assert.Equal(t, map[string]interface{}{"buffer_size": float64(20)}, modelContext.Config)
+
+		modelContext, err = translateModelContext(&v1alpha1.ModelContext{
+			Type:        v1alpha1.ModelContextType_HeadAndTail,
+			HeadAndTail: &v1alpha1.HeadAndTailModelContext{HeadSize: 2, TailSize: 10},
+		}, modelConfig, modelClient)
+		require.NoError(t, err)
+		assert.Equal(t, "autogen_core.model_context.HeadAndTailChatCompletionContext", modelContext.Provider)
+		assert.Equal(t, map[string]interface{}{"head_size": float64(2), "tail_size": float64(10)}, modelContext.Config)
+	})
+
+	t.Run("should count tokens with the model client", func(t *testing.T) {
+		modelContext, err := translateModelContext(tokenLimit(100000), modelConfig, modelClient)
+		require.NoError(t, err)
+		config := &api.TokenLimitedChatCompletionContextConfig{}
+		require.NoError(t, config.FromConfig(modelContext.Config))
+		assert.Equal(t, modelClient.Provider, config.ModelClient.Provider)
+		assert.Equal(t, 100000, *config.TokenLimit)
+	})
+
+	t.Run("should validate the token limit against the context window", func(t *testing.T) {
+		_, err := translateModelContext(tokenLimit(200000), modelConfig, modelClient)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds the context window of 128000 tokens of model gpt-4o")
+
+		// the model info declares the context window of models which are not well-known
+		custom := &v1alpha1.ModelConfig{Spec: v1alpha1.ModelConfigSpec{
+			Model:     "qwen3",
+			ModelInfo: &v1alpha1.ModelInfo{ContextWindow: 32768},
+		}}
+		_, err = translateModelContext(tokenLimit(40000), custom, modelClient)
+		require.Error(t, err)
+
+		custom.Spec.ModelInfo = nil
+		_, err = translateModelContext(tokenLimit(40000), custom, modelClient)
+		assert.NoError(t, err)
+
+		// models are matched by their exact name, variants with larger context windows are not rejected
+		for model, contextWindow := range map[string]int{"gpt-4": 8192, "gpt-4-32k": 32768, "gpt-4-0125-preview": 128000} {
+			_, err = translateModelContext(tokenLimit(contextWindow), &v1alpha1.ModelConfig{Spec: v1alpha1.ModelConfigSpec{Model: model}}, modelClient)
+			assert.NoError(t, err, model)
+		}
+		_, err = translateModelContext(tokenLimit(10000), &v1alpha1.ModelConfig{Spec: v1alpha1.ModelConfigSpec{Model: "gpt-4"}}, modelClient)
+		assert.Error(t, err)
+		_, err = translateModelContext(tokenLimit(500000), &v1alpha1.ModelConfig{Spec: v1alpha1.ModelConfigSpec{Model: "gpt-4o-finetuned"}}, modelClient)
+		assert.NoError(t, err)
+	})
+}
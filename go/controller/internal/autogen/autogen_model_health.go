@@ -0,0 +1,165 @@
+package autogen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var modelHealthLog = ctrl.Log.WithName("model-health")
+
+// ModelHealthChecker periodically checks the API keys and fallbacks of the model configs and
+// records their health in the status of the model configs. An API key is healthy if its provider
+// serves requests with it, so outages, rejected keys and rate limits of the provider are reported.
+// Failures of requests of agents between two checks are not observed.
+type ModelHealthChecker struct {
+	kube     client.Client
+	prober   *modelProber
+	interval time.Duration
+}
+
+func NewModelHealthChecker(kube client.Client, interval time.Duration) *ModelHealthChecker {
+	return &ModelHealthChecker{
+		kube:     kube,
+		prober:   newModelProber(),
+		interval: interval,
+	}
+}
+
+// Start implements controller-runtime's Runnable interface
+func (c *ModelHealthChecker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.checkModelConfigs(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements controller-runtime's LeaderElectionRunnable interface,
+// only the leader updates the status of model configs.
+func (c *ModelHealthChecker) NeedLeaderElection() bool {
+	return true
+}
+
+// checkModelConfigs updates the health in the status of the model configs with API keys or fallbacks.
+func (c *ModelHealthChecker) checkModelConfigs(ctx context.Context) {
+	modelConfigs := &v1alpha1.ModelConfigList{}
+	if err := c.kube.List(ctx, modelConfigs); err != nil {
+		modelHealthLog.Error(err, "Failed to list model configs")
+		return
+	}
+
+	for i := range modelConfigs.Items {
+		modelConfig := &modelConfigs.Items[i]
+		if !modelConfig.DeletionTimestamp.IsZero() || !c.checkModelClientHealth(ctx, modelConfig) {
+			continue
+		}
+		if err := c.kube.Status().Update(ctx, modelConfig); err != nil {
+			// model configs modified since they were listed are checked again at the next check
+			if !k8s_errors.IsConflict(err) && !k8s_errors.IsNotFound(err) {
+				modelHealthLog.Error(err, "Failed to update model config status", "modelConfig", client.ObjectKeyFromObject(modelConfig))
+			}
+		}
+	}
+}
+
+// checkModelClientHealth records the health of the API keys and fallbacks of the model config
+// in its status. It reports whether the status changed.
+func (c *ModelHealthChecker) checkModelClientHealth(ctx context.Context, modelConfig *v1alpha1.ModelConfig) bool {
+	var apiKeys []v1alpha1.ModelClientHealth
+	if len(modelConfig.Spec.APIKeySecrets) > 0 {
+		for _, apiKeyConfig := range modelConfigsPerAPIKey(modelConfig) {
+			err := c.checkAPIKey(ctx, apiKeyConfig)
+			name := apiKeyConfig.Spec.APIKeySecretRef + "/" + apiKeyConfig.Spec.APIKeySecretKey
+			apiKeys = append(apiKeys, modelClientHealth(modelConfig.Status.APIKeys, name, err))
+		}
+	}
+
+	var fallbacks []v1alpha1.ModelClientHealth
+	for _, fallback := range modelConfig.Spec.Fallbacks {
+		err := c.checkFallback(ctx, modelConfig, fallback)
+		fallbacks = append(fallbacks, modelClientHealth(modelConfig.Status.Fallbacks, fallback, err))
+	}
+
+	changed := !reflect.DeepEqual(apiKeys, modelConfig.Status.APIKeys) ||
+		!reflect.DeepEqual(fallbacks, modelConfig.Status.Fallbacks)
+	modelConfig.Status.APIKeys = apiKeys
+	modelConfig.Status.Fallbacks = fallbacks
+	return changed
+}
+
+// checkAPIKey returns an error if the API key of the model config cannot be read or its provider
+// does not serve requests with it.
+func (c *ModelHealthChecker) checkAPIKey(ctx context.Context, apiKeyConfig *v1alpha1.ModelConfig) error {
+	apiKey, err := getModelConfigApiKey(ctx, c.kube, apiKeyConfig)
+	if err != nil {
+		return err
+	}
+	return c.prober.checkAvailability(ctx, apiKeyConfig, string(apiKey))
+}
+
+// checkFallback returns an error if the fallback model config does not exist, is not accepted,
+// or its provider does not serve requests with any of its API keys.
+func (c *ModelHealthChecker) checkFallback(ctx context.Context, modelConfig *v1alpha1.ModelConfig, fallback string) error {
+	fallbackConfig := &v1alpha1.ModelConfig{}
+	if err := fetchObjKube(ctx, c.kube, fallbackConfig, fallback, modelConfig.Namespace); err != nil {
+		return fmt.Errorf("failed to fetch fallback model config %s: %w", fallback, err)
+	}
+	accepted := meta.FindStatusCondition(fallbackConfig.Status.Conditions, v1alpha1.ModelConfigConditionTypeAccepted)
+	if accepted != nil && accepted.Status == metav1.ConditionFalse {
+		return fmt.Errorf("fallback model config %s is not accepted: %s", fallback, accepted.Message)
+	}
+
+	// requests are retried with the next API key of the fallback, so a single healthy key is enough
+	apiKeyConfigs := modelConfigsPerAPIKey(fallbackConfig)
+	if len(apiKeyConfigs) == 0 {
+		apiKeyConfigs = append(apiKeyConfigs, fallbackConfig)
+	}
+	var errs []error
+	for _, apiKeyConfig := range apiKeyConfigs {
+		err := c.checkAPIKey(ctx, apiKeyConfig)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("fallback model config %s is unavailable: %w", fallback, errors.Join(errs...))
+}
+
+// modelClientHealth returns the health of the named API key or fallback given the error of its check,
+// keeping the last failure of its previous health.
+func modelClientHealth(previous []v1alpha1.ModelClientHealth, name string, err error) v1alpha1.ModelClientHealth {
+	health := v1alpha1.ModelClientHealth{Name: name, Healthy: err == nil}
+	wasHealthy := true
+	for _, prev := range previous {
+		if prev.Name == name {
+			health.LastFailureTime = prev.LastFailureTime
+			health.LastFailureMessage = prev.LastFailureMessage
+			wasHealthy = prev.Healthy
+			break
+		}
+	}
+
+	// the time of the failure is recorded when it starts, not every time it is observed
+	if err != nil && (wasHealthy || health.LastFailureMessage != err.Error()) {
+		now := metav1.Now()
+		health.LastFailureTime = &now
+		health.LastFailureMessage = err.Error()
+	}
+	return health
+}
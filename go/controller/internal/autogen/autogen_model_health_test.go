@@ -0,0 +1,154 @@
+package autogen
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newFailoverModelConfigs() (*v1alpha1.ModelConfig, *v1alpha1.ModelConfig, *v1.Secret) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kagent-openai", Namespace: "kagent"},
+		Data: map[string][]byte{
+			"key-1": []byte("secret-1"),
+			"key-2": []byte("secret-2"),
+		},
+	}
+	primary := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "openai", Namespace: "kagent"},
+		Spec: v1alpha1.ModelConfigSpec{
+			Model:           "gpt-4o",
+			Provider:        v1alpha1.OpenAI,
+			APIKeySecretRef: "kagent-openai",
+			APIKeySecretKey: "key-1",
+			APIKeySecrets:   []v1alpha1.APIKeySecretReference{{Name: "kagent-openai", Key: "key-2"}},
+			Fallbacks:       []string{"ollama"},
+		},
+	}
+	fallback := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "ollama", Namespace: "kagent"},
+		Spec: v1alpha1.ModelConfigSpec{
+			Model:    "llama3.2",
+			Provider: v1alpha1.Ollama,
+			Ollama:   &v1alpha1.OllamaConfig{Host: "http://ollama:11434"},
+		},
+	}
+	return primary, fallback, secret
+}
+
+func TestFailoverModelClient(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, v1alpha1.AddToScheme(scheme.Scheme))
+	primary, fallback, secret := newFailoverModelConfigs()
+	translator := &apiTranslator{kube: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(fallback, secret).Build()}
+
+	modelClient, err := translator.createModelClient(ctx, primary, false)
+	require.NoError(t, err)
+	assert.Equal(t, "kagent.models.FailoverChatCompletionClient", modelClient.Provider)
+
+	chain := &api.FailoverClientConfig{}
+	require.NoError(t, chain.FromConfig(modelClient.Config))
+	assert.False(t, chain.RoundRobin)
+	require.Len(t, chain.ModelClients, 2)
+	assert.Equal(t, "autogen_ext.models.ollama.OllamaChatCompletionClient", chain.ModelClients[1].Provider)
+
+	keys := &api.FailoverClientConfig{}
+	require.NoError(t, keys.FromConfig(chain.ModelClients[0].Config))
+	assert.True(t, keys.RoundRobin)
+	require.Len(t, keys.ModelClients, 2)
+	for i, apiKey := range []string{"secret-1", "secret-2"} {
+		config := &api.OpenAIClientConfig{}
+		require.NoError(t, config.FromConfig(keys.ModelClients[i].Config))
+		assert.Equal(t, apiKey, config.APIKey)
+	}
+
+	// a model config without API keys and fallbacks translates to the client of its provider
+	modelClient, err = translator.createModelClient(ctx, fallback, false)
+	require.NoError(t, err)
+	assert.Equal(t, "autogen_ext.models.ollama.OllamaChatCompletionClient", modelClient.Provider)
+
+	primary.Spec.Fallbacks = []string{"missing"}
+	_, err = translator.createModelClient(ctx, primary, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to fetch fallback model config missing")
+}
+
+func TestModelHealthChecker(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, v1alpha1.AddToScheme(scheme.Scheme))
+
+	// the OpenAI API rate limits the second key, the Ollama server is down
+	ollamaDown := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/models" && r.Header.Get("Authorization") == "Bearer secret-1":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/v1/models":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path == "/api/tags" && !ollamaDown:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	primary, fallback, secret := newFailoverModelConfigs()
+	primary.Spec.OpenAI = &v1alpha1.OpenAIConfig{BaseURL: server.URL + "/v1"}
+	primary.Spec.APIKeySecrets = append(primary.Spec.APIKeySecrets, v1alpha1.APIKeySecretReference{Name: "kagent-openai", Key: "key-3"})
+	primary.Spec.Fallbacks = []string{"ollama", "missing"}
+	fallback.Spec.Ollama.Host = server.URL
+	kube := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(primary, fallback, secret).WithStatusSubresource(primary).Build()
+	checker := NewModelHealthChecker(kube, time.Minute)
+
+	checker.checkModelConfigs(ctx)
+	require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(primary), primary))
+	status := primary.Status
+	require.Len(t, status.APIKeys, 3)
+	assert.Equal(t, "kagent-openai/key-1", status.APIKeys[0].Name)
+	assert.True(t, status.APIKeys[0].Healthy)
+	assert.Nil(t, status.APIKeys[0].LastFailureTime)
+	assert.False(t, status.APIKeys[1].Healthy)
+	assert.Contains(t, status.APIKeys[1].LastFailureMessage, "rate limits the API key")
+	assert.False(t, status.APIKeys[2].Healthy)
+	assert.Contains(t, status.APIKeys[2].LastFailureMessage, "API key not found")
+	require.Len(t, status.Fallbacks, 2)
+	assert.False(t, status.Fallbacks[0].Healthy)
+	assert.Contains(t, status.Fallbacks[0].LastFailureMessage, "is unavailable: status 503")
+	assert.False(t, status.Fallbacks[1].Healthy)
+	require.NotNil(t, status.Fallbacks[1].LastFailureTime)
+
+	// the failure time is kept while the failure persists
+	failureTime := status.Fallbacks[1].LastFailureTime
+	assert.False(t, checker.checkModelClientHealth(ctx, primary))
+	assert.Equal(t, failureTime, primary.Status.Fallbacks[1].LastFailureTime)
+
+	// the fallback recovers once its provider is back
+	ollamaDown = false
+	assert.True(t, checker.checkModelClientHealth(ctx, primary))
+	assert.True(t, primary.Status.Fallbacks[0].Healthy)
+	assert.Contains(t, primary.Status.Fallbacks[0].LastFailureMessage, "is unavailable: status 503")
+
+	// the last failure is kept after recovery
+	health := modelClientHealth(primary.Status.Fallbacks, "missing", nil)
+	assert.True(t, health.Healthy)
+	assert.Equal(t, failureTime, health.LastFailureTime)
+
+	health = modelClientHealth([]v1alpha1.ModelClientHealth{health}, "missing", errors.New("down"))
+	assert.False(t, health.Healthy)
+	assert.Equal(t, "down", health.LastFailureMessage)
+}
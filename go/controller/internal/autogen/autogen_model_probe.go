@@ -0,0 +1,342 @@
+package autogen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+const (
+	modelProbeTimeout = 30 * time.Second
+
+	// modelProbeMaxTokens bounds the completions of probe requests
+	modelProbeMaxTokens = 256
+)
+
+// modelProber detects the capabilities of models served by OpenAI-compatible servers.
+type modelProber struct {
+	httpClient *http.Client
+}
+
+func newModelProber() *modelProber {
+	return &modelProber{
+		httpClient: &http.Client{
+			Timeout: modelProbeTimeout,
+		},
+	}
+}
+
+// modelProbeRequest identifies the model to probe and how to reach its server.
+type modelProbeRequest struct {
+	baseURL string
+	model   string
+	apiKey  string
+	headers map[string]string
+}
+
+// probe detects the model info of the model:
+//   - the model must be listed by the /models endpoint, whose metadata reports vision support
+//     on servers that publish it
+//   - function calling is supported if a chat completion with a tool returns a tool call
+//   - JSON output is supported if a chat completion with a json_object response format returns JSON
+//
+// Servers which reject a probe request with a client error do not support the probed capability.
+func (p *modelProber) probe(ctx context.Context, req *modelProbeRequest) (*v1alpha1.ModelInfo, error) {
+	vision, err := p.probeModels(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	functionCalling, err := p.probeFunctionCalling(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	jsonOutput, err := p.probeJSONOutput(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1alpha1.ModelInfo{
+		Vision:          vision,
+		FunctionCalling: functionCalling,
+		JSONOutput:      jsonOutput,
+		Family:          "unknown",
+	}, nil
+}
+
+// probeModels checks that the model is served and reports whether its metadata declares vision support.
+func (p *modelProber) probeModels(ctx context.Context, req *modelProbeRequest) (bool, error) {
+	var models struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	status, err := p.do(ctx, req, http.MethodGet, "/models", nil, &models)
+	if err != nil {
+		return false, err
+	}
+	if status != http.StatusOK {
+		return false, fmt.Errorf("failed to list models of %s: status %d", req.baseURL, status)
+	}
+
+	ids := make([]string, 0, len(models.Data))
+	for _, model := range models.Data {
+		id, _ := model["id"].(string)
+		if id == req.model {
+			return modelMetadataDeclaresVision(model), nil
+		}
+		ids = append(ids, id)
+	}
+	return false, fmt.Errorf("model %s is not served by %s, available models: %s", req.model, req.baseURL, strings.Join(ids, ", "))
+}
+
+// modelMetadataDeclaresVision recognizes the non-standard metadata published by LiteLLM, LM Studio and Ollama.
+func modelMetadataDeclaresVision(model map[string]interface{}) bool {
+	if supportsVision, ok := model["supports_vision"].(bool); ok && supportsVision {
+		return true
+	}
+	if modelType, ok := model["type"].(string); ok && modelType == "vlm" {
+		return true
+	}
+	if capabilities, ok := model["capabilities"].([]interface{}); ok {
+		return slices.Contains(capabilities, interface{}("vision"))
+	}
+	return false
+}
+
+type probeChatCompletion struct {
+	Choices []struct {
+		Message struct {
+			Content   string            `json:"content"`
+			ToolCalls []json.RawMessage `json:"tool_calls"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+func (p *modelProber) probeFunctionCalling(ctx context.Context, req *modelProbeRequest) (bool, error) {
+	body := map[string]interface{}{
+		"model":       req.model,
+		"max_tokens":  modelProbeMaxTokens,
+		"temperature": 0,
+		"messages": []map[string]string{
+			{"role": "user", "content": "What time is it? Use the get_current_time tool to find out."},
+		},
+		"tools": []map[string]interface{}{
+			{
+				"type": "function",
+				"function": map[string]interface{}{
+					"name":        "get_current_time",
+					"description": "Returns the current time",
+					"parameters": map[string]interface{}{
+						"type":       "object",
+						"properties": map[string]interface{}{},
+					},
+				},
+			},
+		},
+	}
+
+	var completion probeChatCompletion
+	supported, err := p.chatCompletion(ctx, req, body, &completion)
+	if err != nil || !supported {
+		return false, err
+	}
+	return len(completion.Choices) > 0 && len(completion.Choices[0].Message.ToolCalls) > 0, nil
+}
+
+func (p *modelProber) probeJSONOutput(ctx context.Context, req *modelProbeRequest) (bool, error) {
+	body := map[string]interface{}{
+		"model":       req.model,
+		"max_tokens":  modelProbeMaxTokens,
+		"temperature": 0,
+		"messages": []map[string]string{
+			{"role": "user", "content": `Reply with a JSON object with the key "ok" set to true.`},
+		},
+		"response_format": map[string]string{"type": "json_object"},
+	}
+
+	var completion probeChatCompletion
+	supported, err := p.chatCompletion(ctx, req, body, &completion)
+	if err != nil || !supported {
+		return false, err
+	}
+	if len(completion.Choices) == 0 {
+		return false, nil
+	}
+	var object map[string]interface{}
+	return json.Unmarshal([]byte(completion.Choices[0].Message.Content), &object) == nil, nil
+}
+
+// chatCompletion sends a chat completion request. It reports false if the server rejected
+// the request with a client error, which servers return for unsupported features.
+func (p *modelProber) chatCompletion(ctx context.Context, req *modelProbeRequest, body interface{}, completion *probeChatCompletion) (bool, error) {
+	status, err := p.do(ctx, req, http.MethodPost, "/chat/completions", body, completion)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case status == http.StatusOK:
+		return true, nil
+	case status == http.StatusUnauthorized || status == http.StatusForbidden || status == http.StatusNotFound:
+		return false, fmt.Errorf("failed to create chat completion with %s: status %d", req.baseURL, status)
+	case status >= 400 && status < 500:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to create chat completion with %s: status %d", req.baseURL, status)
+	}
+}
+
+// checkAvailability checks that the provider of the model config serves requests with the API key
+// by listing its models, which fails like the requests of agents if the provider is down, rejects
+// the API key or rate limits it. Mock models are always available.
+func (p *modelProber) checkAvailability(ctx context.Context, modelConfig *v1alpha1.ModelConfig, apiKey string) error {
+	url, headers := listModelsRequest(modelConfig, apiKey)
+	if url == "" {
+		return nil
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create availability request: %w", err)
+	}
+	for k, v := range modelConfig.Spec.DefaultHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("%s rate limits the API key: status %d", url, resp.StatusCode)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%s rejects the API key: status %d", url, resp.StatusCode)
+	default:
+		return fmt.Errorf("%s is unavailable: status %d", url, resp.StatusCode)
+	}
+}
+
+// listModelsRequest returns the URL and headers of the request listing the models of the provider
+// of the model config, using the same defaults as the model clients. The URL is empty for providers
+// which cannot be checked.
+func listModelsRequest(modelConfig *v1alpha1.ModelConfig, apiKey string) (string, map[string]string) {
+	headers := map[string]string{}
+	switch modelConfig.Spec.Provider {
+	case v1alpha1.OpenAI:
+		baseURL := "https://api.openai.com/v1"
+		if openAIConfig := modelConfig.Spec.OpenAI; openAIConfig != nil {
+			if openAIConfig.BaseURL != "" {
+				baseURL = openAIConfig.BaseURL
+			}
+			if openAIConfig.Organization != "" {
+				headers["OpenAI-Organization"] = openAIConfig.Organization
+			}
+		}
+		headers["Authorization"] = "Bearer " + apiKey
+		return strings.TrimSuffix(baseURL, "/") + "/models", headers
+
+	case v1alpha1.OpenAICompatible:
+		if modelConfig.Spec.OpenAICompatible == nil {
+			return "", nil
+		}
+		if apiKey != "" {
+			headers["Authorization"] = "Bearer " + apiKey
+		}
+		return strings.TrimSuffix(modelConfig.Spec.OpenAICompatible.BaseURL, "/") + "/models", headers
+
+	case v1alpha1.AzureOpenAI:
+		azureConfig := modelConfig.Spec.AzureOpenAI
+		if azureConfig == nil {
+			return "", nil
+		}
+		if apiKey != "" {
+			headers["api-key"] = apiKey
+		} else if azureConfig.AzureADToken != "" {
+			headers["Authorization"] = "Bearer " + azureConfig.AzureADToken
+		}
+		return strings.TrimSuffix(azureConfig.Endpoint, "/") + "/openai/models?api-version=" + url.QueryEscape(azureConfig.APIVersion), headers
+
+	case v1alpha1.Anthropic:
+		baseURL := "https://api.anthropic.com"
+		if modelConfig.Spec.Anthropic != nil && modelConfig.Spec.Anthropic.BaseURL != "" {
+			baseURL = modelConfig.Spec.Anthropic.BaseURL
+		}
+		headers["x-api-key"] = apiKey
+		headers["anthropic-version"] = "2023-06-01"
+		return strings.TrimSuffix(baseURL, "/") + "/v1/models", headers
+
+	case v1alpha1.Ollama:
+		if modelConfig.Spec.Ollama == nil {
+			return "", nil
+		}
+		return strings.TrimSuffix(modelConfig.Spec.Ollama.Host, "/") + "/api/tags", headers
+
+	case v1alpha1.Gemini:
+		baseURL := "https://generativelanguage.googleapis.com/v1beta"
+		if modelConfig.Spec.Gemini != nil && modelConfig.Spec.Gemini.BaseURL != "" {
+			baseURL = modelConfig.Spec.Gemini.BaseURL
+		}
+		headers["x-goog-api-key"] = apiKey
+		return strings.TrimSuffix(baseURL, "/") + "/models", headers
+
+	default:
+		return "", nil
+	}
+}
+
+// do sends a request to the server and decodes successful responses into out.
+func (p *modelProber) do(ctx context.Context, req *modelProbeRequest, method, path string, body interface{}, out interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal probe request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	url := strings.TrimSuffix(req.baseURL, "/") + path
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create probe request: %w", err)
+	}
+	for k, v := range req.headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if req.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+req.apiKey)
+	}
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to probe %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return 0, fmt.Errorf("failed to decode response of %s: %w", url, err)
+	}
+	return resp.StatusCode, nil
+}
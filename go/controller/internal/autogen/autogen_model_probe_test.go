@@ -0,0 +1,128 @@
+package autogen
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newProbedServer serves an OpenAI-compatible API which rejects the features it does not support.
+func newProbedServer(t *testing.T, models string, functionCalling, jsonOutput bool) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/models", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		w.Write([]byte(models)) //nolint:errcheck
+	})
+	mux.HandleFunc("POST /v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		message := map[string]interface{}{"role": "assistant", "content": "It is noon."}
+		switch {
+		case req["tools"] != nil && !functionCalling:
+			http.Error(w, `"auto" tool choice requires --enable-auto-tool-choice`, http.StatusBadRequest)
+			return
+		case req["tools"] != nil:
+			message["tool_calls"] = []map[string]interface{}{{
+				"id":       "call-1",
+				"type":     "function",
+				"function": map[string]string{"name": "get_current_time", "arguments": "{}"},
+			}}
+		case req["response_format"] != nil && !jsonOutput:
+			http.Error(w, "response_format is not supported", http.StatusBadRequest)
+			return
+		case req["response_format"] != nil:
+			message["content"] = `{"ok": true}`
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{ //nolint:errcheck
+			"choices": []map[string]interface{}{{"index": 0, "message": message}},
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestModelProber(t *testing.T) {
+	ctx := context.Background()
+	prober := newModelProber()
+
+	t.Run("should detect supported capabilities", func(t *testing.T) {
+		server := newProbedServer(t, `{"data": [{"id": "qwen"}, {"id": "llava", "capabilities": ["completion", "vision"]}]}`, true, true)
+		modelInfo, err := prober.probe(ctx, &modelProbeRequest{baseURL: server.URL + "/v1/", model: "llava", apiKey: "secret"})
+		require.NoError(t, err)
+		assert.Equal(t, &v1alpha1.ModelInfo{
+			Vision:          true,
+			FunctionCalling: true,
+			JSONOutput:      true,
+			Family:          "unknown",
+		}, modelInfo)
+	})
+
+	t.Run("should detect unsupported capabilities", func(t *testing.T) {
+		server := newProbedServer(t, `{"data": [{"id": "qwen"}]}`, false, false)
+		modelInfo, err := prober.probe(ctx, &modelProbeRequest{baseURL: server.URL + "/v1", model: "qwen", apiKey: "secret"})
+		require.NoError(t, err)
+		assert.Equal(t, &v1alpha1.ModelInfo{Family: "unknown"}, modelInfo)
+	})
+
+	t.Run("should fail if the model is not served", func(t *testing.T) {
+		server := newProbedServer(t, `{"data": [{"id": "qwen"}]}`, true, true)
+		_, err := prober.probe(ctx, &modelProbeRequest{baseURL: server.URL + "/v1", model: "llama", apiKey: "secret"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "model llama is not served")
+		assert.Contains(t, err.Error(), "available models: qwen")
+	})
+
+	t.Run("should fail if the server can not be reached", func(t *testing.T) {
+		server := newProbedServer(t, `{"data": []}`, true, true)
+		server.Close()
+		_, err := prober.probe(ctx, &modelProbeRequest{baseURL: server.URL + "/v1", model: "qwen"})
+		require.Error(t, err)
+	})
+}
+
+func TestOpenAICompatibleModelClient(t *testing.T) {
+	ctx := context.Background()
+	modelConfig := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm", Namespace: "kagent"},
+		Spec: v1alpha1.ModelConfigSpec{
+			Model:            "qwen",
+			Provider:         v1alpha1.OpenAICompatible,
+			OpenAICompatible: &v1alpha1.OpenAICompatibleConfig{BaseURL: "http://vllm:8000/v1", Temperature: "0.2"},
+		},
+	}
+	translator := &apiTranslator{kube: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+
+	_, err := translator.createModelClientForProvider(ctx, modelConfig, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has not been detected yet")
+
+	modelConfig.Status.ModelInfo = &v1alpha1.ModelInfo{FunctionCalling: true, Family: "unknown"}
+	modelClient, err := translator.createModelClientForProvider(ctx, modelConfig, false)
+	require.NoError(t, err)
+	assert.Equal(t, "autogen_ext.models.openai.OpenAIChatCompletionClient", modelClient.Provider)
+
+	config := &api.OpenAIClientConfig{}
+	require.NoError(t, config.FromConfig(modelClient.Config))
+	assert.Equal(t, "http://vllm:8000/v1", *config.BaseURL)
+	assert.Equal(t, 0.2, config.Temperature)
+	assert.Equal(t, &api.ModelInfo{FunctionCalling: true, Family: "unknown"}, config.ModelInfo)
+
+	// the model info of the spec overrides the detected one
+	modelConfig.Spec.ModelInfo = &v1alpha1.ModelInfo{Vision: true, Family: "unknown"}
+	modelClient, err = translator.createModelClientForProvider(ctx, modelConfig, false)
+	require.NoError(t, err)
+	require.NoError(t, config.FromConfig(modelClient.Config))
+	assert.Equal(t, &api.ModelInfo{Vision: true, Family: "unknown"}, config.ModelInfo)
+}
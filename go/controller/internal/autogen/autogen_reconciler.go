@@ -2,14 +2,17 @@ package autogen
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"reflect"
+	"slices"
 	"strings"
 	"sync"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/kagent-dev/kagent/go/autogen/api"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,12 +24,24 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
 )
 
 var (
 	reconcileLog = ctrl.Log.WithName("reconciler")
 )
 
+const (
+	// CleanupFinalizer is added to every kagent resource so that the matching
+	// autogen objects are removed and dependents are requeued on deletion.
+	CleanupFinalizer = "kagent.dev/cleanup"
+
+	// ReferenceNotFoundReason is set on the Accepted condition when a resource
+	// refers to an object which does not exist or is being deleted.
+	ReferenceNotFoundReason = "ReferenceNotFound"
+)
+
 type AutogenReconciler interface {
 	ReconcileAutogenAgent(ctx context.Context, req ctrl.Request) error
 	ReconcileAutogenModelConfig(ctx context.Context, req ctrl.Request) error
@@ -34,14 +49,16 @@ type AutogenReconciler interface {
 	ReconcileAutogenApiKeySecret(ctx context.Context, req ctrl.Request) error
 	ReconcileAutogenToolServer(ctx context.Context, req ctrl.Request) error
 	ReconcileAutogenMemory(ctx context.Context, req ctrl.Request) error
+	ReconcileAutogenRemoteAgent(ctx context.Context, req ctrl.Request) error
 }
 
 type autogenReconciler struct {
 	autogenTranslator ApiTranslator
-	a2aReconciler     a2a.A2AReconciler
 
-	kube          client.Client
-	autogenClient autogen_client.Client
+	kube              client.Client
+	autogenClient     autogen_client.Client
+	remoteAgentClient *a2a.RemoteAgentClient
+	modelProber       *modelProber
 
 	defaultModelConfig types.NamespacedName
 	upsertLock         sync.Mutex
@@ -51,65 +68,65 @@ func NewAutogenReconciler(
 	translator ApiTranslator,
 	kube client.Client,
 	autogenClient autogen_client.Client,
+	remoteAgentClient *a2a.RemoteAgentClient,
 	defaultModelConfig types.NamespacedName,
-	a2aReconciler a2a.A2AReconciler,
 ) AutogenReconciler {
 	return &autogenReconciler{
 		autogenTranslator:  translator,
 		kube:               kube,
 		autogenClient:      autogenClient,
+		remoteAgentClient:  remoteAgentClient,
+		modelProber:        newModelProber(),
 		defaultModelConfig: defaultModelConfig,
-		a2aReconciler:      a2aReconciler,
 	}
 }
 
 func (a *autogenReconciler) ReconcileAutogenAgent(ctx context.Context, req ctrl.Request) error {
 	// reconcile the agent team itself
-
-	// TODO(sbx0r): missing finalizer logic
-
 	agent := &v1alpha1.Agent{}
 	if err := a.kube.Get(ctx, req.NamespacedName, agent); err != nil {
 		if k8s_errors.IsNotFound(err) {
-			return a.handleAgentDeletion(req)
+			// agents created before finalizers were introduced are cleaned up here
+			return a.handleAgentDeletion(ctx, req)
 		}
 
 		return fmt.Errorf("failed to get agent %s/%s: %w", req.Namespace, req.Name, err)
 	}
 
-	return a.handleExistingAgent(ctx, agent, req)
-}
+	if !agent.DeletionTimestamp.IsZero() {
+		if err := a.handleAgentDeletion(ctx, req); err != nil {
+			return err
+		}
+		return a.removeFinalizer(ctx, agent)
+	}
 
-func (a *autogenReconciler) handleAgentDeletion(req ctrl.Request) error {
-	// TODO(sbx0r): handle deletion of agents with multiple teams assignment
+	if err := a.addFinalizer(ctx, agent); err != nil {
+		return err
+	}
 
-	// agents, err := a.findTeamsUsingAgent(ctx, req)
-	// if err != nil {
-	// 	return fmt.Errorf("failed to find teams for agent %s/%s: %v", req.Namespace, req.Name, err)
-	// }
-	// if len(agents) > 1 {
-	// 	reconcileLog.Info("agent with multiple dependencies was deleted",
-	// 	"namespace", req.Namespace,
-	// 	"name", req.Name,
-	// 	"agents", agents)
-	// }
+	return a.handleExistingAgent(ctx, agent, req)
+}
 
-	// remove a2a handler if it exists
-	a.a2aReconciler.ReconcileAutogenAgentDeletion(req.Namespace, req.Name)
+func (a *autogenReconciler) handleAgentDeletion(ctx context.Context, req ctrl.Request) error {
+	if err := a.deleteAutogenTeam(ctx, req.Name); err != nil {
+		return fmt.Errorf("failed to delete agent %s/%s: %w",
+			req.Namespace, req.Name, err)
+	}
 
-	// TODO(sbx0r): temporary mock on GlobalUserID.
-	//              This block will be removed after resolving previous TODO
-	team, err := a.autogenClient.GetTeam(req.Name, common.GetGlobalUserID())
+	// teams and agents referencing this agent can no longer be translated
+	teams, err := a.findTeamsUsingAgent(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to get agent on agent deletion %s/%s: %w",
+		return fmt.Errorf("failed to find teams for agent %s/%s: %w",
 			req.Namespace, req.Name, err)
 	}
-
-	if team != nil {
-		if err = a.autogenClient.DeleteTeam(team.Id, team.UserID); err != nil {
-			return fmt.Errorf("failed to delete agent %s/%s: %w",
-				req.Namespace, req.Name, err)
-		}
+	agents, err := a.findAgentsUsingAgentTool(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to find agents for agent %s/%s: %w",
+			req.Namespace, req.Name, err)
+	}
+	if err := a.requeueDependents(ctx, agents, teams); err != nil {
+		return fmt.Errorf("failed to requeue dependents of agent %s/%s: %w",
+			req.Namespace, req.Name, err)
 	}
 
 	reconcileLog.Info("Agent was deleted", "namespace", req.Namespace, "name", req.Name)
@@ -134,6 +151,10 @@ func (a *autogenReconciler) handleExistingAgent(ctx context.Context, agent *v1al
 	}
 
 	if err := a.reconcileAgents(ctx, agent); err != nil {
+		// record the failure, e.g. a missing reference, before retrying with backoff
+		if statusErr := a.reconcileAgentStatus(ctx, agent, err); statusErr != nil {
+			return statusErr
+		}
 		return fmt.Errorf("failed to reconcile agent %s/%s: %w",
 			req.Namespace, req.Name, err)
 	}
@@ -156,7 +177,7 @@ func (a *autogenReconciler) reconcileAgentStatus(ctx context.Context, agent *v1a
 	if err != nil {
 		status = metav1.ConditionFalse
 		message = err.Error()
-		reason = "AgentReconcileFailed"
+		reason = failureReason(err, "AgentReconcileFailed")
 		reconcileLog.Error(err, "failed to reconcile agent", "agent", agent)
 	} else {
 		status = metav1.ConditionTrue
@@ -184,6 +205,9 @@ func (a *autogenReconciler) reconcileAgentStatus(ctx context.Context, agent *v1a
 func (a *autogenReconciler) ReconcileAutogenModelConfig(ctx context.Context, req ctrl.Request) error {
 	modelConfig := &v1alpha1.ModelConfig{}
 	if err := a.kube.Get(ctx, req.NamespacedName, modelConfig); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
 		return fmt.Errorf("failed to get model %s: %v", req.Name, err)
 	}
 
@@ -192,6 +216,35 @@ func (a *autogenReconciler) ReconcileAutogenModelConfig(ctx context.Context, req
 		return fmt.Errorf("failed to find agents for model %s: %v", req.Name, err)
 	}
 
+	if !modelConfig.DeletionTimestamp.IsZero() {
+		teams, err := a.findTeamsUsingModel(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to find teams for model %s: %v", req.Name, err)
+		}
+		if err := a.requeueDependents(ctx, agents, teams); err != nil {
+			return fmt.Errorf("failed to requeue dependents of model %s: %w", req.Name, err)
+		}
+		return a.removeFinalizer(ctx, modelConfig)
+	}
+
+	if err := a.addFinalizer(ctx, modelConfig); err != nil {
+		return err
+	}
+
+	probed, probeErr := a.probeModelInfo(ctx, modelConfig)
+	if probeErr != nil {
+		if err := a.reconcileModelConfigStatus(ctx, modelConfig, probeErr); err != nil {
+			return err
+		}
+		// return the error so that the model is probed again with backoff
+		return fmt.Errorf("failed to probe model %s: %w", req.Name, probeErr)
+	}
+	if probed {
+		// agents read the detected model info from the status, the status
+		// update triggers another reconcile which translates them
+		return nil
+	}
+
 	if err := a.reconcileAgents(ctx, agents...); err != nil {
 		return fmt.Errorf("failed to reconcile agents for model %s: %v", req.Name, err)
 	}
@@ -217,7 +270,7 @@ func (a *autogenReconciler) reconcileModelConfigStatus(ctx context.Context, mode
 	if err != nil {
 		status = metav1.ConditionFalse
 		message = err.Error()
-		reason = "ModelConfigReconcileFailed"
+		reason = failureReason(err, "ModelConfigReconcileFailed")
 		reconcileLog.Error(err, "failed to reconcile model config", "modelConfig", modelConfig)
 	} else {
 		status = metav1.ConditionTrue
@@ -242,15 +295,81 @@ func (a *autogenReconciler) reconcileModelConfigStatus(ctx context.Context, mode
 	return nil
 }
 
+// probeModelInfo detects the model info of OpenAICompatible models once per generation and
+// records it in the status. It reports whether the status was updated with a new model info.
+// Models with a model info in their spec are not probed, as the probed one would be ignored.
+func (a *autogenReconciler) probeModelInfo(ctx context.Context, modelConfig *v1alpha1.ModelConfig) (bool, error) {
+	if modelConfig.Spec.Provider != v1alpha1.OpenAICompatible || modelConfig.Spec.OpenAICompatible == nil ||
+		modelConfig.Spec.ModelInfo != nil {
+		modelConfig.Status.ModelInfo = nil
+		modelConfig.Status.ProbedGeneration = 0
+		return false, nil
+	}
+	if modelConfig.Status.ModelInfo != nil && modelConfig.Status.ProbedGeneration == modelConfig.Generation {
+		return false, nil
+	}
+
+	apiKey, err := getModelConfigApiKey(ctx, a.kube, modelConfig)
+	if err != nil {
+		return false, err
+	}
+	modelInfo, err := a.modelProber.probe(ctx, &modelProbeRequest{
+		baseURL: modelConfig.Spec.OpenAICompatible.BaseURL,
+		model:   modelConfig.Spec.Model,
+		apiKey:  string(apiKey),
+		headers: modelConfig.Spec.DefaultHeaders,
+	})
+	if err != nil {
+		// the model info of a previous generation may not match the server anymore
+		modelConfig.Status.ModelInfo = nil
+		modelConfig.Status.ProbedGeneration = 0
+		return false, err
+	}
+
+	now := metav1.Now()
+	modelConfig.Status.ModelInfo = modelInfo
+	modelConfig.Status.ProbedGeneration = modelConfig.Generation
+	modelConfig.Status.LastProbedTime = &now
+	if err := a.kube.Status().Update(ctx, modelConfig); err != nil {
+		return false, fmt.Errorf("failed to update model config status: %v", err)
+	}
+	return true, nil
+}
+
 func (a *autogenReconciler) ReconcileAutogenTeam(ctx context.Context, req ctrl.Request) error {
 	team := &v1alpha1.Team{}
 	if err := a.kube.Get(ctx, req.NamespacedName, team); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// teams created before finalizers were introduced are cleaned up here
+			return a.handleTeamDeletion(ctx, req)
+		}
 		return fmt.Errorf("failed to get team %s: %v", req.Name, err)
 	}
 
+	if !team.DeletionTimestamp.IsZero() {
+		if err := a.handleTeamDeletion(ctx, req); err != nil {
+			return err
+		}
+		return a.removeFinalizer(ctx, team)
+	}
+
+	if err := a.addFinalizer(ctx, team); err != nil {
+		return err
+	}
+
 	return a.reconcileTeamStatus(ctx, team, a.reconcileTeams(ctx, team))
 }
 
+func (a *autogenReconciler) handleTeamDeletion(ctx context.Context, req ctrl.Request) error {
+	if err := a.deleteAutogenTeam(ctx, req.Name); err != nil {
+		return fmt.Errorf("failed to delete team %s/%s: %w",
+			req.Namespace, req.Name, err)
+	}
+
+	reconcileLog.Info("Team was deleted", "namespace", req.Namespace, "name", req.Name)
+	return nil
+}
+
 func (a *autogenReconciler) reconcileTeamStatus(ctx context.Context, team *v1alpha1.Team, err error) error {
 	var (
 		status  metav1.ConditionStatus
@@ -261,7 +380,7 @@ func (a *autogenReconciler) reconcileTeamStatus(ctx context.Context, team *v1alp
 		status = metav1.ConditionFalse
 		message = err.Error()
 		reconcileLog.Error(err, "failed to reconcile team", "team", team)
-		reason = "TeamReconcileFailed"
+		reason = failureReason(err, "TeamReconcileFailed")
 	} else {
 		status = metav1.ConditionTrue
 		reason = "TeamReconciled"
@@ -307,25 +426,53 @@ func (a *autogenReconciler) ReconcileAutogenToolServer(ctx context.Context, req
 	// reconcile the agent team itself
 	toolServer := &v1alpha1.ToolServer{}
 	if err := a.kube.Get(ctx, req.NamespacedName, toolServer); err != nil {
-		// if the tool server is not found, we can ignore it
 		if k8s_errors.IsNotFound(err) {
-			return nil
+			// tool servers created before finalizers were introduced are cleaned up here
+			return a.handleToolServerDeletion(ctx, req)
 		}
 		return fmt.Errorf("failed to get tool server %s: %v", req.Name, err)
 	}
 
+	if !toolServer.DeletionTimestamp.IsZero() {
+		if err := a.handleToolServerDeletion(ctx, req); err != nil {
+			return err
+		}
+		return a.removeFinalizer(ctx, toolServer)
+	}
+
+	if err := a.addFinalizer(ctx, toolServer); err != nil {
+		return err
+	}
+
 	serverID, reconcileErr := a.reconcileToolServer(ctx, toolServer)
 
+	var discoveredTools []*v1alpha1.MCPTool
+	if reconcileErr == nil {
+		discoveredTools, reconcileErr = a.getDiscoveredMCPTools(ctx, serverID)
+	}
+
 	// update the tool server status as the agents depend on it
-	if err := a.reconcileToolServerStatus(
+	toolsChanged, err := a.reconcileToolServerStatus(
 		ctx,
 		toolServer,
-		serverID,
+		discoveredTools,
 		reconcileErr,
-	); err != nil {
+	)
+	if err != nil {
 		return fmt.Errorf("failed to reconcile tool server %s: %v", req.Name, err)
 	}
 
+	// return the error so that the tool server is retried with backoff
+	if reconcileErr != nil {
+		return fmt.Errorf("failed to reconcile tool server %s: %w", req.Name, reconcileErr)
+	}
+
+	// agents embed the discovered tools, so they only need to be
+	// reconciled when the set of tools changed
+	if !toolsChanged {
+		return nil
+	}
+
 	// find and reconcile all agents which use this tool server
 	agents, err := a.findAgentsUsingToolServer(ctx, req)
 	if err != nil {
@@ -339,16 +486,44 @@ func (a *autogenReconciler) ReconcileAutogenToolServer(ctx context.Context, req
 	return nil
 }
 
+func (a *autogenReconciler) handleToolServerDeletion(ctx context.Context, req ctrl.Request) error {
+	if err := a.deleteToolServer(ctx, req.Name); err != nil {
+		return fmt.Errorf("failed to delete tool server %s/%s: %w",
+			req.Namespace, req.Name, err)
+	}
+
+	agents, err := a.findAgentsUsingToolServer(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to find agents for tool server %s/%s: %w",
+			req.Namespace, req.Name, err)
+	}
+	if err := a.requeueDependents(ctx, agents, nil); err != nil {
+		return fmt.Errorf("failed to requeue dependents of tool server %s/%s: %w",
+			req.Namespace, req.Name, err)
+	}
+
+	reconcileLog.Info("Tool server was deleted", "namespace", req.Namespace, "name", req.Name)
+	return nil
+}
+
+// reconcileToolServerStatus records the discovered tools in the tool server status
+// and reports whether the set of tools changed since the last discovery.
 func (a *autogenReconciler) reconcileToolServerStatus(
 	ctx context.Context,
 	toolServer *v1alpha1.ToolServer,
-	serverID int,
+	discoveredTools []*v1alpha1.MCPTool,
 	err error,
-) error {
-	discoveredTools, discoveryErr := a.getDiscoveredMCPTools(serverID)
-	if discoveryErr != nil {
-		err = multierror.Append(err, discoveryErr)
+) (bool, error) {
+	// keep the previously discovered tools if the server could not be reached
+	toolsHash := toolServer.Status.ObservedToolsHash
+	if err == nil {
+		toolsHash, err = computeHash(discoveredTools)
+	}
+	if err != nil {
+		discoveredTools = toolServer.Status.DiscoveredTools
+		toolsHash = toolServer.Status.ObservedToolsHash
 	}
+	toolsChanged := toolsHash != toolServer.Status.ObservedToolsHash
 
 	var (
 		status  metav1.ConditionStatus
@@ -358,7 +533,7 @@ func (a *autogenReconciler) reconcileToolServerStatus(
 	if err != nil {
 		status = metav1.ConditionFalse
 		message = err.Error()
-		reason = "AgentReconcileFailed"
+		reason = failureReason(err, "AgentReconcileFailed")
 		reconcileLog.Error(err, "failed to reconcile agent", "agent", toolServer)
 	} else {
 		status = metav1.ConditionTrue
@@ -374,24 +549,32 @@ func (a *autogenReconciler) reconcileToolServerStatus(
 
 	// only update if the status has changed to prevent looping the reconciler
 	if !conditionChanged &&
-		toolServer.Status.ObservedGeneration == toolServer.Generation &&
-		reflect.DeepEqual(toolServer.Status.DiscoveredTools, discoveredTools) {
-		return nil
+		!toolsChanged &&
+		toolServer.Status.ObservedGeneration == toolServer.Generation {
+		return false, nil
 	}
 
 	toolServer.Status.ObservedGeneration = toolServer.Generation
 	toolServer.Status.DiscoveredTools = discoveredTools
+	if toolsChanged {
+		now := metav1.Now()
+		toolServer.Status.ObservedToolsHash = toolsHash
+		toolServer.Status.ToolsLastChangedTime = &now
+	}
 
 	if err := a.kube.Status().Update(ctx, toolServer); err != nil {
-		return fmt.Errorf("failed to update agent status: %v", err)
+		return false, fmt.Errorf("failed to update agent status: %v", err)
 	}
 
-	return nil
+	return toolsChanged, nil
 }
 
 func (a *autogenReconciler) ReconcileAutogenMemory(ctx context.Context, req ctrl.Request) error {
 	memory := &v1alpha1.Memory{}
 	if err := a.kube.Get(ctx, req.NamespacedName, memory); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
 		return fmt.Errorf("failed to get memory %s: %v", req.Name, err)
 	}
 
@@ -400,6 +583,17 @@ func (a *autogenReconciler) ReconcileAutogenMemory(ctx context.Context, req ctrl
 		return fmt.Errorf("failed to find agents using memory %s: %v", req.Name, err)
 	}
 
+	if !memory.DeletionTimestamp.IsZero() {
+		if err := a.requeueDependents(ctx, agents, nil); err != nil {
+			return fmt.Errorf("failed to requeue dependents of memory %s: %w", req.Name, err)
+		}
+		return a.removeFinalizer(ctx, memory)
+	}
+
+	if err := a.addFinalizer(ctx, memory); err != nil {
+		return err
+	}
+
 	return a.reconcileMemoryStatus(ctx, memory, a.reconcileAgents(ctx, agents...))
 }
 
@@ -412,7 +606,7 @@ func (a *autogenReconciler) reconcileMemoryStatus(ctx context.Context, memory *v
 	if err != nil {
 		status = metav1.ConditionFalse
 		message = err.Error()
-		reason = "MemoryReconcileFailed"
+		reason = failureReason(err, "MemoryReconcileFailed")
 		reconcileLog.Error(err, "failed to reconcile memory", "memory", memory)
 	} else {
 		status = metav1.ConditionTrue
@@ -436,58 +630,177 @@ func (a *autogenReconciler) reconcileMemoryStatus(ctx context.Context, memory *v
 	return nil
 }
 
+func (a *autogenReconciler) ReconcileAutogenRemoteAgent(ctx context.Context, req ctrl.Request) error {
+	remoteAgent := &v1alpha1.RemoteAgent{}
+	if err := a.kube.Get(ctx, req.NamespacedName, remoteAgent); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get remote agent %s: %v", req.Name, err)
+	}
+
+	agents, err := a.findAgentsUsingRemoteAgent(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to find agents using remote agent %s: %v", req.Name, err)
+	}
+
+	if !remoteAgent.DeletionTimestamp.IsZero() {
+		if err := a.requeueDependents(ctx, agents, nil); err != nil {
+			return fmt.Errorf("failed to requeue dependents of remote agent %s: %w", req.Name, err)
+		}
+		return a.removeFinalizer(ctx, remoteAgent)
+	}
+
+	if err := a.addFinalizer(ctx, remoteAgent); err != nil {
+		return err
+	}
+
+	card, fetchErr := a.remoteAgentClient.FetchAgentCard(ctx, remoteAgent)
+
+	// update the remote agent status as the agents depend on it
+	cardChanged, err := a.reconcileRemoteAgentStatus(ctx, remoteAgent, card, fetchErr)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile remote agent %s: %v", req.Name, err)
+	}
+
+	// return the error so that the remote agent is retried with backoff
+	if fetchErr != nil {
+		return fmt.Errorf("failed to reconcile remote agent %s: %w", req.Name, fetchErr)
+	}
+
+	// agents embed the agent card in their tools, so they only need to be
+	// reconciled when it changed
+	if !cardChanged {
+		return nil
+	}
+
+	if err := a.requeueDependents(ctx, agents, nil); err != nil {
+		return fmt.Errorf("failed to reconcile agents for remote agent %s: %w", req.Name, err)
+	}
+
+	return nil
+}
+
+// reconcileRemoteAgentStatus records the fetched agent card in the remote agent status
+// and reports whether the card changed since the last fetch.
+func (a *autogenReconciler) reconcileRemoteAgentStatus(
+	ctx context.Context,
+	remoteAgent *v1alpha1.RemoteAgent,
+	card *server.AgentCard,
+	err error,
+) (bool, error) {
+	// keep the previously fetched card if the agent could not be reached
+	agentCard := remoteAgent.Status.AgentCard
+	if err == nil {
+		agentCard = a2a.ConvertAgentCard(card)
+	}
+	cardChanged := !equality.Semantic.DeepEqual(agentCard, remoteAgent.Status.AgentCard)
+
+	var (
+		status  metav1.ConditionStatus
+		message string
+		reason  string
+	)
+	if err != nil {
+		status = metav1.ConditionFalse
+		message = err.Error()
+		reason = failureReason(err, "AgentCardFetchFailed")
+		reconcileLog.Error(err, "failed to fetch agent card", "remoteAgent", remoteAgent)
+	} else {
+		status = metav1.ConditionTrue
+		reason = "AgentCardFetched"
+	}
+	conditionChanged := meta.SetStatusCondition(&remoteAgent.Status.Conditions, metav1.Condition{
+		Type:               v1alpha1.RemoteAgentConditionTypeAccepted,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+
+	// successful fetches always record the fetch time, status updates do not trigger the reconciler
+	if err != nil &&
+		!conditionChanged &&
+		!cardChanged &&
+		remoteAgent.Status.ObservedGeneration == remoteAgent.Generation {
+		return false, nil
+	}
+
+	remoteAgent.Status.ObservedGeneration = remoteAgent.Generation
+	remoteAgent.Status.AgentCard = agentCard
+	if err == nil {
+		now := metav1.Now()
+		remoteAgent.Status.LastFetchedTime = &now
+	}
+
+	if err := a.kube.Status().Update(ctx, remoteAgent); err != nil {
+		return false, fmt.Errorf("failed to update remote agent status: %v", err)
+	}
+
+	return cardChanged, nil
+}
+
 func (a *autogenReconciler) reconcileTeams(ctx context.Context, teams ...*v1alpha1.Team) error {
-	errs := map[types.NamespacedName]error{}
+	errs := reconcileErrors{}
 	for _, team := range teams {
 		autogenTeam, err := a.autogenTranslator.TranslateGroupChatForTeam(ctx, team)
 		if err != nil {
-			errs[types.NamespacedName{Name: team.Name, Namespace: team.Namespace}] = fmt.Errorf("failed to translate team %s: %v", team.Name, err)
+			errs[types.NamespacedName{Name: team.Name, Namespace: team.Namespace}] = fmt.Errorf("failed to translate team %s: %w", team.Name, err)
 			continue
 		}
-		if err := a.upsertTeam(autogenTeam); err != nil {
-			errs[types.NamespacedName{Name: team.Name, Namespace: team.Namespace}] = fmt.Errorf("failed to upsert team %s: %v", team.Name, err)
+		if err := a.upsertTeamIfChanged(ctx, team, &team.Status.ComponentHash, &team.Status.BackendTeamID, autogenTeam); err != nil {
+			errs[types.NamespacedName{Name: team.Name, Namespace: team.Namespace}] = fmt.Errorf("failed to upsert team %s: %w", team.Name, err)
 			continue
 		}
 	}
 
 	if len(errs) > 0 {
-		return fmt.Errorf("failed to reconcile teams: %v", errs)
+		return fmt.Errorf("failed to reconcile teams: %w", errs)
 	}
 
 	return nil
 }
 
 func (a *autogenReconciler) reconcileAgents(ctx context.Context, agents ...*v1alpha1.Agent) error {
-	errs := map[types.NamespacedName]error{}
+	errs := reconcileErrors{}
 	for _, agent := range agents {
 		autogenTeam, err := a.autogenTranslator.TranslateGroupChatForAgent(ctx, agent)
 		if err != nil {
-			errs[types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}] = fmt.Errorf("failed to translate agent %s: %v", agent.Name, err)
-			continue
-		}
-		if err := a.reconcileA2A(ctx, autogenTeam, agent); err != nil {
-			errs[types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}] = fmt.Errorf("failed to reconcile A2A for agent %s: %v", agent.Name, err)
+			errs[types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}] = fmt.Errorf("failed to translate agent %s: %w", agent.Name, err)
 			continue
 		}
-		if err := a.upsertTeam(autogenTeam); err != nil {
-			errs[types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}] = fmt.Errorf("failed to upsert agent %s: %v", agent.Name, err)
+		if err := a.upsertTeamIfChanged(ctx, agent, &agent.Status.ComponentHash, &agent.Status.BackendTeamID, autogenTeam); err != nil {
+			errs[types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}] = fmt.Errorf("failed to upsert agent %s: %w", agent.Name, err)
 			continue
 		}
 	}
 
 	if len(errs) > 0 {
-		return fmt.Errorf("failed to reconcile agents: %v", errs)
+		return fmt.Errorf("failed to reconcile agents: %w", errs)
 	}
 
 	return nil
 }
 
 func (a *autogenReconciler) reconcileToolServer(ctx context.Context, server *v1alpha1.ToolServer) (int, error) {
+	// an unchanged tool server which was accepted before only needs its tools refreshed
+	if server.Status.ObservedGeneration == server.Generation &&
+		meta.IsStatusConditionTrue(server.Status.Conditions, v1alpha1.AgentConditionTypeAccepted) {
+		serverID, err := a.refreshToolServer(ctx, server.Name)
+		if err == nil {
+			return serverID, nil
+		}
+		reconcileLog.Info("failed to refresh tool server, upserting it",
+			"namespace", server.Namespace,
+			"name", server.Name,
+			"error", err.Error())
+	}
+
 	toolServer, err := a.autogenTranslator.TranslateToolServer(ctx, server)
 	if err != nil {
 		return 0, fmt.Errorf("failed to translate tool server %s: %v", server.Name, err)
 	}
-	serverID, err := a.upsertToolServer(toolServer)
+	serverID, err := a.upsertToolServer(ctx, toolServer)
 	if err != nil {
 		return 0, fmt.Errorf("failed to upsert tool server %s: %v", server.Name, err)
 	}
@@ -495,7 +808,122 @@ func (a *autogenReconciler) reconcileToolServer(ctx context.Context, server *v1a
 	return serverID, nil
 }
 
-func (a *autogenReconciler) upsertTeam(team *autogen_client.Team) error {
+// requeueDependents re-translates the given agents and teams and records the
+// outcome in their status, e.g. after one of their references was deleted.
+func (a *autogenReconciler) requeueDependents(ctx context.Context, agents []*v1alpha1.Agent, teams []*v1alpha1.Team) error {
+	var errs *multierror.Error
+	for _, agent := range agents {
+		if !agent.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := a.reconcileAgentStatus(ctx, agent, a.reconcileAgents(ctx, agent)); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	for _, team := range teams {
+		if !team.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := a.reconcileTeamStatus(ctx, team, a.reconcileTeams(ctx, team)); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func (a *autogenReconciler) addFinalizer(ctx context.Context, obj client.Object) error {
+	if !controllerutil.AddFinalizer(obj, CleanupFinalizer) {
+		return nil
+	}
+	if err := a.kube.Update(ctx, obj); err != nil {
+		return fmt.Errorf("failed to add finalizer to %s: %w", obj.GetName(), err)
+	}
+	return nil
+}
+
+func (a *autogenReconciler) removeFinalizer(ctx context.Context, obj client.Object) error {
+	if !controllerutil.RemoveFinalizer(obj, CleanupFinalizer) {
+		return nil
+	}
+	if err := a.kube.Update(ctx, obj); err != nil {
+		return fmt.Errorf("failed to remove finalizer from %s: %w", obj.GetName(), err)
+	}
+	return nil
+}
+
+func (a *autogenReconciler) deleteAutogenTeam(ctx context.Context, label string) error {
+	// lock to prevent races
+	a.upsertLock.Lock()
+	defer a.upsertLock.Unlock()
+
+	// TODO(sbx0r): temporary mock on GlobalUserID.
+	team, err := a.autogenClient.GetTeam(ctx, label, common.GetGlobalUserID())
+	if err != nil {
+		return fmt.Errorf("failed to get team %s: %w", label, err)
+	}
+	if team == nil {
+		return nil
+	}
+
+	return a.autogenClient.DeleteTeam(ctx, team.Id, team.UserID)
+}
+
+func (a *autogenReconciler) deleteToolServer(ctx context.Context, label string) error {
+	// lock to prevent races
+	a.upsertLock.Lock()
+	defer a.upsertLock.Unlock()
+
+	toolServer, err := a.autogenClient.GetToolServerByLabel(ctx, label, common.GetGlobalUserID())
+	if err != nil {
+		if autogen_client.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get toolServer %s: %w", label, err)
+	}
+
+	return a.autogenClient.DeleteToolServer(ctx, &toolServer.Id, common.GetGlobalUserID())
+}
+
+// upsertTeamIfChanged pushes the team to the backend unless its component matches
+// the hash recorded in the status of obj and the backend still has the team with the
+// recorded ID, and records the new hash and ID once pushed.
+func (a *autogenReconciler) upsertTeamIfChanged(
+	ctx context.Context,
+	obj client.Object,
+	componentHash *string,
+	backendTeamID *int,
+	team *autogen_client.Team,
+) error {
+	hash, err := computeHash(team.Component)
+	if err != nil {
+		return err
+	}
+	if *componentHash == hash && *backendTeamID != 0 {
+		// the backend may have lost the team, e.g. after its database was reset
+		existingTeam, err := a.autogenClient.GetTeamByID(ctx, *backendTeamID, common.GetGlobalUserID())
+		if err != nil && !autogen_client.IsNotFound(err) {
+			return fmt.Errorf("failed to get existing team %s: %w", team.Component.Label, err)
+		}
+		if err == nil && existingTeam != nil && existingTeam.Component != nil && existingTeam.Component.Label == team.Component.Label {
+			return nil
+		}
+		reconcileLog.Info("team is missing in the backend, upserting it", "team", team.Component.Label)
+	}
+
+	if err := a.upsertTeam(ctx, team); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	*componentHash = hash
+	*backendTeamID = team.Id
+	if err := a.kube.Status().Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("failed to record component hash of %s: %w", team.Component.Label, err)
+	}
+	return nil
+}
+
+func (a *autogenReconciler) upsertTeam(ctx context.Context, team *autogen_client.Team) error {
 	// lock to prevent races
 	a.upsertLock.Lock()
 	defer a.upsertLock.Unlock()
@@ -503,54 +931,54 @@ func (a *autogenReconciler) upsertTeam(team *autogen_client.Team) error {
 	req := autogen_client.ValidationRequest{
 		Component: team.Component,
 	}
-	resp, err := a.autogenClient.Validate(&req)
+	resp, err := a.autogenClient.Validate(ctx, &req)
 	if err != nil {
-		return fmt.Errorf("failed to validate team %s: %v", team.Component.Label, err)
+		return fmt.Errorf("failed to validate team %s: %w", team.Component.Label, err)
 	}
 	if !resp.IsValid {
 		return fmt.Errorf("team %s is invalid: %v", team.Component.Label, resp.ErrorMsg())
 	}
 
 	// delete if team exists
-	existingTeam, err := a.autogenClient.GetTeam(team.Component.Label, common.GetGlobalUserID())
+	existingTeam, err := a.autogenClient.GetTeam(ctx, team.Component.Label, common.GetGlobalUserID())
 	if err != nil {
-		return fmt.Errorf("failed to get existing team %s: %v", team.Component.Label, err)
+		return fmt.Errorf("failed to get existing team %s: %w", team.Component.Label, err)
 	}
 	if existingTeam != nil {
 		team.Id = existingTeam.Id
 	}
 
-	return a.autogenClient.CreateTeam(team)
+	return a.autogenClient.CreateTeam(ctx, team)
 }
 
-func (a *autogenReconciler) upsertToolServer(toolServer *autogen_client.ToolServer) (int, error) {
+func (a *autogenReconciler) upsertToolServer(ctx context.Context, toolServer *autogen_client.ToolServer) (int, error) {
 	// lock to prevent races
 	a.upsertLock.Lock()
 	defer a.upsertLock.Unlock()
 
 	// delete if toolServer exists
-	existingToolServer, err := a.autogenClient.GetToolServerByLabel(toolServer.Component.Label, common.GetGlobalUserID())
-	if err != nil && !strings.Contains(err.Error(), "not found") {
+	existingToolServer, err := a.autogenClient.GetToolServerByLabel(ctx, toolServer.Component.Label, common.GetGlobalUserID())
+	if err != nil && !autogen_client.IsNotFound(err) {
 		return 0, fmt.Errorf("failed to get existing toolServer %s: %v", toolServer.Component.Label, err)
 	}
 	if existingToolServer != nil {
 		toolServer.Id = existingToolServer.Id
-		err = a.autogenClient.UpdateToolServer(toolServer, common.GetGlobalUserID())
+		err = a.autogenClient.UpdateToolServer(ctx, toolServer, common.GetGlobalUserID())
 		if err != nil {
 			return 0, fmt.Errorf("failed to delete existing toolServer %s: %v", toolServer.Component.Label, err)
 		}
 	} else {
-		existingToolServer, err = a.autogenClient.CreateToolServer(toolServer, common.GetGlobalUserID())
+		existingToolServer, err = a.autogenClient.CreateToolServer(ctx, toolServer, common.GetGlobalUserID())
 		if err != nil {
 			return 0, fmt.Errorf("failed to create toolServer %s: %v", toolServer.Component.Label, err)
 		}
-		existingToolServer, err = a.autogenClient.GetToolServerByLabel(toolServer.Component.Label, common.GetGlobalUserID())
+		existingToolServer, err = a.autogenClient.GetToolServerByLabel(ctx, toolServer.Component.Label, common.GetGlobalUserID())
 		if err != nil {
 			return 0, fmt.Errorf("failed to get existing toolServer %s: %v", toolServer.Component.Label, err)
 		}
 	}
 
-	err = a.autogenClient.RefreshToolServer(existingToolServer.Id, common.GetGlobalUserID())
+	err = a.autogenClient.RefreshToolServer(ctx, existingToolServer.Id, common.GetGlobalUserID())
 	if err != nil {
 		return 0, fmt.Errorf("failed to refresh toolServer %s: %v", toolServer.Component.Label, err)
 	}
@@ -558,60 +986,45 @@ func (a *autogenReconciler) upsertToolServer(toolServer *autogen_client.ToolServ
 	return existingToolServer.Id, nil
 }
 
-func (a *autogenReconciler) findAgentsUsingModel(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
-	var agentsList v1alpha1.AgentList
-	if err := a.kube.List(
-		ctx,
-		&agentsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list agents: %v", err)
+// refreshToolServer re-discovers the tools of an existing tool server.
+func (a *autogenReconciler) refreshToolServer(ctx context.Context, label string) (int, error) {
+	// lock to prevent races
+	a.upsertLock.Lock()
+	defer a.upsertLock.Unlock()
+
+	existingToolServer, err := a.autogenClient.GetToolServerByLabel(ctx, label, common.GetGlobalUserID())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get existing toolServer %s: %v", label, err)
 	}
 
-	var agents []*v1alpha1.Agent
-	for i := range agentsList.Items {
-		agent := &agentsList.Items[i]
-		if getRefFromString(agent.Spec.ModelConfig, agent.Namespace) == req.NamespacedName {
-			agents = append(agents, agent)
-		}
+	if err := a.autogenClient.RefreshToolServer(ctx, existingToolServer.Id, common.GetGlobalUserID()); err != nil {
+		return 0, fmt.Errorf("failed to refresh toolServer %s: %v", label, err)
 	}
 
-	return agents, nil
+	return existingToolServer.Id, nil
 }
 
-func (a *autogenReconciler) findAgentsUsingApiKeySecret(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
-	var modelsList v1alpha1.ModelConfigList
-	if err := a.kube.List(
-		ctx,
-		&modelsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list model configs: %v", err)
-	}
+func (a *autogenReconciler) findAgentsUsingModel(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
+	return a.listAgentsByIndex(ctx, AgentModelConfigIndex, req.NamespacedName)
+}
 
-	var models []string
-	for _, model := range modelsList.Items {
-		if getRefFromString(model.Spec.APIKeySecretRef, model.Namespace) == req.NamespacedName {
-			models = append(models, model.Name)
-		}
+func (a *autogenReconciler) findAgentsUsingApiKeySecret(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
+	models, err := a.findModelsUsingApiKeySecret(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
 	var agents []*v1alpha1.Agent
-	uniqueAgents := make(map[string]bool)
-
-	for _, modelName := range models {
-		agentsUsingModel, err := a.findAgentsUsingModel(ctx, ctrl.Request{
-			NamespacedName: types.NamespacedName{
-				Namespace: req.Namespace,
-				Name:      modelName,
-			},
-		})
+	uniqueAgents := make(map[types.NamespacedName]bool)
+
+	for _, model := range models {
+		agentsUsingModel, err := a.findAgentsUsingModel(ctx, ctrl.Request{NamespacedName: model})
 		if err != nil {
-			return nil, fmt.Errorf("failed to find agents for model %s: %v", modelName, err)
+			return nil, fmt.Errorf("failed to find agents for model %s: %v", model, err)
 		}
 
 		for _, agent := range agentsUsingModel {
-			key := fmt.Sprintf("%s/%s", agent.Namespace, agent.Name)
+			key := types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}
 			if !uniqueAgents[key] {
 				uniqueAgents[key] = true
 				agents = append(agents, agent)
@@ -623,46 +1036,67 @@ func (a *autogenReconciler) findAgentsUsingApiKeySecret(ctx context.Context, req
 }
 
 func (a *autogenReconciler) findAgentsUsingMemory(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
-	var agentsList v1alpha1.AgentList
-	if err := a.kube.List(
-		ctx,
-		&agentsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list agents: %v", err)
+	return a.listAgentsByIndex(ctx, AgentMemoryIndex, req.NamespacedName)
+}
+
+func (a *autogenReconciler) findTeamsUsingAgent(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Team, error) {
+	return a.listTeamsByIndex(ctx, TeamParticipantIndex, req.NamespacedName)
+}
+
+// findTeamsUsingModel returns the teams which use the model config themselves or through
+// one of their participants.
+func (a *autogenReconciler) findTeamsUsingModel(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Team, error) {
+	teams, err := a.listTeamsByIndex(ctx, TeamModelConfigIndex, req.NamespacedName)
+	if err != nil {
+		return nil, err
 	}
 
-	var agents []*v1alpha1.Agent
-	for i := range agentsList.Items {
-		agent := &agentsList.Items[i]
-		for _, memory := range agent.Spec.Memory {
-			if getRefFromString(memory, agent.Namespace) == req.NamespacedName {
-				agents = append(agents, agent)
-				break
+	agents, err := a.findAgentsUsingModel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	uniqueTeams := make(map[types.NamespacedName]bool)
+	for _, team := range teams {
+		uniqueTeams[types.NamespacedName{Namespace: team.Namespace, Name: team.Name}] = true
+	}
+	for _, agent := range agents {
+		teamsUsingAgent, err := a.findTeamsUsingAgent(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find teams for agent %s: %v", agent.Name, err)
+		}
+		for _, team := range teamsUsingAgent {
+			key := types.NamespacedName{Namespace: team.Namespace, Name: team.Name}
+			if !uniqueTeams[key] {
+				uniqueTeams[key] = true
+				teams = append(teams, team)
 			}
 		}
 	}
 
-	return agents, nil
+	return teams, nil
 }
 
-func (a *autogenReconciler) findTeamsUsingAgent(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Team, error) {
-	var teamsList v1alpha1.TeamList
-	if err := a.kube.List(
-		ctx,
-		&teamsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list teams: %v", err)
+func (a *autogenReconciler) findTeamsUsingApiKeySecret(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Team, error) {
+	models, err := a.findModelsUsingApiKeySecret(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
 	var teams []*v1alpha1.Team
-	for i := range teamsList.Items {
-		team := &teamsList.Items[i]
-		for _, participant := range team.Spec.Participants {
-			if getRefFromString(participant, team.Namespace) == req.NamespacedName {
+	uniqueTeams := make(map[types.NamespacedName]bool)
+
+	for _, model := range models {
+		teamsUsingModel, err := a.findTeamsUsingModel(ctx, ctrl.Request{NamespacedName: model})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find teams for model %s: %v", model, err)
+		}
+
+		for _, team := range teamsUsingModel {
+			key := types.NamespacedName{Namespace: team.Namespace, Name: team.Name}
+			if !uniqueTeams[key] {
+				uniqueTeams[key] = true
 				teams = append(teams, team)
-				break
 			}
 		}
 	}
@@ -670,101 +1104,76 @@ func (a *autogenReconciler) findTeamsUsingAgent(ctx context.Context, req ctrl.Re
 	return teams, nil
 }
 
-func (a *autogenReconciler) findTeamsUsingModel(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Team, error) {
-	var teamsList v1alpha1.TeamList
-	if err := a.kube.List(
-		ctx,
-		&teamsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list teams: %v", err)
-	}
+func (a *autogenReconciler) findAgentsUsingToolServer(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
+	return a.listAgentsByIndex(ctx, AgentToolServerIndex, req.NamespacedName)
+}
 
-	var teams []*v1alpha1.Team
-	for i := range teamsList.Items {
-		team := &teamsList.Items[i]
-		if getRefFromString(team.Spec.ModelConfig, team.Namespace) == req.NamespacedName {
-			teams = append(teams, team)
-		}
-	}
+func (a *autogenReconciler) findAgentsUsingAgentTool(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
+	return a.listAgentsByIndex(ctx, AgentAgentToolIndex, req.NamespacedName)
+}
 
-	return teams, nil
+func (a *autogenReconciler) findAgentsUsingRemoteAgent(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
+	return a.listAgentsByIndex(ctx, AgentRemoteAgentIndex, req.NamespacedName)
 }
 
-func (a *autogenReconciler) findTeamsUsingApiKeySecret(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Team, error) {
+func (a *autogenReconciler) findModelsUsingApiKeySecret(ctx context.Context, req ctrl.Request) ([]types.NamespacedName, error) {
 	var modelsList v1alpha1.ModelConfigList
 	if err := a.kube.List(
 		ctx,
 		&modelsList,
-		client.InNamespace(req.Namespace),
+		client.MatchingFields{ModelConfigApiKeySecretIndex: req.NamespacedName.String()},
 	); err != nil {
 		return nil, fmt.Errorf("failed to list model configs: %v", err)
 	}
 
-	var models []string
+	models := make([]types.NamespacedName, 0, len(modelsList.Items))
 	for _, model := range modelsList.Items {
-		if getRefFromString(model.Spec.APIKeySecretRef, model.Namespace) == req.NamespacedName {
-			models = append(models, model.Name)
-		}
+		models = append(models, types.NamespacedName{Namespace: model.Namespace, Name: model.Name})
 	}
 
-	var teams []*v1alpha1.Team
-	uniqueTeams := make(map[string]bool)
-
-	for _, modelName := range models {
-		teamsUsingModel, err := a.findTeamsUsingModel(ctx, ctrl.Request{
-			NamespacedName: types.NamespacedName{
-				Namespace: req.Namespace,
-				Name:      modelName,
-			},
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to find teams for model %s: %v", modelName, err)
-		}
-
-		for _, team := range teamsUsingModel {
-			key := fmt.Sprintf("%s/%s", team.Namespace, team.Name)
-			if !uniqueTeams[key] {
-				uniqueTeams[key] = true
-				teams = append(teams, team)
-			}
-		}
-	}
-
-	return teams, nil
+	return models, nil
 }
 
-func (a *autogenReconciler) findAgentsUsingToolServer(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
+// listAgentsByIndex returns the agents whose reference index contains the given object.
+func (a *autogenReconciler) listAgentsByIndex(ctx context.Context, index string, ref types.NamespacedName) ([]*v1alpha1.Agent, error) {
 	var agentsList v1alpha1.AgentList
 	if err := a.kube.List(
 		ctx,
 		&agentsList,
-		client.InNamespace(req.Namespace),
+		client.MatchingFields{index: ref.String()},
 	); err != nil {
 		return nil, fmt.Errorf("failed to list agents: %v", err)
 	}
 
-	var agents []*v1alpha1.Agent
-	appendAgentIfUsesToolServer := func(agent *v1alpha1.Agent) {
-		for _, tool := range agent.Spec.Tools {
-			if tool.McpServer != nil && getRefFromString(tool.McpServer.ToolServer, agent.Namespace) == req.NamespacedName {
-				agents = append(agents, agent)
-				return
-			}
-		}
+	agents := make([]*v1alpha1.Agent, 0, len(agentsList.Items))
+	for i := range agentsList.Items {
+		agents = append(agents, &agentsList.Items[i])
 	}
 
-	for _, agent := range agentsList.Items {
-		agent := agent
-		appendAgentIfUsesToolServer(&agent)
+	return agents, nil
+}
+
+// listTeamsByIndex returns the teams whose reference index contains the given object.
+func (a *autogenReconciler) listTeamsByIndex(ctx context.Context, index string, ref types.NamespacedName) ([]*v1alpha1.Team, error) {
+	var teamsList v1alpha1.TeamList
+	if err := a.kube.List(
+		ctx,
+		&teamsList,
+		client.MatchingFields{index: ref.String()},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list teams: %v", err)
 	}
 
-	return agents, nil
+	teams := make([]*v1alpha1.Team, 0, len(teamsList.Items))
+	for i := range teamsList.Items {
+		teams = append(teams, &teamsList.Items[i])
+	}
 
+	return teams, nil
 }
 
-func (a *autogenReconciler) getDiscoveredMCPTools(serverID int) ([]*v1alpha1.MCPTool, error) {
-	allTools, err := a.autogenClient.ListTools(common.GetGlobalUserID())
+func (a *autogenReconciler) getDiscoveredMCPTools(ctx context.Context, serverID int) ([]*v1alpha1.MCPTool, error) {
+	allTools, err := a.autogenClient.ListTools(ctx, common.GetGlobalUserID())
 	if err != nil {
 		return nil, err
 	}
@@ -780,15 +1189,47 @@ func (a *autogenReconciler) getDiscoveredMCPTools(serverID int) ([]*v1alpha1.MCP
 		}
 	}
 
+	// keep the order stable so that the status and tools hash only change with the tools
+	slices.SortFunc(discoveredTools, func(a, b *v1alpha1.MCPTool) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
 	return discoveredTools, nil
 }
 
-func (a *autogenReconciler) reconcileA2A(
-	ctx context.Context,
-	team *autogen_client.Team,
-	agent *v1alpha1.Agent,
-) error {
-	return a.a2aReconciler.ReconcileAutogenAgent(ctx, agent, team)
+// computeHash returns a stable hash of the JSON representation of v.
+func computeHash(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %T: %v", v, err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// reconcileErrors collects reconcile errors per object while keeping them
+// inspectable with errors.Is and errors.As.
+type reconcileErrors map[types.NamespacedName]error
+
+func (e reconcileErrors) Error() string {
+	return fmt.Sprintf("%v", map[types.NamespacedName]error(e))
+}
+
+func (e reconcileErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// failureReason reports ReferenceNotFoundReason when the error was caused by a
+// missing reference and the given reason otherwise.
+func failureReason(err error, reason string) string {
+	if k8s_errors.IsNotFound(err) {
+		return ReferenceNotFoundReason
+	}
+	return reason
 }
 
 func convertTool(tool *autogen_client.Tool) (*v1alpha1.MCPTool, error) {
@@ -0,0 +1,310 @@
+package autogen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	autogen_fake "github.com/kagent-dev/kagent/go/autogen/fake"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "kagent"
+
+// newTestReconciler returns a reconciler backed by a fake kube client, which serves the
+// field indexes of the manager, and a fake autogen backend.
+func newTestReconciler(t *testing.T, objs ...client.Object) (*autogenReconciler, client.Client, *autogen_fake.Server) {
+	t.Helper()
+	require.NoError(t, v1alpha1.AddToScheme(scheme.Scheme))
+	builder := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.Agent{}, &v1alpha1.Team{}, &v1alpha1.ModelConfig{}, &v1alpha1.ToolServer{})
+	for _, idx := range fieldIndexes {
+		builder = builder.WithIndex(idx.obj, idx.field, idx.indexFn)
+	}
+	kube := builder.Build()
+
+	backend := autogen_fake.NewServer()
+	t.Cleanup(backend.Close)
+
+	defaultModelConfig := types.NamespacedName{Namespace: testNamespace, Name: "default-model"}
+	reconciler := NewAutogenReconciler(
+		NewAutogenApiTranslator(kube, defaultModelConfig),
+		kube,
+		backend.Client(),
+		nil,
+		defaultModelConfig,
+	).(*autogenReconciler)
+	return reconciler, kube, backend
+}
+
+func newTestModelConfig(name string) *v1alpha1.ModelConfig {
+	return &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec: v1alpha1.ModelConfigSpec{
+			Model:    "llama3.2",
+			Provider: v1alpha1.Ollama,
+			Ollama:   &v1alpha1.OllamaConfig{Host: "http://ollama:11434"},
+		},
+	}
+}
+
+func newTestAgent(name, modelConfig string) *v1alpha1.Agent {
+	return &v1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Generation: 1},
+		Spec: v1alpha1.AgentSpec{
+			Description:   "The " + name + " agent",
+			SystemMessage: "You are " + name,
+			ModelConfig:   modelConfig,
+		},
+	}
+}
+
+func requestFor(obj client.Object) ctrl.Request {
+	return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}}
+}
+
+func getBackendTeam(t *testing.T, backend *autogen_fake.Server, label string) *autogen_client.Team {
+	t.Helper()
+	team, err := backend.Client().GetTeam(context.Background(), label, common.GetGlobalUserID())
+	require.NoError(t, err)
+	return team
+}
+
+func TestReconcileAgentFinalizer(t *testing.T) {
+	ctx := context.Background()
+	reconciler, kube, backend := newTestReconciler(t, newTestModelConfig("model"), newTestAgent("k8s-agent", "model"))
+	agent := &v1alpha1.Agent{}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "k8s-agent"}}
+
+	require.NoError(t, reconciler.ReconcileAutogenAgent(ctx, req))
+	require.NoError(t, kube.Get(ctx, req.NamespacedName, agent))
+	assert.Contains(t, agent.Finalizers, CleanupFinalizer)
+	assert.True(t, meta.IsStatusConditionTrue(agent.Status.Conditions, v1alpha1.AgentConditionTypeAccepted))
+	require.NotNil(t, getBackendTeam(t, backend, "k8s-agent"))
+
+	// the finalizer keeps the agent until its team was removed from the backend
+	require.NoError(t, kube.Delete(ctx, agent))
+	require.NoError(t, kube.Get(ctx, req.NamespacedName, agent))
+	require.NoError(t, reconciler.ReconcileAutogenAgent(ctx, req))
+	assert.Nil(t, getBackendTeam(t, backend, "k8s-agent"))
+	assert.True(t, k8s_errors.IsNotFound(kube.Get(ctx, req.NamespacedName, agent)))
+}
+
+func TestFetchObjKubeBeingDeleted(t *testing.T) {
+	ctx := context.Background()
+	modelConfig := newTestModelConfig("model")
+	modelConfig.Finalizers = []string{CleanupFinalizer}
+	_, kube, _ := newTestReconciler(t, modelConfig)
+	require.NoError(t, kube.Delete(ctx, modelConfig))
+
+	err := fetchObjKube(ctx, kube, &v1alpha1.ModelConfig{}, "model", testNamespace)
+	assert.True(t, k8s_errors.IsNotFound(err))
+	assert.EqualError(t, err, `modelconfigs.kagent.dev "model" not found`)
+}
+
+func TestReconcileReferenceNotFound(t *testing.T) {
+	ctx := context.Background()
+	modelConfig := newTestModelConfig("model")
+	reconciler, kube, _ := newTestReconciler(t, modelConfig, newTestAgent("k8s-agent", "model"), newTestAgent("orphan", "missing"))
+
+	err := reconciler.ReconcileAutogenAgent(ctx, requestFor(newTestAgent("orphan", "")))
+	require.Error(t, err)
+	assert.True(t, k8s_errors.IsNotFound(err))
+	orphan := &v1alpha1.Agent{}
+	require.NoError(t, kube.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "orphan"}, orphan))
+	condition := meta.FindStatusCondition(orphan.Status.Conditions, v1alpha1.AgentConditionTypeAccepted)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReferenceNotFoundReason, condition.Reason)
+
+	// deleting a model config requeues the agents using it, which lose their reference
+	require.NoError(t, reconciler.ReconcileAutogenAgent(ctx, requestFor(newTestAgent("k8s-agent", ""))))
+	require.NoError(t, reconciler.ReconcileAutogenModelConfig(ctx, requestFor(modelConfig)))
+	require.NoError(t, kube.Get(ctx, requestFor(modelConfig).NamespacedName, modelConfig))
+	assert.Contains(t, modelConfig.Finalizers, CleanupFinalizer)
+	require.NoError(t, kube.Delete(ctx, modelConfig))
+	require.NoError(t, reconciler.ReconcileAutogenModelConfig(ctx, requestFor(modelConfig)))
+	assert.True(t, k8s_errors.IsNotFound(kube.Get(ctx, requestFor(modelConfig).NamespacedName, modelConfig)))
+
+	agent := &v1alpha1.Agent{}
+	require.NoError(t, kube.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "k8s-agent"}, agent))
+	condition = meta.FindStatusCondition(agent.Status.Conditions, v1alpha1.AgentConditionTypeAccepted)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReferenceNotFoundReason, condition.Reason)
+}
+
+// objectNames returns the "<namespace>/<name>" of the objects found by a lookup, which
+// takes the results of the lookup directly and fails the test by panicking on errors.
+func objectNames[T client.Object](objs []T, err error) []string {
+	if err != nil {
+		panic(err)
+	}
+	var names []string
+	for _, obj := range objs {
+		names = append(names, obj.GetNamespace()+"/"+obj.GetName())
+	}
+	return names
+}
+
+func TestFindDependentsByIndex(t *testing.T) {
+	ctx := context.Background()
+	modelConfig := newTestModelConfig("model")
+	modelConfig.Spec.APIKeySecretRef = "api-keys"
+	otherModelConfig := newTestModelConfig("other")
+	otherModelConfig.Spec.APIKeySecrets = []v1alpha1.APIKeySecretReference{{Name: "api-keys", Key: "backup"}}
+
+	withTools := newTestAgent("with-tools", "other")
+	withTools.Spec.Tools = []*v1alpha1.Tool{
+		{Type: v1alpha1.ToolProviderType_McpServer, McpServer: &v1alpha1.McpServerTool{ToolServer: "tools"}},
+		{Type: v1alpha1.ToolProviderType_Agent, Agent: &v1alpha1.AgentTool{Ref: "k8s-agent"}},
+	}
+	// references to other namespaces are indexed with their namespace
+	elsewhere := newTestAgent("elsewhere", testNamespace+"/model")
+	elsewhere.Namespace = "other-ns"
+
+	team := &v1alpha1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: testNamespace},
+		Spec:       v1alpha1.TeamSpec{Participants: []string{"with-tools"}, ModelConfig: "other"},
+	}
+	reconciler, _, _ := newTestReconciler(t,
+		modelConfig, otherModelConfig, newTestAgent("k8s-agent", "model"), withTools, elsewhere, team)
+
+	req := func(name string) ctrl.Request {
+		return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: name}}
+	}
+
+	assert.ElementsMatch(t, []string{"kagent/k8s-agent", "other-ns/elsewhere"}, objectNames(reconciler.findAgentsUsingModel(ctx, req("model"))))
+	assert.ElementsMatch(t, []string{"kagent/k8s-agent", "other-ns/elsewhere", "kagent/with-tools"},
+		objectNames(reconciler.findAgentsUsingApiKeySecret(ctx, req("api-keys"))))
+	assert.Equal(t, []string{"kagent/with-tools"}, objectNames(reconciler.findAgentsUsingToolServer(ctx, req("tools"))))
+	assert.Equal(t, []string{"kagent/with-tools"}, objectNames(reconciler.findAgentsUsingAgentTool(ctx, req("k8s-agent"))))
+	assert.Empty(t, objectNames(reconciler.findAgentsUsingToolServer(ctx, req("missing"))))
+
+	assert.Equal(t, []string{"kagent/team"}, objectNames(reconciler.findTeamsUsingAgent(ctx, req("with-tools"))))
+	// the team uses the model config itself and through its participant only once
+	assert.Equal(t, []string{"kagent/team"}, objectNames(reconciler.findTeamsUsingModel(ctx, req("other"))))
+	assert.Empty(t, objectNames(reconciler.findTeamsUsingModel(ctx, req("model"))))
+}
+
+// countingTranslator counts the agents translated by the reconciler.
+type countingTranslator struct {
+	ApiTranslator
+	agents int
+}
+
+func (c *countingTranslator) TranslateGroupChatForAgent(ctx context.Context, agent *v1alpha1.Agent) (*autogen_client.Team, error) {
+	c.agents++
+	return c.ApiTranslator.TranslateGroupChatForAgent(ctx, agent)
+}
+
+func TestReconcileToolServerSkipsUnchangedTools(t *testing.T) {
+	ctx := context.Background()
+	toolServer := &v1alpha1.ToolServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: testNamespace, Generation: 1},
+		Spec: v1alpha1.ToolServerSpec{
+			Config: v1alpha1.ToolServerConfig{Sse: &v1alpha1.SseMcpServerConfig{URL: "http://tools:8080/sse"}},
+		},
+	}
+	agent := newTestAgent("k8s-agent", "model")
+	agent.Spec.Tools = []*v1alpha1.Tool{{
+		Type:      v1alpha1.ToolProviderType_McpServer,
+		McpServer: &v1alpha1.McpServerTool{ToolServer: "tools", ToolNames: []string{"get_pods"}},
+	}}
+	reconciler, kube, backend := newTestReconciler(t, newTestModelConfig("model"), toolServer, agent)
+	translator := &countingTranslator{ApiTranslator: reconciler.autogenTranslator}
+	reconciler.autogenTranslator = translator
+	backend.SetToolServerTools("tools", api.MCPTool{Name: "get_pods", Description: "Gets pods"})
+
+	require.NoError(t, reconciler.ReconcileAutogenToolServer(ctx, requestFor(toolServer)))
+	require.NoError(t, kube.Get(ctx, requestFor(toolServer).NamespacedName, toolServer))
+	require.Len(t, toolServer.Status.DiscoveredTools, 1)
+	toolsHash := toolServer.Status.ObservedToolsHash
+	assert.NotEmpty(t, toolsHash)
+	assert.Equal(t, 1, translator.agents)
+
+	// a periodic refresh which discovers the same tools does not touch the agents
+	require.NoError(t, reconciler.ReconcileAutogenToolServer(ctx, requestFor(toolServer)))
+	require.NoError(t, kube.Get(ctx, requestFor(toolServer).NamespacedName, toolServer))
+	assert.Equal(t, toolsHash, toolServer.Status.ObservedToolsHash)
+	assert.Equal(t, 1, translator.agents)
+
+	backend.SetToolServerTools("tools",
+		api.MCPTool{Name: "get_pods", Description: "Gets pods"},
+		api.MCPTool{Name: "get_logs", Description: "Gets logs"},
+	)
+	require.NoError(t, reconciler.ReconcileAutogenToolServer(ctx, requestFor(toolServer)))
+	require.NoError(t, kube.Get(ctx, requestFor(toolServer).NamespacedName, toolServer))
+	assert.Len(t, toolServer.Status.DiscoveredTools, 2)
+	assert.NotEqual(t, toolsHash, toolServer.Status.ObservedToolsHash)
+	assert.Equal(t, 2, translator.agents)
+}
+
+// listCountingClient counts the requests listing all teams of the backend.
+type listCountingClient struct {
+	autogen_client.Client
+	lists int
+}
+
+func (c *listCountingClient) ListTeams(ctx context.Context, userID string) ([]*autogen_client.Team, error) {
+	c.lists++
+	return c.Client.ListTeams(ctx, userID)
+}
+
+func (c *listCountingClient) GetTeam(ctx context.Context, teamLabel string, userID string) (*autogen_client.Team, error) {
+	c.lists++
+	return c.Client.GetTeam(ctx, teamLabel, userID)
+}
+
+func TestReconcileAgentSkipsUnchangedTeams(t *testing.T) {
+	ctx := context.Background()
+	agent := newTestAgent("k8s-agent", "model")
+	reconciler, kube, backend := newTestReconciler(t, newTestModelConfig("model"), agent)
+	var upserts int
+	backend.SetValidator(func(*api.Component) *autogen_client.ValidationResponse {
+		upserts++
+		return &autogen_client.ValidationResponse{IsValid: true}
+	})
+
+	teamLists := &listCountingClient{Client: reconciler.autogenClient}
+	reconciler.autogenClient = teamLists
+
+	require.NoError(t, reconciler.ReconcileAutogenAgent(ctx, requestFor(agent)))
+	require.NoError(t, kube.Get(ctx, requestFor(agent).NamespacedName, agent))
+	assert.NotEmpty(t, agent.Status.ComponentHash)
+	assert.NotZero(t, agent.Status.BackendTeamID)
+	assert.Equal(t, 1, upserts)
+
+	// an unchanged team is looked up by its ID instead of listing all teams
+	teamLists.lists = 0
+	require.NoError(t, reconciler.ReconcileAutogenAgent(ctx, requestFor(agent)))
+	assert.Equal(t, 1, upserts)
+	assert.Zero(t, teamLists.lists)
+
+	// a team lost by the backend is pushed again although its hash did not change
+	team := getBackendTeam(t, backend, "k8s-agent")
+	require.NoError(t, backend.Client().DeleteTeam(ctx, team.Id, team.UserID))
+	require.NoError(t, reconciler.ReconcileAutogenAgent(ctx, requestFor(agent)))
+	assert.Equal(t, 2, upserts)
+	assert.NotNil(t, getBackendTeam(t, backend, "k8s-agent"))
+
+	require.NoError(t, kube.Get(ctx, requestFor(agent).NamespacedName, agent))
+	agent.Spec.SystemMessage = "You are a Kubernetes expert"
+	require.NoError(t, kube.Update(ctx, agent))
+	require.NoError(t, reconciler.ReconcileAutogenAgent(ctx, requestFor(agent)))
+	assert.Equal(t, 3, upserts)
+}
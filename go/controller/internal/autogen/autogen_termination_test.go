@@ -0,0 +1,67 @@
+package autogen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestTranslateNestedTerminationCondition(t *testing.T) {
+	// stop after 10 minutes, 50k tokens or once the reviewer approves
+	condition, err := translateTerminationCondition(v1alpha1.TerminationCondition{
+		OrTermination: &v1alpha1.OrTermination{Conditions: []v1alpha1.TerminationCondition{
+			{TimeoutTermination: &v1alpha1.TimeoutTermination{Timeout: metav1.Duration{Duration: 10 * time.Minute}}},
+			{TokenUsageTermination: &v1alpha1.TokenUsageTermination{MaxTotalTokens: ptr.To(50000)}},
+			{AndTermination: &v1alpha1.AndTermination{Conditions: []v1alpha1.TerminationCondition{
+				{SourceMatchTermination: &v1alpha1.SourceMatchTermination{Sources: []string{"code-reviewer"}}},
+				{TextMentionTermination: &v1alpha1.TextMentionTermination{Text: "APPROVE"}},
+			}}},
+			{HandoffTermination: &v1alpha1.HandoffTermination{Target: "user"}},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "autogen_agentchat.base.OrTerminationCondition", condition.Provider)
+
+	or := &api.OrTerminationConfig{}
+	require.NoError(t, or.FromConfig(condition.Config))
+	require.Len(t, or.Conditions, 4)
+
+	timeout := &api.TimeoutTerminationConfig{}
+	require.NoError(t, timeout.FromConfig(or.Conditions[0].Config))
+	assert.Equal(t, "autogen_agentchat.conditions.TimeoutTermination", or.Conditions[0].Provider)
+	assert.Equal(t, 600.0, timeout.TimeoutSeconds)
+
+	tokenUsage := &api.TokenUsageTerminationConfig{}
+	require.NoError(t, tokenUsage.FromConfig(or.Conditions[1].Config))
+	assert.Equal(t, &api.TokenUsageTerminationConfig{MaxTotalToken: ptr.To(50000)}, tokenUsage)
+	assert.NotContains(t, or.Conditions[1].Config, "max_prompt_token")
+
+	and := &api.AndTerminationConfig{}
+	require.NoError(t, and.FromConfig(or.Conditions[2].Config))
+	assert.Equal(t, "autogen_agentchat.base.AndTerminationCondition", or.Conditions[2].Provider)
+	require.Len(t, and.Conditions, 2)
+	sourceMatch := &api.SourceMatchTerminationConfig{}
+	require.NoError(t, sourceMatch.FromConfig(and.Conditions[0].Config))
+	assert.Equal(t, []string{"code_reviewer"}, sourceMatch.Sources)
+
+	handoff := &api.HandoffTerminationConfig{}
+	require.NoError(t, handoff.FromConfig(or.Conditions[3].Config))
+	assert.Equal(t, "user", handoff.Target)
+
+	for _, invalid := range []v1alpha1.TerminationCondition{
+		{AndTermination: &v1alpha1.AndTermination{}},
+		{OrTermination: &v1alpha1.OrTermination{Conditions: []v1alpha1.TerminationCondition{{}}}},
+		{TimeoutTermination: &v1alpha1.TimeoutTermination{}},
+		{TokenUsageTermination: &v1alpha1.TokenUsageTermination{}},
+	} {
+		_, err := translateTerminationCondition(invalid)
+		assert.Error(t, err)
+	}
+}
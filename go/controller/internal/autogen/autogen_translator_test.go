@@ -314,13 +314,13 @@ func TestAutogenClient(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, autogenTeam)
 
-		listBefore, err := client.ListTeams(autogenTeam.UserID)
+		listBefore, err := client.ListTeams(ctx, autogenTeam.UserID)
 		require.NoError(t, err)
 
-		err = client.CreateTeam(autogenTeam)
+		err = client.CreateTeam(ctx, autogenTeam)
 		require.NoError(t, err)
 
-		list, err := client.ListTeams(autogenTeam.UserID)
+		list, err := client.ListTeams(ctx, autogenTeam.UserID)
 		require.NoError(t, err)
 		assert.NotNil(t, list)
 		assert.Equal(t, len(listBefore)+1, len(list))
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/kagent-dev/kagent/go/controller/internal/a2a"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	agentv1alpha1 "github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+// A2AAgentReconciler keeps the A2A routing table of this replica in sync with the Agents in the cluster.
+// Unlike the other reconcilers it runs on every replica, since every replica serves A2A requests.
+type A2AAgentReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	Reconciler a2a.A2AReconciler
+}
+
+// +kubebuilder:rbac:groups=kagent.dev,resources=agents,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+
+func (r *A2AAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = log.FromContext(ctx)
+
+	agent := &agentv1alpha1.Agent{}
+	if err := r.Get(ctx, req.NamespacedName, agent); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return ctrl.Result{}, r.Reconciler.ReconcileAutogenAgentDeletion(ctx, req.Namespace, req.Name)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !agent.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.Reconciler.ReconcileAutogenAgentDeletion(ctx, req.Namespace, req.Name)
+	}
+
+	return ctrl.Result{}, r.Reconciler.ReconcileAutogenAgent(ctx, agent)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *A2AAgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&agentv1alpha1.Agent{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			deletionPredicate(),
+		))).
+		WithOptions(controller.Options{
+			NeedLeaderElection: common.MakePtr(false),
+		}).
+		Named("a2aagent").
+		Complete(r)
+}
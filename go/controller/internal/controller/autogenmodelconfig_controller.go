@@ -21,10 +21,13 @@ import (
 
 	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	agentv1alpha1 "github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
 )
@@ -39,6 +42,8 @@ type AutogenModelConfigReconciler struct {
 // +kubebuilder:rbac:groups=kagent.dev,resources=modelconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kagent.dev,resources=modelconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kagent.dev,resources=modelconfigs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 
 func (r *AutogenModelConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
@@ -49,6 +54,46 @@ func (r *AutogenModelConfigReconciler) Reconcile(ctx context.Context, req ctrl.R
 func (r *AutogenModelConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&agentv1alpha1.ModelConfig{}).
+		// the ConfigMap cache excludes the A2A task ConfigMaps, see main.go
+		Watches(&v1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.findModelsUsingMockScript)).
+		Watches(&v1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findModelsUsingApiKeySecret)).
+		Watches(&agentv1alpha1.ModelConfig{}, handler.EnqueueRequestsFromMapFunc(r.findModelsUsingFallback)).
 		Named("autogenmodelconfig").
 		Complete(r)
 }
+
+// findModelsUsingMockScript requeues the Mock model configs whose script is in the ConfigMap,
+// so that agents pick up changes of the script.
+func (r *AutogenModelConfigReconciler) findModelsUsingMockScript(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.findModelsByIndex(ctx, autogen.ModelConfigMockScriptIndex, obj)
+}
+
+// findModelsUsingApiKeySecret requeues the model configs which use an API key of the secret,
+// so that their agents pick up changed API keys.
+func (r *AutogenModelConfigReconciler) findModelsUsingApiKeySecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.findModelsByIndex(ctx, autogen.ModelConfigApiKeySecretIndex, obj)
+}
+
+// findModelsUsingFallback requeues the model configs which fall back to the model config,
+// so that their agents pick up its changes.
+func (r *AutogenModelConfigReconciler) findModelsUsingFallback(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.findModelsByIndex(ctx, autogen.ModelConfigFallbackIndex, obj)
+}
+
+func (r *AutogenModelConfigReconciler) findModelsByIndex(ctx context.Context, index string, obj client.Object) []reconcile.Request {
+	var modelsList agentv1alpha1.ModelConfigList
+	if err := r.List(
+		ctx,
+		&modelsList,
+		client.MatchingFields{index: client.ObjectKeyFromObject(obj).String()},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list model configs", "index", index, "ref", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(modelsList.Items))
+	for _, model := range modelsList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&model)})
+	}
+	return requests
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	agentv1alpha1 "github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+// RemoteAgentReconciler reconciles a RemoteAgent object
+type RemoteAgentReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	Reconciler autogen.AutogenReconciler
+}
+
+// +kubebuilder:rbac:groups=kagent.dev,resources=remoteagents,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kagent.dev,resources=remoteagents/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kagent.dev,resources=remoteagents/finalizers,verbs=update
+
+// DefaultRemoteAgentRefreshInterval is used when a RemoteAgent does not set a refresh interval.
+const DefaultRemoteAgentRefreshInterval = 5 * time.Minute
+
+func (r *RemoteAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = log.FromContext(ctx)
+
+	// failures are requeued with the controller's exponential backoff
+	if err := r.Reconciler.ReconcileAutogenRemoteAgent(ctx, req); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	remoteAgent := &agentv1alpha1.RemoteAgent{}
+	if err := r.Get(ctx, req.NamespacedName, remoteAgent); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !remoteAgent.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// requeue to periodically refresh the agent card
+	refreshInterval := DefaultRemoteAgentRefreshInterval
+	if remoteAgent.Spec.RefreshInterval != nil {
+		refreshInterval = remoteAgent.Spec.RefreshInterval.Duration
+	}
+
+	return ctrl.Result{RequeueAfter: refreshInterval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RemoteAgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// status updates must not trigger another fetch
+		For(&agentv1alpha1.RemoteAgent{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			deletionPredicate(),
+		))).
+		Named("remoteagent").
+		Complete(r)
+}
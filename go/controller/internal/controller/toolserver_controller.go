@@ -24,8 +24,11 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	agentv1alpha1 "github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
 )
@@ -37,24 +40,58 @@ type ToolServerReconciler struct {
 	Reconciler autogen.AutogenReconciler
 }
 
-// +kubebuilder:rbac:groups=agent.kagent.dev,resources=toolservers,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=agent.kagent.dev,resources=toolservers/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=agent.kagent.dev,resources=toolservers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=kagent.dev,resources=toolservers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kagent.dev,resources=toolservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kagent.dev,resources=toolservers/finalizers,verbs=update
+
+// DefaultToolServerRefreshInterval is used when a ToolServer does not set a refresh interval.
+const DefaultToolServerRefreshInterval = 60 * time.Second
 
 func (r *ToolServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
 
-	return ctrl.Result{
-		// loop forever because we need to refresh tools server status
-		Requeue:      true,
-		RequeueAfter: 60 * time.Second,
-	}, r.Reconciler.ReconcileAutogenToolServer(ctx, req)
+	// failures are requeued with the controller's exponential backoff
+	if err := r.Reconciler.ReconcileAutogenToolServer(ctx, req); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	toolServer := &agentv1alpha1.ToolServer{}
+	if err := r.Get(ctx, req.NamespacedName, toolServer); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !toolServer.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// requeue to periodically refresh the discovered tools
+	refreshInterval := DefaultToolServerRefreshInterval
+	if toolServer.Spec.RefreshInterval != nil {
+		refreshInterval = toolServer.Spec.RefreshInterval.Duration
+	}
+
+	return ctrl.Result{RequeueAfter: refreshInterval}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ToolServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&agentv1alpha1.ToolServer{}).
+		// status updates must not trigger another discovery
+		For(&agentv1alpha1.ToolServer{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			deletionPredicate(),
+		))).
 		Named("toolserver").
 		Complete(r)
 }
+
+// deletionPredicate passes updates which mark an object for deletion.
+func deletionPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetDeletionTimestamp().IsZero() && !e.ObjectNew.GetDeletionTimestamp().IsZero()
+		},
+	}
+}
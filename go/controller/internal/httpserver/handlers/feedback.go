@@ -49,7 +49,7 @@ func (h *FeedbackHandler) HandleCreateFeedback(w ErrorResponseWriter, r *http.Re
 		return
 	}
 
-	err = h.AutogenClient.CreateFeedback(&feedbackReq)
+	err = h.AutogenClient.CreateFeedback(r.Context(), &feedbackReq)
 	if err != nil {
 		log.Error(err, "Failed to create feedback")
 		w.RespondWithError(errors.NewInternalServerError("Failed to create feedback", err))
@@ -72,7 +72,7 @@ func (h *FeedbackHandler) HandleListFeedback(w ErrorResponseWriter, r *http.Requ
 		return
 	}
 
-	feedback, err := h.AutogenClient.ListFeedback(userID)
+	feedback, err := h.AutogenClient.ListFeedback(r.Context(), userID)
 	if err != nil {
 		log.Error(err, "Failed to list feedback")
 		w.RespondWithError(errors.NewInternalServerError("Failed to list feedback", err))
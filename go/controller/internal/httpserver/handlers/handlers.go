@@ -9,17 +9,18 @@ import (
 
 // Handlers holds all the HTTP handler components
 type Handlers struct {
-	Health      *HealthHandler
-	ModelConfig *ModelConfigHandler
-	Model       *ModelHandler
-	Provider    *ProviderHandler
-	Sessions    *SessionsHandler
-	Teams       *TeamsHandler
-	Tools       *ToolsHandler
-	ToolServers *ToolServersHandler
-	Invoke      *InvokeHandler
-	Memory      *MemoryHandler
-	Feedback    *FeedbackHandler
+	Health       *HealthHandler
+	ModelConfig  *ModelConfigHandler
+	Model        *ModelHandler
+	Provider     *ProviderHandler
+	Sessions     *SessionsHandler
+	Teams        *TeamsHandler
+	Tools        *ToolsHandler
+	ToolServers  *ToolServersHandler
+	Invoke       *InvokeHandler
+	Memory       *MemoryHandler
+	Feedback     *FeedbackHandler
+	RemoteAgents *RemoteAgentsHandler
 }
 
 // Base holds common dependencies for all handlers
@@ -38,16 +39,17 @@ func NewHandlers(kubeClient client.Client, autogenClient autogen_client.Client,
 	}
 
 	return &Handlers{
-		Health:      NewHealthHandler(),
-		ModelConfig: NewModelConfigHandler(base),
-		Model:       NewModelHandler(base),
-		Provider:    NewProviderHandler(base),
-		Sessions:    NewSessionsHandler(base),
-		Teams:       NewTeamsHandler(base),
-		Tools:       NewToolsHandler(base),
-		ToolServers: NewToolServersHandler(base),
-		Invoke:      NewInvokeHandler(base),
-		Memory:      NewMemoryHandler(base),
-		Feedback:    NewFeedbackHandler(base),
+		Health:       NewHealthHandler(),
+		ModelConfig:  NewModelConfigHandler(base),
+		Model:        NewModelHandler(base),
+		Provider:     NewProviderHandler(base),
+		Sessions:     NewSessionsHandler(base),
+		Teams:        NewTeamsHandler(base),
+		Tools:        NewToolsHandler(base),
+		ToolServers:  NewToolServersHandler(base),
+		Invoke:       NewInvokeHandler(base),
+		Memory:       NewMemoryHandler(base),
+		Feedback:     NewFeedbackHandler(base),
+		RemoteAgents: NewRemoteAgentsHandler(base),
 	}
 }
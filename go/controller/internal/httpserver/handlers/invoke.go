@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"fmt"
 	"net/http"
 	"strconv"
 
@@ -55,13 +54,13 @@ func (h *InvokeHandler) HandleInvokeAgent(w ErrorResponseWriter, r *http.Request
 		return
 	}
 
-	team, err := h.AutogenClient.GetTeamByID(agentID, req.UserID)
+	team, err := h.AutogenClient.GetTeamByID(r.Context(), agentID, req.UserID)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to get team", err))
 		return
 	}
 
-	result, err := h.AutogenClient.InvokeTask(&autogen_client.InvokeTaskRequest{
+	result, err := h.AutogenClient.InvokeTask(r.Context(), &autogen_client.InvokeTaskRequest{
 		Task:       req.Message,
 		TeamConfig: team.Component,
 	})
@@ -86,13 +85,13 @@ func (h *InvokeHandler) HandleInvokeAgentStream(w ErrorResponseWriter, r *http.R
 		return
 	}
 
-	team, err := h.AutogenClient.GetTeamByID(agentID, req.UserID)
+	team, err := h.AutogenClient.GetTeamByID(r.Context(), agentID, req.UserID)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to get team", err))
 		return
 	}
 
-	ch, err := h.AutogenClient.InvokeTaskStream(&autogen_client.InvokeTaskRequest{
+	ch, err := h.AutogenClient.InvokeTaskStream(r.Context(), &autogen_client.InvokeTaskRequest{
 		Task:       req.Message,
 		TeamConfig: team.Component,
 	})
@@ -108,7 +107,7 @@ func (h *InvokeHandler) HandleInvokeAgentStream(w ErrorResponseWriter, r *http.R
 	w.WriteHeader(http.StatusOK)
 
 	for event := range ch {
-		w.Write([]byte(fmt.Sprintf("event: %s\ndata: %s\n\n", event.Event, event.Data)))
+		w.Write(event.Encode())
 	}
 }
 
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 
@@ -37,150 +38,150 @@ type mockAutogenClient struct {
 	invokeTaskFunc    func(*autogen_client.InvokeTaskRequest) (*autogen_client.InvokeTaskResult, error)
 }
 
-func (m *mockAutogenClient) CreateSession(req *autogen_client.CreateSession) (*autogen_client.Session, error) {
+func (m *mockAutogenClient) CreateSession(ctx context.Context, req *autogen_client.CreateSession) (*autogen_client.Session, error) {
 	return m.createSessionFunc(req)
 }
 
-func (m *mockAutogenClient) CreateRun(req *autogen_client.CreateRunRequest) (*autogen_client.CreateRunResult, error) {
+func (m *mockAutogenClient) CreateRun(ctx context.Context, req *autogen_client.CreateRunRequest) (*autogen_client.CreateRunResult, error) {
 	return m.createRunFunc(req)
 }
 
-func (m *mockAutogenClient) GetTeamByID(teamID int, userID string) (*autogen_client.Team, error) {
+func (m *mockAutogenClient) GetTeamByID(ctx context.Context, teamID int, userID string) (*autogen_client.Team, error) {
 	return m.getTeamByIDFunc(teamID, userID)
 }
 
-func (m *mockAutogenClient) CreateFeedback(feedback *autogen_client.FeedbackSubmission) error {
+func (m *mockAutogenClient) CreateFeedback(ctx context.Context, feedback *autogen_client.FeedbackSubmission) error {
 	return nil
 }
 
-func (m *mockAutogenClient) CreateTeam(team *autogen_client.Team) error {
+func (m *mockAutogenClient) CreateTeam(ctx context.Context, team *autogen_client.Team) error {
 	return nil
 }
 
-func (m *mockAutogenClient) CreateToolServer(toolServer *autogen_client.ToolServer, userID string) (*autogen_client.ToolServer, error) {
+func (m *mockAutogenClient) CreateToolServer(ctx context.Context, toolServer *autogen_client.ToolServer, userID string) (*autogen_client.ToolServer, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) DeleteRun(runID uuid.UUID) error {
+func (m *mockAutogenClient) DeleteRun(ctx context.Context, runID uuid.UUID) error {
 	return nil
 }
 
-func (m *mockAutogenClient) DeleteSession(sessionID int, userID string) error {
+func (m *mockAutogenClient) DeleteSession(ctx context.Context, sessionID int, userID string) error {
 	return nil
 }
 
-func (m *mockAutogenClient) DeleteTeam(teamID int, userID string) error {
+func (m *mockAutogenClient) DeleteTeam(ctx context.Context, teamID int, userID string) error {
 	return nil
 }
 
-func (m *mockAutogenClient) DeleteToolServer(serverID *int, userID string) error {
+func (m *mockAutogenClient) DeleteToolServer(ctx context.Context, serverID *int, userID string) error {
 	return nil
 }
 
-func (m *mockAutogenClient) GetRun(runID int) (*autogen_client.Run, error) {
+func (m *mockAutogenClient) GetRun(ctx context.Context, runID int) (*autogen_client.Run, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) GetRunMessages(runID uuid.UUID) ([]*autogen_client.RunMessage, error) {
+func (m *mockAutogenClient) GetRunMessages(ctx context.Context, runID uuid.UUID) ([]*autogen_client.RunMessage, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) GetSession(sessionLabel string, userID string) (*autogen_client.Session, error) {
+func (m *mockAutogenClient) GetSession(ctx context.Context, sessionLabel string, userID string) (*autogen_client.Session, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) GetSessionById(sessionID int, userID string) (*autogen_client.Session, error) {
+func (m *mockAutogenClient) GetSessionById(ctx context.Context, sessionID int, userID string) (*autogen_client.Session, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) GetTeam(teamLabel string, userID string) (*autogen_client.Team, error) {
+func (m *mockAutogenClient) GetTeam(ctx context.Context, teamLabel string, userID string) (*autogen_client.Team, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) GetTool(provider string, userID string) (*autogen_client.Tool, error) {
+func (m *mockAutogenClient) GetTool(ctx context.Context, provider string, userID string) (*autogen_client.Tool, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) GetToolServer(serverID int, userID string) (*autogen_client.ToolServer, error) {
+func (m *mockAutogenClient) GetToolServer(ctx context.Context, serverID int, userID string) (*autogen_client.ToolServer, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) GetToolServerByLabel(toolServerLabel string, userID string) (*autogen_client.ToolServer, error) {
+func (m *mockAutogenClient) GetToolServerByLabel(ctx context.Context, toolServerLabel string, userID string) (*autogen_client.ToolServer, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) GetVersion() (string, error) {
+func (m *mockAutogenClient) GetVersion(ctx context.Context) (string, error) {
 	return "", nil
 }
 
-func (m *mockAutogenClient) InvokeSession(sessionID int, userID string, task string) (*autogen_client.TeamResult, error) {
+func (m *mockAutogenClient) InvokeSession(ctx context.Context, sessionID int, userID string, task string) (*autogen_client.TeamResult, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) InvokeSessionStream(sessionID int, userID string, task string) (<-chan *autogen_client.SseEvent, error) {
+func (m *mockAutogenClient) InvokeSessionStream(ctx context.Context, sessionID int, userID string, task string) (<-chan *autogen_client.SseEvent, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) InvokeTask(req *autogen_client.InvokeTaskRequest) (*autogen_client.InvokeTaskResult, error) {
+func (m *mockAutogenClient) InvokeTask(ctx context.Context, req *autogen_client.InvokeTaskRequest) (*autogen_client.InvokeTaskResult, error) {
 	return m.invokeTaskFunc(req)
 }
 
-func (m *mockAutogenClient) InvokeTaskStream(req *autogen_client.InvokeTaskRequest) (<-chan *autogen_client.SseEvent, error) {
+func (m *mockAutogenClient) InvokeTaskStream(ctx context.Context, req *autogen_client.InvokeTaskRequest) (<-chan *autogen_client.SseEvent, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) ListFeedback(userID string) ([]*autogen_client.FeedbackSubmission, error) {
+func (m *mockAutogenClient) ListFeedback(ctx context.Context, userID string) ([]*autogen_client.FeedbackSubmission, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) ListRuns(userID string) ([]*autogen_client.Run, error) {
+func (m *mockAutogenClient) ListRuns(ctx context.Context, userID string) ([]*autogen_client.Run, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) ListSessionRuns(sessionID int, userID string) ([]*autogen_client.Run, error) {
+func (m *mockAutogenClient) ListSessionRuns(ctx context.Context, sessionID int, userID string) ([]*autogen_client.Run, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) ListSessions(userID string) ([]*autogen_client.Session, error) {
+func (m *mockAutogenClient) ListSessions(ctx context.Context, userID string) ([]*autogen_client.Session, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) ListSupportedModels() (*autogen_client.ProviderModels, error) {
+func (m *mockAutogenClient) ListSupportedModels(ctx context.Context) (*autogen_client.ProviderModels, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) ListTeams(userID string) ([]*autogen_client.Team, error) {
+func (m *mockAutogenClient) ListTeams(ctx context.Context, userID string) ([]*autogen_client.Team, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) ListToolServers(userID string) ([]*autogen_client.ToolServer, error) {
+func (m *mockAutogenClient) ListToolServers(ctx context.Context, userID string) ([]*autogen_client.ToolServer, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) ListTools(userID string) ([]*autogen_client.Tool, error) {
+func (m *mockAutogenClient) ListTools(ctx context.Context, userID string) ([]*autogen_client.Tool, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) ListToolsForServer(serverID *int, userID string) ([]*autogen_client.Tool, error) {
+func (m *mockAutogenClient) ListToolsForServer(ctx context.Context, serverID *int, userID string) ([]*autogen_client.Tool, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) RefreshToolServer(serverID int, userID string) error {
+func (m *mockAutogenClient) RefreshToolServer(ctx context.Context, serverID int, userID string) error {
 	return nil
 }
 
-func (m *mockAutogenClient) RefreshTools(serverID *int, userID string) error {
+func (m *mockAutogenClient) RefreshTools(ctx context.Context, serverID *int, userID string) error {
 	return nil
 }
 
-func (m *mockAutogenClient) UpdateSession(sessionID int, userID string, session *autogen_client.Session) (*autogen_client.Session, error) {
+func (m *mockAutogenClient) UpdateSession(ctx context.Context, sessionID int, userID string, session *autogen_client.Session) (*autogen_client.Session, error) {
 	return nil, nil
 }
 
-func (m *mockAutogenClient) UpdateToolServer(server *autogen_client.ToolServer, userID string) error {
+func (m *mockAutogenClient) UpdateToolServer(ctx context.Context, server *autogen_client.ToolServer, userID string) error {
 	return nil
 }
 
-func (m *mockAutogenClient) Validate(req *autogen_client.ValidationRequest) (*autogen_client.ValidationResponse, error) {
+func (m *mockAutogenClient) Validate(ctx context.Context, req *autogen_client.ValidationRequest) (*autogen_client.ValidationResponse, error) {
 	return nil, nil
 }
@@ -65,6 +65,15 @@ func (h *ModelConfigHandler) HandleListModelConfigs(w ErrorResponseWriter, r *ht
 		if config.Spec.Ollama != nil {
 			FlattenStructToMap(config.Spec.Ollama, modelParams)
 		}
+		if config.Spec.Gemini != nil {
+			FlattenStructToMap(config.Spec.Gemini, modelParams)
+		}
+		if config.Spec.OpenAICompatible != nil {
+			FlattenStructToMap(config.Spec.OpenAICompatible, modelParams)
+		}
+		if config.Spec.Mock != nil {
+			FlattenStructToMap(config.Spec.Mock, modelParams)
+		}
 
 		responseItem := ModelConfigResponse{
 			Name:            config.Name,
@@ -123,6 +132,15 @@ func (h *ModelConfigHandler) HandleGetModelConfig(w ErrorResponseWriter, r *http
 	if modelConfig.Spec.Ollama != nil {
 		FlattenStructToMap(modelConfig.Spec.Ollama, modelParams)
 	}
+	if modelConfig.Spec.Gemini != nil {
+		FlattenStructToMap(modelConfig.Spec.Gemini, modelParams)
+	}
+	if modelConfig.Spec.OpenAICompatible != nil {
+		FlattenStructToMap(modelConfig.Spec.OpenAICompatible, modelParams)
+	}
+	if modelConfig.Spec.Mock != nil {
+		FlattenStructToMap(modelConfig.Spec.Mock, modelParams)
+	}
 
 	responseItem := ModelConfigResponse{
 		Name:            modelConfig.Name,
@@ -156,14 +174,17 @@ func getStructJSONKeys(structType reflect.Type) []string {
 }
 
 type CreateModelConfigRequest struct {
-	Name            string                      `json:"name"`
-	Provider        Provider                    `json:"provider"`
-	Model           string                      `json:"model"`
-	APIKey          string                      `json:"apiKey"`
-	OpenAIParams    *v1alpha1.OpenAIConfig      `json:"openAI,omitempty"`
-	AnthropicParams *v1alpha1.AnthropicConfig   `json:"anthropic,omitempty"`
-	AzureParams     *v1alpha1.AzureOpenAIConfig `json:"azureOpenAI,omitempty"`
-	OllamaParams    *v1alpha1.OllamaConfig      `json:"ollama,omitempty"`
+	Name                   string                           `json:"name"`
+	Provider               Provider                         `json:"provider"`
+	Model                  string                           `json:"model"`
+	APIKey                 string                           `json:"apiKey"`
+	OpenAIParams           *v1alpha1.OpenAIConfig           `json:"openAI,omitempty"`
+	AnthropicParams        *v1alpha1.AnthropicConfig        `json:"anthropic,omitempty"`
+	AzureParams            *v1alpha1.AzureOpenAIConfig      `json:"azureOpenAI,omitempty"`
+	OllamaParams           *v1alpha1.OllamaConfig           `json:"ollama,omitempty"`
+	GeminiParams           *v1alpha1.GeminiConfig           `json:"gemini,omitempty"`
+	OpenAICompatibleParams *v1alpha1.OpenAICompatibleConfig `json:"openAICompatible,omitempty"`
+	MockParams             *v1alpha1.MockConfig             `json:"mock,omitempty"`
 }
 
 type Provider struct {
@@ -269,6 +290,27 @@ func (h *ModelConfigHandler) HandleCreateModelConfig(w ErrorResponseWriter, r *h
 		} else {
 			log.V(1).Info("No Ollama params provided in create.")
 		}
+	case v1alpha1.Gemini:
+		if req.GeminiParams != nil {
+			modelConfig.Spec.Gemini = req.GeminiParams
+			log.V(1).Info("Assigned Gemini params to spec")
+		} else {
+			log.V(1).Info("No Gemini params provided in create.")
+		}
+	case v1alpha1.OpenAICompatible:
+		if req.OpenAICompatibleParams == nil || req.OpenAICompatibleParams.BaseURL == "" {
+			providerConfigErr = fmt.Errorf("missing required OpenAICompatible parameters: baseUrl")
+		} else {
+			modelConfig.Spec.OpenAICompatible = req.OpenAICompatibleParams
+			log.V(1).Info("Assigned OpenAICompatible params to spec")
+		}
+	case v1alpha1.Mock:
+		if req.MockParams == nil || req.MockParams.ScriptConfigMapRef == "" {
+			providerConfigErr = fmt.Errorf("missing required Mock parameters: scriptConfigMapRef")
+		} else {
+			modelConfig.Spec.Mock = req.MockParams
+			log.V(1).Info("Assigned Mock params to spec")
+		}
 	default:
 		providerConfigErr = fmt.Errorf("unsupported provider type: %s", req.Provider.Type)
 	}
@@ -306,13 +348,16 @@ func (h *ModelConfigHandler) HandleCreateModelConfig(w ErrorResponseWriter, r *h
 // UpdateModelConfigRequest defines the structure for updating a model config.
 // It's similar to Create, but APIKey is optional.
 type UpdateModelConfigRequest struct {
-	Provider        Provider                    `json:"provider"`
-	Model           string                      `json:"model"`
-	APIKey          *string                     `json:"apiKey,omitempty"`
-	OpenAIParams    *v1alpha1.OpenAIConfig      `json:"openAI,omitempty"`
-	AnthropicParams *v1alpha1.AnthropicConfig   `json:"anthropic,omitempty"`
-	AzureParams     *v1alpha1.AzureOpenAIConfig `json:"azureOpenAI,omitempty"`
-	OllamaParams    *v1alpha1.OllamaConfig      `json:"ollama,omitempty"`
+	Provider               Provider                         `json:"provider"`
+	Model                  string                           `json:"model"`
+	APIKey                 *string                          `json:"apiKey,omitempty"`
+	OpenAIParams           *v1alpha1.OpenAIConfig           `json:"openAI,omitempty"`
+	AnthropicParams        *v1alpha1.AnthropicConfig        `json:"anthropic,omitempty"`
+	AzureParams            *v1alpha1.AzureOpenAIConfig      `json:"azureOpenAI,omitempty"`
+	OllamaParams           *v1alpha1.OllamaConfig           `json:"ollama,omitempty"`
+	GeminiParams           *v1alpha1.GeminiConfig           `json:"gemini,omitempty"`
+	OpenAICompatibleParams *v1alpha1.OpenAICompatibleConfig `json:"openAICompatible,omitempty"`
+	MockParams             *v1alpha1.MockConfig             `json:"mock,omitempty"`
 }
 
 func (h *ModelConfigHandler) HandleUpdateModelConfig(w ErrorResponseWriter, r *http.Request) {
@@ -352,12 +397,15 @@ func (h *ModelConfigHandler) HandleUpdateModelConfig(w ErrorResponseWriter, r *h
 	}
 
 	modelConfig.Spec = v1alpha1.ModelConfigSpec{
-		Model:       req.Model,
-		Provider:    v1alpha1.ModelProvider(req.Provider.Type),
-		OpenAI:      nil,
-		Anthropic:   nil,
-		AzureOpenAI: nil,
-		Ollama:      nil,
+		Model:            req.Model,
+		Provider:         v1alpha1.ModelProvider(req.Provider.Type),
+		OpenAI:           nil,
+		Anthropic:        nil,
+		AzureOpenAI:      nil,
+		Ollama:           nil,
+		Gemini:           nil,
+		OpenAICompatible: nil,
+		Mock:             nil,
 	}
 
 	// --- Update Secret if API Key is provided (and not Ollama or using AI API Gateway) ---
@@ -440,6 +488,27 @@ func (h *ModelConfigHandler) HandleUpdateModelConfig(w ErrorResponseWriter, r *h
 		} else {
 			log.V(1).Info("No Ollama params provided in update.")
 		}
+	case v1alpha1.Gemini:
+		if req.GeminiParams != nil {
+			modelConfig.Spec.Gemini = req.GeminiParams
+			log.V(1).Info("Assigned updated Gemini params to spec")
+		} else {
+			log.V(1).Info("No Gemini params provided in update.")
+		}
+	case v1alpha1.OpenAICompatible:
+		if req.OpenAICompatibleParams == nil || req.OpenAICompatibleParams.BaseURL == "" {
+			providerConfigErr = fmt.Errorf("missing required OpenAICompatible parameters: baseUrl")
+		} else {
+			modelConfig.Spec.OpenAICompatible = req.OpenAICompatibleParams
+			log.V(1).Info("Assigned updated OpenAICompatible params to spec")
+		}
+	case v1alpha1.Mock:
+		if req.MockParams == nil || req.MockParams.ScriptConfigMapRef == "" {
+			providerConfigErr = fmt.Errorf("missing required Mock parameters: scriptConfigMapRef")
+		} else {
+			modelConfig.Spec.Mock = req.MockParams
+			log.V(1).Info("Assigned updated Mock params to spec")
+		}
 	default:
 		providerConfigErr = fmt.Errorf("unsupported provider type specified: %s", req.Provider.Type)
 	}
@@ -466,6 +535,12 @@ func (h *ModelConfigHandler) HandleUpdateModelConfig(w ErrorResponseWriter, r *h
 		FlattenStructToMap(modelConfig.Spec.AzureOpenAI, updatedParams)
 	} else if modelConfig.Spec.Ollama != nil {
 		FlattenStructToMap(modelConfig.Spec.Ollama, updatedParams)
+	} else if modelConfig.Spec.Gemini != nil {
+		FlattenStructToMap(modelConfig.Spec.Gemini, updatedParams)
+	} else if modelConfig.Spec.OpenAICompatible != nil {
+		FlattenStructToMap(modelConfig.Spec.OpenAICompatible, updatedParams)
+	} else if modelConfig.Spec.Mock != nil {
+		FlattenStructToMap(modelConfig.Spec.Mock, updatedParams)
 	}
 
 	responseItem := ModelConfigResponse{
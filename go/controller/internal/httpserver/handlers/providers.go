@@ -24,7 +24,11 @@ func getRequiredKeysForModelProvider(providerType v1alpha1.ModelProvider) []stri
 	case v1alpha1.AzureOpenAI:
 		// Based on the +required comments in the AzureOpenAIConfig struct definition
 		return []string{"azureEndpoint", "apiVersion"}
-	case v1alpha1.OpenAI, v1alpha1.Anthropic, v1alpha1.Ollama:
+	case v1alpha1.OpenAICompatible:
+		return []string{"baseUrl"}
+	case v1alpha1.Mock:
+		return []string{"scriptConfigMapRef"}
+	case v1alpha1.OpenAI, v1alpha1.Anthropic, v1alpha1.Ollama, v1alpha1.Gemini:
 		// These providers currently have no fields marked as strictly required in the API definition
 		return []string{}
 	default:
@@ -95,6 +99,9 @@ func (h *ProviderHandler) HandleListSupportedModelProviders(w ErrorResponseWrite
 		{v1alpha1.Anthropic, reflect.TypeOf(v1alpha1.AnthropicConfig{})},
 		{v1alpha1.AzureOpenAI, reflect.TypeOf(v1alpha1.AzureOpenAIConfig{})},
 		{v1alpha1.Ollama, reflect.TypeOf(v1alpha1.OllamaConfig{})},
+		{v1alpha1.Gemini, reflect.TypeOf(v1alpha1.GeminiConfig{})},
+		{v1alpha1.OpenAICompatible, reflect.TypeOf(v1alpha1.OpenAICompatibleConfig{})},
+		{v1alpha1.Mock, reflect.TypeOf(v1alpha1.MockConfig{})},
 	}
 
 	providersResponse := []map[string]interface{}{}
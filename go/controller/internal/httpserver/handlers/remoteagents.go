@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/a2a"
+	"github.com/kagent-dev/kagent/go/controller/internal/httpserver/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// RemoteAgentsHandler handles remote agent-related requests
+type RemoteAgentsHandler struct {
+	*Base
+	client *a2a.RemoteAgentClient
+}
+
+// NewRemoteAgentsHandler creates a new RemoteAgentsHandler
+func NewRemoteAgentsHandler(base *Base) *RemoteAgentsHandler {
+	return &RemoteAgentsHandler{
+		Base:   base,
+		client: a2a.NewRemoteAgentClient(base.KubeClient),
+	}
+}
+
+// RemoteAgentInvokeRequest is sent by the remote agent tool of an agent.
+type RemoteAgentInvokeRequest struct {
+	Task string `json:"task"`
+}
+
+// RemoteAgentInvokeResponse contains the text produced by the remote agent.
+type RemoteAgentInvokeResponse struct {
+	Result string `json:"result"`
+}
+
+// HandleListRemoteAgents handles GET /api/remoteagents requests
+func (h *RemoteAgentsHandler) HandleListRemoteAgents(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("remoteagents-handler").WithValues("operation", "list")
+
+	log.V(1).Info("Listing remote agents from Kubernetes")
+	remoteAgentList := &v1alpha1.RemoteAgentList{}
+	if err := h.KubeClient.List(r.Context(), remoteAgentList); err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to list remote agents from Kubernetes", err))
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, remoteAgentList.Items)
+}
+
+// HandleInvokeRemoteAgent handles POST /api/remoteagents/{namespace}/{remoteAgentName}/invoke requests.
+// It sends the task to the remote agent and waits for the result, so that agents can use remote agents as tools.
+// The server only accepts these requests from the agent runtime in the pod of the controller.
+func (h *RemoteAgentsHandler) HandleInvokeRemoteAgent(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("remoteagents-handler").WithValues("operation", "invoke")
+
+	namespace, err := GetPathParam(r, "namespace")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get namespace from path", err))
+		return
+	}
+	remoteAgentName, err := GetPathParam(r, "remoteAgentName")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get remote agent name from path", err))
+		return
+	}
+	log = log.WithValues("namespace", namespace, "remoteAgentName", remoteAgentName)
+
+	var invokeRequest RemoteAgentInvokeRequest
+	if err := DecodeJSONBody(r, &invokeRequest); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		return
+	}
+
+	remoteAgent := &v1alpha1.RemoteAgent{}
+	if err := h.KubeClient.Get(r.Context(), types.NamespacedName{
+		Name:      remoteAgentName,
+		Namespace: namespace,
+	}, remoteAgent); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info("Remote agent not found")
+			w.RespondWithError(errors.NewNotFoundError("Remote agent not found", nil))
+			return
+		}
+		log.Error(err, "Failed to get remote agent")
+		w.RespondWithError(errors.NewInternalServerError("Failed to get remote agent", err))
+		return
+	}
+
+	log.V(1).Info("Sending task to remote agent", "url", remoteAgent.Spec.URL)
+	result, err := h.client.SendTask(r.Context(), remoteAgent, invokeRequest.Task)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to invoke remote agent", err))
+		return
+	}
+
+	log.Info("Successfully invoked remote agent")
+	RespondWithJSON(w, http.StatusOK, RemoteAgentInvokeResponse{Result: result})
+}
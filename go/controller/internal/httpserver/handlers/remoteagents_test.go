@@ -0,0 +1,53 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/httpserver/handlers"
+)
+
+func TestInvokeRemoteAgentErrors(t *testing.T) {
+	require.NoError(t, v1alpha1.AddToScheme(scheme.Scheme))
+
+	invoke := func(kube client.Client) *mockErrorResponseWriter {
+		handler := handlers.NewRemoteAgentsHandler(&handlers.Base{KubeClient: kube})
+		responseRecorder := newMockErrorResponseWriter()
+		req := httptest.NewRequest("POST", "/api/remoteagents/kagent/weather/invoke", bytes.NewBufferString(`{"task":"weather in Paris?"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		router := mux.NewRouter()
+		router.HandleFunc("/api/remoteagents/{namespace}/{remoteAgentName}/invoke", func(w http.ResponseWriter, r *http.Request) {
+			handler.HandleInvokeRemoteAgent(responseRecorder, r)
+		}).Methods("POST")
+		router.ServeHTTP(responseRecorder, req)
+		return responseRecorder
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		kube := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		assert.Equal(t, http.StatusNotFound, invoke(kube).Code)
+	})
+
+	t.Run("GetFailed", func(t *testing.T) {
+		kube := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return errors.New("connection refused")
+			},
+		}).Build()
+		assert.Equal(t, http.StatusInternalServerError, invoke(kube).Code)
+	})
+}
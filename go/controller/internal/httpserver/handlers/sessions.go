@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"fmt"
 	"io"
 	"net/http"
 
@@ -32,7 +31,7 @@ func (h *SessionsHandler) HandleListSessions(w ErrorResponseWriter, r *http.Requ
 	log = log.WithValues("userID", userID)
 
 	log.V(1).Info("Listing sessions from Autogen")
-	sessions, err := h.AutogenClient.ListSessions(userID)
+	sessions, err := h.AutogenClient.ListSessions(r.Context(), userID)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to list sessions", err))
 		return
@@ -61,7 +60,7 @@ func (h *SessionsHandler) HandleCreateSession(w ErrorResponseWriter, r *http.Req
 	log.V(1).Info("Creating session in Autogen",
 		"teamID", sessionRequest.TeamID,
 		"name", sessionRequest.Name)
-	session, err := h.AutogenClient.CreateSession(sessionRequest)
+	session, err := h.AutogenClient.CreateSession(r.Context(), sessionRequest)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to create session", err))
 		return
@@ -90,7 +89,7 @@ func (h *SessionsHandler) HandleGetSession(w ErrorResponseWriter, r *http.Reques
 	log = log.WithValues("userID", userID)
 
 	log.V(1).Info("Getting session from Autogen")
-	session, err := h.AutogenClient.GetSessionById(sessionID, userID)
+	session, err := h.AutogenClient.GetSessionById(r.Context(), sessionID, userID)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to get session", err))
 		return
@@ -128,7 +127,7 @@ func (h *SessionsHandler) HandleSessionInvoke(w ErrorResponseWriter, r *http.Req
 		return
 	}
 
-	result, err := h.AutogenClient.InvokeSession(sessionID, userID, string(body))
+	result, err := h.AutogenClient.InvokeSession(r.Context(), sessionID, userID, string(body))
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to invoke session", err))
 		return
@@ -160,14 +159,14 @@ func (h *SessionsHandler) HandleSessionInvokeStream(w ErrorResponseWriter, r *ht
 		return
 	}
 
-	ch, err := h.AutogenClient.InvokeSessionStream(sessionID, userID, string(body))
+	ch, err := h.AutogenClient.InvokeSessionStream(r.Context(), sessionID, userID, string(body))
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to invoke session", err))
 		return
 	}
 
 	for event := range ch {
-		w.Write([]byte(fmt.Sprintf("event: %s\ndata: %s\n\n", event.Event, event.Data)))
+		w.Write(event.Encode())
 	}
 }
 
@@ -190,7 +189,7 @@ func (h *SessionsHandler) HandleListSessionMessages(w ErrorResponseWriter, r *ht
 	log = log.WithValues("userID", userID)
 
 	log.V(1).Info("Listing runs for session from Autogen")
-	runs, err := h.AutogenClient.ListSessionRuns(sessionID, userID)
+	runs, err := h.AutogenClient.ListSessionRuns(r.Context(), sessionID, userID)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to list session runs", err))
 		return
@@ -230,7 +229,7 @@ func (h *SessionsHandler) HandleDeleteSession(w ErrorResponseWriter, r *http.Req
 	}
 	log = log.WithValues("sessionID", sessionID)
 
-	err = h.AutogenClient.DeleteSession(sessionID, userID)
+	err = h.AutogenClient.DeleteSession(r.Context(), sessionID, userID)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to delete session", err))
 		return
@@ -262,7 +261,7 @@ func (h *SessionsHandler) HandleUpdateSession(w ErrorResponseWriter, r *http.Req
 		return
 	}
 
-	updatedSession, err := h.AutogenClient.UpdateSession(sessionID, userID, sessionRequest)
+	updatedSession, err := h.AutogenClient.UpdateSession(r.Context(), sessionID, userID, sessionRequest)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to update session", err))
 		return
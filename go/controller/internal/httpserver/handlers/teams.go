@@ -50,7 +50,7 @@ func (h *TeamsHandler) HandleListTeams(w ErrorResponseWriter, r *http.Request) {
 	teamsWithID := make([]map[string]interface{}, 0)
 	for _, team := range agentList.Items {
 		log.V(1).Info("Processing team", "teamName", team.Name)
-		autogenTeam, err := h.AutogenClient.GetTeam(convertToKubernetesIdentifier(team.Name), userID)
+		autogenTeam, err := h.AutogenClient.GetTeam(r.Context(), convertToKubernetesIdentifier(team.Name), userID)
 		if err != nil {
 			w.RespondWithError(errors.NewInternalServerError("Failed to get team from Autogen", err))
 			return
@@ -157,7 +157,7 @@ func (h *TeamsHandler) HandleCreateTeam(w ErrorResponseWriter, r *http.Request)
 
 	// Validate the team
 	log.V(1).Info("Validating team")
-	validationResp, err := h.AutogenClient.Validate(&validateReq)
+	validationResp, err := h.AutogenClient.Validate(r.Context(), &validateReq)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to validate team", err))
 		return
@@ -218,7 +218,7 @@ func (h *TeamsHandler) HandleGetTeam(w ErrorResponseWriter, r *http.Request) {
 	log = log.WithValues("teamID", teamID)
 
 	log.V(1).Info("Getting team from Autogen")
-	autogenTeam, err := h.AutogenClient.GetTeamByID(teamID, userID)
+	autogenTeam, err := h.AutogenClient.GetTeamByID(r.Context(), teamID, userID)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to get team from Autogen", err))
 		return
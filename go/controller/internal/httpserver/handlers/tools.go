@@ -43,7 +43,7 @@ func (h *ToolsHandler) HandleListTools(w ErrorResponseWriter, r *http.Request) {
 	log = log.WithValues("userID", userID)
 
 	log.V(1).Info("Listing tools from Autogen")
-	tools, err := h.AutogenClient.ListTools(userID)
+	tools, err := h.AutogenClient.ListTools(r.Context(), userID)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to list tools", err))
 		return
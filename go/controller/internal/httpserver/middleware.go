@@ -47,6 +47,13 @@ func (w *statusResponseWriter) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
+// Flush forwards to the underlying writer, so that streamed responses are not buffered
+func (w *statusResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 // Forward RespondWithError to underlying writer if it implements ErrorResponseWriter
 func (w *statusResponseWriter) RespondWithError(err error) {
 	if errWriter, ok := w.ResponseWriter.(handlers.ErrorResponseWriter); ok {
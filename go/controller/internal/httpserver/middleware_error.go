@@ -27,6 +27,13 @@ type errorResponseWriter struct {
 
 var _ handlers.ErrorResponseWriter = &errorResponseWriter{}
 
+// Flush forwards to the underlying writer, so that streamed responses are not buffered
+func (w *errorResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 func (w *errorResponseWriter) RespondWithError(err error) {
 	log := ctrllog.FromContext(w.request.Context())
 
@@ -2,7 +2,12 @@ package httpserver
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -17,19 +22,20 @@ import (
 
 const (
 	// API Path constants
-	APIPathHealth      = "/health"
-	APIPathModelConfig = "/api/modelconfigs"
-	APIPathRuns        = "/api/runs"
-	APIPathSessions    = "/api/sessions"
-	APIPathTools       = "/api/tools"
-	APIPathToolServers = "/api/toolservers"
-	APIPathTeams       = "/api/teams"
-	APIPathAgents      = "/api/agents"
-	APIPathProviders   = "/api/providers"
-	APIPathModels      = "/api/models"
-	APIPathMemories    = "/api/memories"
-	APIPathA2A         = "/api/a2a"
-	APIPathFeedback    = "/api/feedback"
+	APIPathHealth       = "/health"
+	APIPathModelConfig  = "/api/modelconfigs"
+	APIPathRuns         = "/api/runs"
+	APIPathSessions     = "/api/sessions"
+	APIPathTools        = "/api/tools"
+	APIPathToolServers  = "/api/toolservers"
+	APIPathTeams        = "/api/teams"
+	APIPathAgents       = "/api/agents"
+	APIPathProviders    = "/api/providers"
+	APIPathModels       = "/api/models"
+	APIPathMemories     = "/api/memories"
+	APIPathA2A          = "/api/a2a"
+	APIPathFeedback     = "/api/feedback"
+	APIPathRemoteAgents = "/api/remoteagents"
 )
 
 var defaultModelConfig = types.NamespacedName{
@@ -43,14 +49,31 @@ type ServerConfig struct {
 	AutogenClient autogen_client.Client
 	KubeClient    client.Client
 	A2AHandler    a2a.A2AHandlerMux
+	// RuntimeBindAddr is the address of the listener serving the agent runtime, whose routes act
+	// with the permissions of the controller. It must be a loopback address, so that only the
+	// agent runtime in the pod of the controller can reach it.
+	RuntimeBindAddr string
+	// A2ATLS optionally serves the A2A endpoints on an additional TLS listener,
+	// which verifies client certificates for mTLS authentication.
+	A2ATLS *A2ATLSConfig
+}
+
+// A2ATLSConfig holds the configuration for the A2A TLS listener
+type A2ATLSConfig struct {
+	BindAddr     string
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
 }
 
 // HTTPServer is the structure that manages the HTTP server
 type HTTPServer struct {
-	httpServer *http.Server
-	config     ServerConfig
-	router     *mux.Router
-	handlers   *handlers.Handlers
+	httpServer    *http.Server
+	runtimeServer *http.Server
+	a2aServer     *http.Server
+	config        ServerConfig
+	router        *mux.Router
+	handlers      *handlers.Handlers
 }
 
 // NewHTTPServer creates a new HTTP server instance
@@ -83,6 +106,35 @@ func (s *HTTPServer) Start(ctx context.Context) error {
 		}
 	}()
 
+	if s.config.RuntimeBindAddr != "" {
+		if !isLoopbackAddr(s.config.RuntimeBindAddr) {
+			return fmt.Errorf("agent runtime server address %s is not a loopback address", s.config.RuntimeBindAddr)
+		}
+		s.runtimeServer = s.newRuntimeServer()
+
+		log.Info("Starting agent runtime server", "address", s.config.RuntimeBindAddr)
+		go func() {
+			if err := s.runtimeServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Error(err, "Agent runtime server failed")
+			}
+		}()
+	}
+
+	if s.config.A2ATLS != nil {
+		a2aServer, err := s.newA2ATLSServer()
+		if err != nil {
+			return err
+		}
+		s.a2aServer = a2aServer
+
+		log.Info("Starting A2A TLS server", "address", s.config.A2ATLS.BindAddr)
+		go func() {
+			if err := s.a2aServer.ListenAndServeTLS(s.config.A2ATLS.CertFile, s.config.A2ATLS.KeyFile); err != nil && err != http.ErrServerClosed {
+				log.Error(err, "A2A TLS server failed")
+			}
+		}()
+	}
+
 	// Wait for context cancellation to shut down
 	go func() {
 		<-ctx.Done()
@@ -92,6 +144,16 @@ func (s *HTTPServer) Start(ctx context.Context) error {
 		if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
 			log.Error(err, "Failed to properly shutdown HTTP server")
 		}
+		if s.runtimeServer != nil {
+			if err := s.runtimeServer.Shutdown(shutdownCtx); err != nil {
+				log.Error(err, "Failed to properly shutdown agent runtime server")
+			}
+		}
+		if s.a2aServer != nil {
+			if err := s.a2aServer.Shutdown(shutdownCtx); err != nil {
+				log.Error(err, "Failed to properly shutdown A2A TLS server")
+			}
+		}
 	}()
 
 	return nil
@@ -99,12 +161,80 @@ func (s *HTTPServer) Start(ctx context.Context) error {
 
 // Stop stops the HTTP server
 func (s *HTTPServer) Stop(ctx context.Context) error {
+	if s.runtimeServer != nil {
+		if err := s.runtimeServer.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	if s.a2aServer != nil {
+		if err := s.a2aServer.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
 	if s.httpServer != nil {
 		return s.httpServer.Shutdown(ctx)
 	}
 	return nil
 }
 
+// newRuntimeServer creates the server for the agent runtime listener. Its routes are not served
+// by the main listener, which is exposed by the Service of the controller.
+func (s *HTTPServer) newRuntimeServer() *http.Server {
+	router := mux.NewRouter()
+	router.HandleFunc(APIPathRemoteAgents+"/{namespace}/{remoteAgentName}/invoke", adaptHandler(s.handlers.RemoteAgents.HandleInvokeRemoteAgent)).Methods(http.MethodPost)
+	router.Use(contentTypeMiddleware)
+	router.Use(loggingMiddleware)
+	router.Use(errorHandlerMiddleware)
+
+	return &http.Server{
+		Addr:    s.config.RuntimeBindAddr,
+		Handler: router,
+	}
+}
+
+// isLoopbackAddr reports whether the host of the address is a loopback address.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// newA2ATLSServer creates the server for the A2A TLS listener.
+// Client certificates are optional, so that agents not using mTLS can be reached as well.
+func (s *HTTPServer) newA2ATLSServer() (*http.Server, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}
+	if s.config.A2ATLS.ClientCAFile != "" {
+		caCert, err := os.ReadFile(s.config.A2ATLS.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read A2A client CA: %w", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in A2A client CA %s", s.config.A2ATLS.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = clientCAs
+	}
+
+	router := mux.NewRouter()
+	router.PathPrefix(APIPathA2A).Handler(s.config.A2AHandler)
+	router.Use(loggingMiddleware)
+
+	return &http.Server{
+		Addr:      s.config.A2ATLS.BindAddr,
+		Handler:   router,
+		TLSConfig: tlsConfig,
+	}, nil
+}
+
 // NeedLeaderElection implements controller-runtime's LeaderElectionRunnable interface
 func (s *HTTPServer) NeedLeaderElection() bool {
 	// Return false so the HTTP server runs on all instances, not just the leader
@@ -170,6 +300,9 @@ func (s *HTTPServer) setupRoutes() {
 	s.router.HandleFunc(APIPathFeedback, adaptHandler(s.handlers.Feedback.HandleCreateFeedback)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathFeedback, adaptHandler(s.handlers.Feedback.HandleListFeedback)).Methods(http.MethodGet)
 
+	// Remote Agents
+	s.router.HandleFunc(APIPathRemoteAgents, adaptHandler(s.handlers.RemoteAgents.HandleListRemoteAgents)).Methods(http.MethodGet)
+
 	// A2A
 	s.router.PathPrefix(APIPathA2A).Handler(s.config.A2AHandler)
 
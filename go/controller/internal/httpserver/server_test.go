@@ -0,0 +1,42 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRuntimeRoutes(t *testing.T) {
+	require.NoError(t, v1alpha1.AddToScheme(scheme.Scheme))
+	server := NewHTTPServer(ServerConfig{
+		KubeClient:      fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+		RuntimeBindAddr: "127.0.0.1:0",
+	})
+	server.setupRoutes()
+
+	invoke := func(handler http.Handler) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, APIPathRemoteAgents+"/kagent/weather/invoke", strings.NewReader(`{"task":"weather in Paris?"}`))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// requests to the main listener may come from any pod, whatever their source address
+	assert.Equal(t, "404 page not found\n", invoke(server.router).Body.String())
+	// the runtime listener looks up the remote agent, which does not exist
+	rec := invoke(server.newRuntimeServer().Handler)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "not found")
+
+	assert.True(t, isLoopbackAddr("127.0.0.1:8084"))
+	assert.True(t, isLoopbackAddr("localhost:8084"))
+	assert.False(t, isLoopbackAddr(":8084"))
+	assert.False(t, isLoopbackAddr("0.0.0.0:8084"))
+}
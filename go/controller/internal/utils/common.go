@@ -1,6 +1,11 @@
 package common
 
-import "os"
+import (
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
 
 func GetResourceNamespace() string {
 	if val := os.Getenv("KAGENT_NAMESPACE"); val != "" {
@@ -20,3 +25,20 @@ func GetGlobalUserID() string {
 func MakePtr[T any](v T) *T {
 	return &v
 }
+
+// ParseRefString parses a resource reference of the form <name> or <namespace>/<name>.
+// References without a namespace resolve to parentNamespace.
+func ParseRefString(ref string, parentNamespace string) types.NamespacedName {
+	parts := strings.Split(ref, "/")
+	if len(parts) == 2 {
+		return types.NamespacedName{
+			Namespace: parts[0],
+			Name:      parts[1],
+		}
+	}
+
+	return types.NamespacedName{
+		Namespace: parentNamespace,
+		Name:      ref,
+	}
+}
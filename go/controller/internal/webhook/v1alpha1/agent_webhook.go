@@ -0,0 +1,147 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var agentlog = logf.Log.WithName("agent-resource")
+
+// SetupAgentWebhookWithManager registers the webhook for Agent in the manager.
+func SetupAgentWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Agent{}).
+		WithValidator(&AgentCustomValidator{Kube: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha1-agent,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=agents,verbs=create;update,versions=v1alpha1,name=vagent-v1alpha1.kagent.dev,admissionReviewVersions=v1
+
+// AgentCustomValidator validates Agent resources when they are created or updated.
+type AgentCustomValidator struct {
+	Kube client.Reader
+}
+
+var _ admission.CustomValidator = &AgentCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Agent.
+func (v *AgentCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	agent, ok := obj.(*v1alpha1.Agent)
+	if !ok {
+		return nil, fmt.Errorf("expected an Agent object but got %T", obj)
+	}
+	agentlog.V(1).Info("Validation for Agent upon creation", "name", agent.GetName())
+
+	return nil, v.validateAgent(ctx, agent)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Agent.
+func (v *AgentCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	agent, ok := newObj.(*v1alpha1.Agent)
+	if !ok {
+		return nil, fmt.Errorf("expected an Agent object for the newObj but got %T", newObj)
+	}
+	agentlog.V(1).Info("Validation for Agent upon update", "name", agent.GetName())
+
+	return nil, v.validateAgent(ctx, agent)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Agent.
+func (v *AgentCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *AgentCustomValidator) validateAgent(ctx context.Context, agent *v1alpha1.Agent) error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if agent.Spec.ModelConfig != "" {
+		if err := validateReference(
+			ctx,
+			v.Kube,
+			specPath.Child("modelConfig"),
+			agent.Spec.ModelConfig,
+			agent.Namespace,
+			&v1alpha1.ModelConfig{},
+		); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	for i, tool := range agent.Spec.Tools {
+		if tool == nil || tool.McpServer == nil {
+			continue
+		}
+		if err := validateReference(
+			ctx,
+			v.Kube,
+			specPath.Child("tools").Index(i).Child("mcpServer", "toolServer"),
+			tool.McpServer.ToolServer,
+			agent.Namespace,
+			&v1alpha1.ToolServer{},
+		); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	for i, tool := range agent.Spec.Tools {
+		if tool == nil || tool.RemoteAgent == nil {
+			continue
+		}
+		if err := validateReference(
+			ctx,
+			v.Kube,
+			specPath.Child("tools").Index(i).Child("remoteAgent", "ref"),
+			tool.RemoteAgent.Ref,
+			agent.Namespace,
+			&v1alpha1.RemoteAgent{},
+		); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	allErrs = append(allErrs, validateAgentToolGraph(ctx, v.Kube, agent)...)
+	allErrs = append(allErrs, validateHandoffs(specPath.Child("handoffs"), agent)...)
+
+	for i, memory := range agent.Spec.Memory {
+		if err := validateReference(
+			ctx,
+			v.Kube,
+			specPath.Child("memory").Index(i),
+			memory,
+			agent.Namespace,
+			&v1alpha1.Memory{},
+		); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("Agent").GroupKind(), agent.Name, allErrs)
+}
+
+// validateHandoffs only checks the handoffs of the agent itself, as the targets are resolved
+// against the participants of the swarm teams the agent is part of.
+func validateHandoffs(fldPath *field.Path, agent *v1alpha1.Agent) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := make(map[string]bool)
+	for i, handoff := range agent.Spec.Handoffs {
+		switch {
+		case handoff.Target == agent.Name:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("target"), handoff.Target, "an agent can not hand off to itself"))
+		case seen[handoff.Target]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("target"), handoff.Target))
+		}
+		seen[handoff.Target] = true
+	}
+	return allErrs
+}
@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var memorylog = logf.Log.WithName("memory-resource")
+
+// SetupMemoryWebhookWithManager registers the webhook for Memory in the manager.
+func SetupMemoryWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Memory{}).
+		WithValidator(&MemoryCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha1-memory,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=memories,verbs=create;update,versions=v1alpha1,name=vmemory-v1alpha1.kagent.dev,admissionReviewVersions=v1
+
+// MemoryCustomValidator validates Memory resources when they are created or updated.
+type MemoryCustomValidator struct{}
+
+var _ admission.CustomValidator = &MemoryCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Memory.
+func (v *MemoryCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	memory, ok := obj.(*v1alpha1.Memory)
+	if !ok {
+		return nil, fmt.Errorf("expected a Memory object but got %T", obj)
+	}
+	memorylog.V(1).Info("Validation for Memory upon creation", "name", memory.GetName())
+
+	return nil, validateMemory(memory)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Memory.
+func (v *MemoryCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	memory, ok := newObj.(*v1alpha1.Memory)
+	if !ok {
+		return nil, fmt.Errorf("expected a Memory object for the newObj but got %T", newObj)
+	}
+	memorylog.V(1).Info("Validation for Memory upon update", "name", memory.GetName())
+
+	return nil, validateMemory(memory)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Memory.
+func (v *MemoryCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateMemory(memory *v1alpha1.Memory) error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if memory.Spec.Provider == v1alpha1.Pinecone {
+		if memory.Spec.Pinecone == nil {
+			allErrs = append(allErrs, field.Required(specPath.Child("pinecone"), "pinecone must be specified for the Pinecone provider"))
+		} else {
+			allErrs = appendIfErr(allErrs, validateFloatString(
+				specPath.Child("pinecone", "scoreThreshold"),
+				memory.Spec.Pinecone.ScoreThreshold,
+			))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("Memory").GroupKind(), memory.Name, allErrs)
+}
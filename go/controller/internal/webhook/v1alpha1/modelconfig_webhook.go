@@ -0,0 +1,151 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var modelconfiglog = logf.Log.WithName("modelconfig-resource")
+
+// SetupModelConfigWebhookWithManager registers the webhook for ModelConfig in the manager.
+func SetupModelConfigWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.ModelConfig{}).
+		WithValidator(&ModelConfigCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha1-modelconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=modelconfigs,verbs=create;update,versions=v1alpha1,name=vmodelconfig-v1alpha1.kagent.dev,admissionReviewVersions=v1
+
+// ModelConfigCustomValidator validates ModelConfig resources when they are created or updated.
+type ModelConfigCustomValidator struct{}
+
+var _ admission.CustomValidator = &ModelConfigCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ModelConfig.
+func (v *ModelConfigCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	modelConfig, ok := obj.(*v1alpha1.ModelConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a ModelConfig object but got %T", obj)
+	}
+	modelconfiglog.V(1).Info("Validation for ModelConfig upon creation", "name", modelConfig.GetName())
+
+	return nil, validateModelConfig(modelConfig)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ModelConfig.
+func (v *ModelConfigCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	modelConfig, ok := newObj.(*v1alpha1.ModelConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a ModelConfig object for the newObj but got %T", newObj)
+	}
+	modelconfiglog.V(1).Info("Validation for ModelConfig upon update", "name", modelConfig.GetName())
+
+	return nil, validateModelConfig(modelConfig)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ModelConfig.
+func (v *ModelConfigCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateModelConfig(modelConfig *v1alpha1.ModelConfig) error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if anthropic := modelConfig.Spec.Anthropic; anthropic != nil {
+		fldPath := specPath.Child("anthropic")
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("temperature"), anthropic.Temperature))
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("topP"), anthropic.TopP))
+	}
+
+	if openAI := modelConfig.Spec.OpenAI; openAI != nil {
+		fldPath := specPath.Child("openAI")
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("temperature"), openAI.Temperature))
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("topP"), openAI.TopP))
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("frequencyPenalty"), openAI.FrequencyPenalty))
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("presencePenalty"), openAI.PresencePenalty))
+	}
+
+	if azureOpenAI := modelConfig.Spec.AzureOpenAI; azureOpenAI != nil {
+		fldPath := specPath.Child("azureOpenAI")
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("temperature"), azureOpenAI.Temperature))
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("topP"), azureOpenAI.TopP))
+	}
+
+	if gemini := modelConfig.Spec.Gemini; gemini != nil {
+		fldPath := specPath.Child("gemini")
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("temperature"), gemini.Temperature))
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("topP"), gemini.TopP))
+	}
+
+	if openAICompatible := modelConfig.Spec.OpenAICompatible; openAICompatible != nil {
+		fldPath := specPath.Child("openAICompatible")
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("temperature"), openAICompatible.Temperature))
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("topP"), openAICompatible.TopP))
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("frequencyPenalty"), openAICompatible.FrequencyPenalty))
+		allErrs = appendIfErr(allErrs, validateFloatString(fldPath.Child("presencePenalty"), openAICompatible.PresencePenalty))
+	}
+
+	if modelConfig.Spec.Provider == v1alpha1.OpenAICompatible && modelConfig.Spec.OpenAICompatible == nil {
+		allErrs = append(allErrs, field.Required(specPath.Child("openAICompatible"), "openAICompatible must be specified for the OpenAICompatible provider"))
+	}
+
+	if modelConfig.Spec.Provider == v1alpha1.Ollama && modelConfig.Spec.Ollama == nil {
+		allErrs = append(allErrs, field.Required(specPath.Child("ollama"), "ollama must be specified for the Ollama provider"))
+	}
+
+	if modelConfig.Spec.Provider == v1alpha1.Mock {
+		if modelConfig.Spec.Mock == nil {
+			allErrs = append(allErrs, field.Required(specPath.Child("mock"), "mock must be specified for the Mock provider"))
+		} else if modelConfig.Spec.Mock.ScriptConfigMapRef == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("mock", "scriptConfigMapRef"), "the ConfigMap of the mock script must be specified"))
+		}
+	}
+
+	allErrs = append(allErrs, validateFallbacks(specPath.Child("fallbacks"), modelConfig)...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("ModelConfig").GroupKind(), modelConfig.Name, allErrs)
+}
+
+// validateFallbacks rejects fallbacks to the model config itself and duplicate fallbacks.
+func validateFallbacks(fldPath *field.Path, modelConfig *v1alpha1.ModelConfig) field.ErrorList {
+	var allErrs field.ErrorList
+	self := modelConfig.Namespace + "/" + modelConfig.Name
+	seen := make(map[string]bool)
+	for i, fallback := range modelConfig.Spec.Fallbacks {
+		ref := fallback
+		if !strings.Contains(ref, "/") {
+			ref = modelConfig.Namespace + "/" + ref
+		}
+		switch {
+		case fallback == "":
+			allErrs = append(allErrs, field.Required(fldPath.Index(i), "the name of the fallback model config must be specified"))
+		case ref == self:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), fallback, "a model config can not fall back to itself"))
+		case seen[ref]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), fallback))
+		}
+		seen[ref] = true
+	}
+	return allErrs
+}
+
+func appendIfErr(allErrs field.ErrorList, err *field.Error) field.ErrorList {
+	if err != nil {
+		return append(allErrs, err)
+	}
+	return allErrs
+}
@@ -0,0 +1,86 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var remoteagentlog = logf.Log.WithName("remoteagent-resource")
+
+// SetupRemoteAgentWebhookWithManager registers the webhook for RemoteAgent in the manager.
+func SetupRemoteAgentWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.RemoteAgent{}).
+		WithValidator(&RemoteAgentCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha1-remoteagent,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=remoteagents,verbs=create;update,versions=v1alpha1,name=vremoteagent-v1alpha1.kagent.dev,admissionReviewVersions=v1
+
+// RemoteAgentCustomValidator validates RemoteAgent resources when they are created or updated.
+type RemoteAgentCustomValidator struct{}
+
+var _ admission.CustomValidator = &RemoteAgentCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RemoteAgent.
+func (v *RemoteAgentCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	remoteAgent, ok := obj.(*v1alpha1.RemoteAgent)
+	if !ok {
+		return nil, fmt.Errorf("expected a RemoteAgent object but got %T", obj)
+	}
+	remoteagentlog.V(1).Info("Validation for RemoteAgent upon creation", "name", remoteAgent.GetName())
+
+	return nil, validateRemoteAgent(remoteAgent)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RemoteAgent.
+func (v *RemoteAgentCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	remoteAgent, ok := newObj.(*v1alpha1.RemoteAgent)
+	if !ok {
+		return nil, fmt.Errorf("expected a RemoteAgent object for the newObj but got %T", newObj)
+	}
+	remoteagentlog.V(1).Info("Validation for RemoteAgent upon update", "name", remoteAgent.GetName())
+
+	return nil, validateRemoteAgent(remoteAgent)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RemoteAgent.
+func (v *RemoteAgentCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateRemoteAgent(remoteAgent *v1alpha1.RemoteAgent) error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if u, err := url.Parse(remoteAgent.Spec.URL); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("url"), remoteAgent.Spec.URL, err.Error()))
+	} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("url"), remoteAgent.Spec.URL, "must be an absolute http or https URL"))
+	}
+
+	if auth := remoteAgent.Spec.Authentication; auth != nil && auth.Bearer != nil && auth.Bearer.SecretRef == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("authentication", "bearer", "secretRef"), "must be set"))
+	}
+
+	if interval := remoteAgent.Spec.RefreshInterval; interval != nil && interval.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("refreshInterval"), interval.Duration.String(), "must not be negative"))
+	}
+	if timeout := remoteAgent.Spec.Timeout; timeout != nil && timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("timeout"), timeout.Duration.String(), "must be positive"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("RemoteAgent").GroupKind(), remoteAgent.Name, allErrs)
+}
@@ -0,0 +1,196 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var teamlog = logf.Log.WithName("team-resource")
+
+// SetupTeamWebhookWithManager registers the webhook for Team in the manager.
+func SetupTeamWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Team{}).
+		WithValidator(&TeamCustomValidator{Kube: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha1-team,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=teams,verbs=create;update,versions=v1alpha1,name=vteam-v1alpha1.kagent.dev,admissionReviewVersions=v1
+
+// TeamCustomValidator validates Team resources when they are created or updated.
+type TeamCustomValidator struct {
+	Kube client.Reader
+}
+
+var _ admission.CustomValidator = &TeamCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Team.
+func (v *TeamCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	team, ok := obj.(*v1alpha1.Team)
+	if !ok {
+		return nil, fmt.Errorf("expected a Team object but got %T", obj)
+	}
+	teamlog.V(1).Info("Validation for Team upon creation", "name", team.GetName())
+
+	return nil, v.validateTeam(ctx, team)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Team.
+func (v *TeamCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	team, ok := newObj.(*v1alpha1.Team)
+	if !ok {
+		return nil, fmt.Errorf("expected a Team object for the newObj but got %T", newObj)
+	}
+	teamlog.V(1).Info("Validation for Team upon update", "name", team.GetName())
+
+	return nil, v.validateTeam(ctx, team)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Team.
+func (v *TeamCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *TeamCustomValidator) validateTeam(ctx context.Context, team *v1alpha1.Team) error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	var modesSet []string
+	if team.Spec.RoundRobinTeamConfig != nil {
+		modesSet = append(modesSet, "roundRobinTeamConfig")
+	}
+	if team.Spec.SelectorTeamConfig != nil {
+		modesSet = append(modesSet, "selectorTeamConfig")
+	}
+	if team.Spec.MagenticOneTeamConfig != nil {
+		modesSet = append(modesSet, "magenticOneTeamConfig")
+	}
+	if team.Spec.SwarmTeamConfig != nil {
+		modesSet = append(modesSet, "swarmTeamConfig")
+	}
+	if len(modesSet) != 1 {
+		allErrs = append(allErrs, field.Invalid(specPath, modesSet,
+			"exactly one of roundRobinTeamConfig, selectorTeamConfig, magenticOneTeamConfig or swarmTeamConfig must be set"))
+	}
+
+	if team.Spec.ModelConfig != "" {
+		if err := validateReference(
+			ctx,
+			v.Kube,
+			specPath.Child("modelConfig"),
+			team.Spec.ModelConfig,
+			team.Namespace,
+			&v1alpha1.ModelConfig{},
+		); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	for i, participant := range team.Spec.Participants {
+		if err := validateReference(
+			ctx,
+			v.Kube,
+			specPath.Child("participants").Index(i),
+			participant,
+			team.Namespace,
+			&v1alpha1.Agent{},
+		); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	allErrs = append(allErrs, validateTerminationCondition(specPath.Child("terminationCondition"), team.Spec.TerminationCondition, false)...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("Team").GroupKind(), team.Name, allErrs)
+}
+
+// validateTerminationCondition validates a termination condition and its nested conditions. The schema
+// of nested conditions is not part of the CRD, so they are validated here.
+func validateTerminationCondition(fldPath *field.Path, condition v1alpha1.TerminationCondition, nested bool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var conditionsSet []string
+	for _, c := range []struct {
+		name string
+		set  bool
+	}{
+		{"maxMessageTermination", condition.MaxMessageTermination != nil},
+		{"textMentionTermination", condition.TextMentionTermination != nil},
+		{"textMessageTermination", condition.TextMessageTermination != nil},
+		{"stopMessageTermination", condition.StopMessageTermination != nil},
+		{"timeoutTermination", condition.TimeoutTermination != nil},
+		{"tokenUsageTermination", condition.TokenUsageTermination != nil},
+		{"handoffTermination", condition.HandoffTermination != nil},
+		{"sourceMatchTermination", condition.SourceMatchTermination != nil},
+		{"andTermination", condition.AndTermination != nil},
+		{"orTermination", condition.OrTermination != nil},
+	} {
+		if c.set {
+			conditionsSet = append(conditionsSet, c.name)
+		}
+	}
+	switch {
+	case len(conditionsSet) > 1:
+		allErrs = append(allErrs, field.Invalid(fldPath, conditionsSet, "exactly one termination condition must be set"))
+	case len(conditionsSet) == 0 && nested:
+		allErrs = append(allErrs, field.Required(fldPath, "a termination condition must be set"))
+	}
+
+	if condition.TimeoutTermination != nil && condition.TimeoutTermination.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutTermination", "timeout"),
+			condition.TimeoutTermination.Timeout.Duration.String(), "the timeout must be positive"))
+	}
+	if tokenUsage := condition.TokenUsageTermination; tokenUsage != nil {
+		if tokenUsage.MaxTotalTokens == nil && tokenUsage.MaxPromptTokens == nil && tokenUsage.MaxCompletionTokens == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("tokenUsageTermination"), "at least one token limit must be set"))
+		}
+		allErrs = appendIfErr(allErrs, validateTokenLimit(fldPath.Child("tokenUsageTermination", "maxTotalTokens"), tokenUsage.MaxTotalTokens))
+		allErrs = appendIfErr(allErrs, validateTokenLimit(fldPath.Child("tokenUsageTermination", "maxPromptTokens"), tokenUsage.MaxPromptTokens))
+		allErrs = appendIfErr(allErrs, validateTokenLimit(fldPath.Child("tokenUsageTermination", "maxCompletionTokens"), tokenUsage.MaxCompletionTokens))
+	}
+	if condition.HandoffTermination != nil && condition.HandoffTermination.Target == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("handoffTermination", "target"), "the target must be specified"))
+	}
+	if condition.SourceMatchTermination != nil && len(condition.SourceMatchTermination.Sources) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("sourceMatchTermination", "sources"), "at least one source must be specified"))
+	}
+
+	if condition.AndTermination != nil {
+		allErrs = append(allErrs, validateNestedTerminationConditions(fldPath.Child("andTermination", "conditions"), condition.AndTermination.Conditions)...)
+	}
+	if condition.OrTermination != nil {
+		allErrs = append(allErrs, validateNestedTerminationConditions(fldPath.Child("orTermination", "conditions"), condition.OrTermination.Conditions)...)
+	}
+
+	return allErrs
+}
+
+func validateNestedTerminationConditions(fldPath *field.Path, conditions []v1alpha1.TerminationCondition) field.ErrorList {
+	if len(conditions) == 0 {
+		return field.ErrorList{field.Required(fldPath, "at least one condition must be specified")}
+	}
+	var allErrs field.ErrorList
+	for i, condition := range conditions {
+		allErrs = append(allErrs, validateTerminationCondition(fldPath.Index(i), condition, true)...)
+	}
+	return allErrs
+}
+
+func validateTokenLimit(fldPath *field.Path, limit *int) *field.Error {
+	if limit != nil && *limit < 1 {
+		return field.Invalid(fldPath, *limit, "the token limit must be at least 1")
+	}
+	return nil
+}
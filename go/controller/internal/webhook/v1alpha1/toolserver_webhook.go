@@ -0,0 +1,86 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var toolserverlog = logf.Log.WithName("toolserver-resource")
+
+// SetupToolServerWebhookWithManager registers the webhook for ToolServer in the manager.
+func SetupToolServerWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.ToolServer{}).
+		WithValidator(&ToolServerCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha1-toolserver,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=toolservers,verbs=create;update,versions=v1alpha1,name=vtoolserver-v1alpha1.kagent.dev,admissionReviewVersions=v1
+
+// ToolServerCustomValidator validates ToolServer resources when they are created or updated.
+type ToolServerCustomValidator struct{}
+
+var _ admission.CustomValidator = &ToolServerCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ToolServer.
+func (v *ToolServerCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	toolServer, ok := obj.(*v1alpha1.ToolServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a ToolServer object but got %T", obj)
+	}
+	toolserverlog.V(1).Info("Validation for ToolServer upon creation", "name", toolServer.GetName())
+
+	return nil, validateToolServer(toolServer)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ToolServer.
+func (v *ToolServerCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	toolServer, ok := newObj.(*v1alpha1.ToolServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a ToolServer object for the newObj but got %T", newObj)
+	}
+	toolserverlog.V(1).Info("Validation for ToolServer upon update", "name", toolServer.GetName())
+
+	return nil, validateToolServer(toolServer)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ToolServer.
+func (v *ToolServerCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateToolServer(toolServer *v1alpha1.ToolServer) error {
+	var allErrs field.ErrorList
+	configPath := field.NewPath("spec", "config")
+	config := toolServer.Spec.Config
+
+	switch {
+	case config.Stdio != nil && config.Sse != nil:
+		allErrs = append(allErrs, field.Invalid(configPath, "stdio, sse", "only one of stdio or sse may be set"))
+	case config.Stdio == nil && config.Sse == nil:
+		allErrs = append(allErrs, field.Required(configPath, "one of stdio or sse must be set"))
+	}
+
+	if config.Sse != nil {
+		ssePath := configPath.Child("sse")
+		allErrs = appendIfErr(allErrs, validateDurationString(ssePath.Child("timeout"), config.Sse.Timeout))
+		allErrs = appendIfErr(allErrs, validateDurationString(ssePath.Child("sse_read_timeout"), config.Sse.SseReadTimeout))
+	}
+
+	if interval := toolServer.Spec.RefreshInterval; interval != nil && interval.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "refreshInterval"), interval.Duration.String(), "must not be negative"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("ToolServer").GroupKind(), toolServer.Name, allErrs)
+}
@@ -0,0 +1,144 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxAgentToolDepth mirrors the recursion limit enforced by the autogen translator
+const maxAgentToolDepth = 10
+
+// validateReference checks that the object referenced by ref exists.
+// ref can either be a name in parentNamespace or a reference of the form <namespace>/<name>.
+func validateReference(
+	ctx context.Context,
+	kube client.Reader,
+	path *field.Path,
+	ref string,
+	parentNamespace string,
+	obj client.Object,
+) *field.Error {
+	if ref == "" {
+		return field.Required(path, "reference must not be empty")
+	}
+
+	if err := kube.Get(ctx, common.ParseRefString(ref, parentNamespace), obj); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return field.NotFound(path, ref)
+		}
+		return field.InternalError(path, err)
+	}
+
+	return nil
+}
+
+// validateFloatString checks that value can be parsed the same way the translator parses it.
+func validateFloatString(path *field.Path, value string) *field.Error {
+	if value == "" {
+		return nil
+	}
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return field.Invalid(path, value, "must be a valid floating point number")
+	}
+	return nil
+}
+
+// validateDurationString checks that value is a valid Go duration, e.g. "30s" or "5m".
+func validateDurationString(path *field.Path, value string) *field.Error {
+	if value == "" {
+		return nil
+	}
+	if _, err := time.ParseDuration(value); err != nil {
+		return field.Invalid(path, value, fmt.Sprintf("must be a valid duration: %v", err))
+	}
+	return nil
+}
+
+// validateAgentToolGraph walks the agent tool references starting at root and
+// reports self references, cycles and chains deeper than the translator allows.
+// Agents are resolved from the cluster, except for root which is validated as submitted.
+func validateAgentToolGraph(ctx context.Context, kube client.Reader, root *v1alpha1.Agent) field.ErrorList {
+	rootRef := types.NamespacedName{Namespace: root.Namespace, Name: root.Name}
+
+	// walk returns a description of the first problem found below agent, if any
+	var walk func(agent *v1alpha1.Agent, path []types.NamespacedName) (string, error)
+	walk = func(agent *v1alpha1.Agent, path []types.NamespacedName) (string, error) {
+		for _, tool := range agent.Spec.Tools {
+			if tool == nil || tool.Agent == nil {
+				continue
+			}
+			ref := common.ParseRefString(tool.Agent.Ref, agent.Namespace)
+			if slices.Contains(path, ref) {
+				return fmt.Sprintf("cycle detected in agent tool chain: %s -> %s", formatAgentPath(path), ref), nil
+			}
+			if len(path) > maxAgentToolDepth {
+				return fmt.Sprintf("recursion limit reached in agent tool chain: %s -> %s", formatAgentPath(path), ref), nil
+			}
+
+			toolAgent := root
+			if ref != rootRef {
+				toolAgent = &v1alpha1.Agent{}
+				if err := kube.Get(ctx, ref, toolAgent); err != nil {
+					if k8s_errors.IsNotFound(err) {
+						// missing nested agents are reported when the agent referencing them is validated
+						continue
+					}
+					return "", err
+				}
+			}
+
+			if msg, err := walk(toolAgent, append(slices.Clone(path), ref)); msg != "" || err != nil {
+				return msg, err
+			}
+		}
+		return "", nil
+	}
+
+	var allErrs field.ErrorList
+	for i, tool := range root.Spec.Tools {
+		if tool == nil || tool.Agent == nil {
+			continue
+		}
+		fldPath := field.NewPath("spec", "tools").Index(i).Child("agent", "ref")
+		ref := common.ParseRefString(tool.Agent.Ref, root.Namespace)
+		if ref == rootRef {
+			allErrs = append(allErrs, field.Invalid(fldPath, tool.Agent.Ref,
+				fmt.Sprintf("agent tool cannot be used to reference itself, %s", root.Name)))
+			continue
+		}
+
+		toolAgent := &v1alpha1.Agent{}
+		if err := validateReference(ctx, kube, fldPath, tool.Agent.Ref, root.Namespace, toolAgent); err != nil {
+			allErrs = append(allErrs, err)
+			continue
+		}
+
+		msg, err := walk(toolAgent, []types.NamespacedName{rootRef, ref})
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(fldPath, err))
+		} else if msg != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath, tool.Agent.Ref, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func formatAgentPath(path []types.NamespacedName) string {
+	parts := make([]string, 0, len(path))
+	for _, ref := range path {
+		parts = append(parts, ref.String())
+	}
+	return strings.Join(parts, " -> ")
+}
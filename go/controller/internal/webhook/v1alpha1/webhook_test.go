@@ -0,0 +1,241 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	webhookv1alpha1 "github.com/kagent-dev/kagent/go/controller/internal/webhook/v1alpha1"
+)
+
+const namespace = "test-ns"
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func agentWithTools(name string, agentTools ...string) *v1alpha1.Agent {
+	agent := &v1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       v1alpha1.AgentSpec{SystemMessage: "test"},
+	}
+	for _, ref := range agentTools {
+		agent.Spec.Tools = append(agent.Spec.Tools, &v1alpha1.Tool{
+			Type:  v1alpha1.ToolProviderType_Agent,
+			Agent: &v1alpha1.AgentTool{Ref: ref},
+		})
+	}
+	return agent
+}
+
+func TestAgentValidator(t *testing.T) {
+	ctx := context.Background()
+	modelConfig := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: namespace},
+	}
+
+	t.Run("should accept valid agent", func(t *testing.T) {
+		kube := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(modelConfig, agentWithTools("b")).Build()
+		validator := &webhookv1alpha1.AgentCustomValidator{Kube: kube}
+
+		agent := agentWithTools("a", "b")
+		agent.Spec.ModelConfig = "model"
+		_, err := validator.ValidateCreate(ctx, agent)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject unknown references", func(t *testing.T) {
+		kube := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+		validator := &webhookv1alpha1.AgentCustomValidator{Kube: kube}
+
+		agent := agentWithTools("a", "missing-agent")
+		agent.Spec.ModelConfig = "missing-model"
+		agent.Spec.Memory = []string{"missing-memory"}
+		agent.Spec.Tools = append(agent.Spec.Tools, &v1alpha1.Tool{
+			Type:      v1alpha1.ToolProviderType_McpServer,
+			McpServer: &v1alpha1.McpServerTool{ToolServer: "missing-server"},
+		})
+		_, err := validator.ValidateCreate(ctx, agent)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.modelConfig")
+		assert.Contains(t, err.Error(), "spec.tools[0].agent.ref")
+		assert.Contains(t, err.Error(), "spec.tools[1].mcpServer.toolServer")
+		assert.Contains(t, err.Error(), "spec.memory[0]")
+	})
+
+	t.Run("should reject self reference", func(t *testing.T) {
+		kube := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+		validator := &webhookv1alpha1.AgentCustomValidator{Kube: kube}
+
+		_, err := validator.ValidateCreate(ctx, agentWithTools("a", "a"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be used to reference itself")
+	})
+
+	t.Run("should reject cycles through existing agents", func(t *testing.T) {
+		kube := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(
+			agentWithTools("b", "c"),
+			agentWithTools("c", "a"),
+		).Build()
+		validator := &webhookv1alpha1.AgentCustomValidator{Kube: kube}
+
+		_, err := validator.ValidateUpdate(ctx, agentWithTools("a"), agentWithTools("a", "b"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cycle detected in agent tool chain")
+	})
+
+	t.Run("should reject invalid handoffs", func(t *testing.T) {
+		kube := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+		validator := &webhookv1alpha1.AgentCustomValidator{Kube: kube}
+
+		agent := agentWithTools("a")
+		agent.Spec.Handoffs = []v1alpha1.Handoff{{Target: "b"}, {Target: "a"}, {Target: "b"}}
+		_, err := validator.ValidateCreate(ctx, agent)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.handoffs[1].target")
+		assert.Contains(t, err.Error(), "spec.handoffs[2].target")
+		assert.NotContains(t, err.Error(), "spec.handoffs[0]")
+	})
+}
+
+func TestTeamValidator(t *testing.T) {
+	ctx := context.Background()
+	kube := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(agentWithTools("a")).Build()
+	validator := &webhookv1alpha1.TeamCustomValidator{Kube: kube}
+
+	team := &v1alpha1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: namespace},
+		Spec: v1alpha1.TeamSpec{
+			Participants:         []string{"a"},
+			RoundRobinTeamConfig: &v1alpha1.RoundRobinTeamConfig{},
+		},
+	}
+	_, err := validator.ValidateCreate(ctx, team)
+	assert.NoError(t, err)
+
+	team.Spec.SwarmTeamConfig = &v1alpha1.SwarmTeamConfig{}
+	team.Spec.Participants = append(team.Spec.Participants, "missing-agent")
+	_, err = validator.ValidateCreate(ctx, team)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly one of roundRobinTeamConfig")
+	assert.Contains(t, err.Error(), "spec.participants[1]")
+}
+
+func TestTeamTerminationConditionValidator(t *testing.T) {
+	ctx := context.Background()
+	kube := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(agentWithTools("a")).Build()
+	validator := &webhookv1alpha1.TeamCustomValidator{Kube: kube}
+
+	team := &v1alpha1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: namespace},
+		Spec: v1alpha1.TeamSpec{
+			Participants:         []string{"a"},
+			RoundRobinTeamConfig: &v1alpha1.RoundRobinTeamConfig{},
+			TerminationCondition: v1alpha1.TerminationCondition{
+				OrTermination: &v1alpha1.OrTermination{Conditions: []v1alpha1.TerminationCondition{
+					{TimeoutTermination: &v1alpha1.TimeoutTermination{Timeout: metav1.Duration{Duration: 10 * time.Minute}}},
+					{TokenUsageTermination: &v1alpha1.TokenUsageTermination{MaxTotalTokens: ptr.To(50000)}},
+					{AndTermination: &v1alpha1.AndTermination{Conditions: []v1alpha1.TerminationCondition{
+						{SourceMatchTermination: &v1alpha1.SourceMatchTermination{Sources: []string{"reviewer"}}},
+						{TextMentionTermination: &v1alpha1.TextMentionTermination{Text: "APPROVE"}},
+					}}},
+				}},
+			},
+		},
+	}
+	_, err := validator.ValidateCreate(ctx, team)
+	assert.NoError(t, err)
+
+	team.Spec.TerminationCondition.OrTermination.Conditions = append(team.Spec.TerminationCondition.OrTermination.Conditions,
+		v1alpha1.TerminationCondition{},
+		v1alpha1.TerminationCondition{TokenUsageTermination: &v1alpha1.TokenUsageTermination{}},
+		v1alpha1.TerminationCondition{AndTermination: &v1alpha1.AndTermination{Conditions: []v1alpha1.TerminationCondition{
+			{TimeoutTermination: &v1alpha1.TimeoutTermination{}, HandoffTermination: &v1alpha1.HandoffTermination{Target: "user"}},
+		}}},
+	)
+	_, err = validator.ValidateCreate(ctx, team)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.terminationCondition.orTermination.conditions[3]: Required value")
+	assert.Contains(t, err.Error(), "spec.terminationCondition.orTermination.conditions[4].tokenUsageTermination: Required value")
+	assert.Contains(t, err.Error(), "spec.terminationCondition.orTermination.conditions[5].andTermination.conditions[0]: Invalid value")
+	assert.Contains(t, err.Error(), "spec.terminationCondition.orTermination.conditions[5].andTermination.conditions[0].timeoutTermination.timeout")
+	assert.NotContains(t, err.Error(), "conditions[2]")
+}
+
+func TestModelConfigValidator(t *testing.T) {
+	validator := &webhookv1alpha1.ModelConfigCustomValidator{}
+
+	modelConfig := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: namespace},
+		Spec: v1alpha1.ModelConfigSpec{
+			Provider: v1alpha1.OpenAI,
+			OpenAI:   &v1alpha1.OpenAIConfig{Temperature: "0.7", TopP: "0.95"},
+		},
+	}
+	_, err := validator.ValidateCreate(context.Background(), modelConfig)
+	assert.NoError(t, err)
+
+	modelConfig.Spec.OpenAI.TopP = "high"
+	_, err = validator.ValidateCreate(context.Background(), modelConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.openAI.topP")
+
+	mockConfig := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mock", Namespace: namespace},
+		Spec:       v1alpha1.ModelConfigSpec{Provider: v1alpha1.Mock},
+	}
+	_, err = validator.ValidateCreate(context.Background(), mockConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.mock")
+
+	mockConfig.Spec.Mock = &v1alpha1.MockConfig{ScriptConfigMapRef: "mock-script"}
+	_, err = validator.ValidateCreate(context.Background(), mockConfig)
+	assert.NoError(t, err)
+
+	modelConfig.Spec.OpenAI.TopP = "0.95"
+	modelConfig.Spec.Fallbacks = []string{"anthropic", namespace + "/model"}
+	_, err = validator.ValidateCreate(context.Background(), modelConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.fallbacks[1]")
+
+	modelConfig.Spec.Fallbacks = []string{"anthropic", namespace + "/anthropic"}
+	_, err = validator.ValidateCreate(context.Background(), modelConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Duplicate value")
+
+	modelConfig.Spec.Fallbacks = []string{"anthropic", "other/model"}
+	_, err = validator.ValidateCreate(context.Background(), modelConfig)
+	assert.NoError(t, err)
+}
+
+func TestToolServerValidator(t *testing.T) {
+	validator := &webhookv1alpha1.ToolServerCustomValidator{}
+
+	toolServer := &v1alpha1.ToolServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server", Namespace: namespace},
+		Spec: v1alpha1.ToolServerSpec{
+			Config: v1alpha1.ToolServerConfig{
+				Sse: &v1alpha1.SseMcpServerConfig{URL: "http://localhost", Timeout: "30s"},
+			},
+		},
+	}
+	_, err := validator.ValidateCreate(context.Background(), toolServer)
+	assert.NoError(t, err)
+
+	toolServer.Spec.Config.Sse.Timeout = "30 seconds"
+	_, err = validator.ValidateCreate(context.Background(), toolServer)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.config.sse.timeout")
+}
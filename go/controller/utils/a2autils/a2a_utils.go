@@ -1,6 +1,10 @@
 package a2autils
 
-import "trpc.group/trpc-go/trpc-a2a-go/protocol"
+import (
+	"encoding/json"
+
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
 
 // ExtractText extracts the text content from a message.
 func ExtractText(message protocol.Message) string {
@@ -11,3 +15,21 @@ func ExtractText(message protocol.Message) string {
 	}
 	return ""
 }
+
+// ExtractPartsText concatenates the text of the given parts.
+// Data parts are rendered as JSON, file parts are skipped.
+func ExtractPartsText(parts []protocol.Part) string {
+	var text string
+	for _, part := range parts {
+		switch p := part.(type) {
+		case protocol.TextPart:
+			text += p.Text
+		case protocol.DataPart:
+			b, err := json.Marshal(p.Data)
+			if err == nil {
+				text += string(b)
+			}
+		}
+	}
+	return text
+}
@@ -53,7 +53,7 @@ func TestE2E(t *testing.T) {
 	testStartTime := time.Now().String()
 
 	createOrFetchAgentSession := func(agentName string) *autogen_client.Session {
-		agentTeam, err := agentClient.GetTeam(agentName, GlobalUserID)
+		agentTeam, err := agentClient.GetTeam(ctx, agentName, GlobalUserID)
 		require.NoError(t, err)
 
 		require.NotNil(t, agentTeam, fmt.Sprintf("Agent with label %s not found", agentName))
@@ -61,7 +61,7 @@ func TestE2E(t *testing.T) {
 		apiTestTeam := agentTeam
 
 		// reuse existing sessions if available
-		existingSessions, err := agentClient.ListSessions(GlobalUserID)
+		existingSessions, err := agentClient.ListSessions(ctx, GlobalUserID)
 		require.NoError(t, err)
 		for _, session := range existingSessions {
 			if session.TeamID == apiTestTeam.Id && session.UserID == GlobalUserID {
@@ -69,7 +69,7 @@ func TestE2E(t *testing.T) {
 			}
 		}
 
-		sess, err := agentClient.CreateSession(&autogen_client.CreateSession{
+		sess, err := agentClient.CreateSession(ctx, &autogen_client.CreateSession{
 			UserID: GlobalUserID,
 			TeamID: apiTestTeam.Id,
 			Name:   fmt.Sprintf("e2e-test-%s-%s", agentName, testStartTime),
@@ -83,7 +83,7 @@ func TestE2E(t *testing.T) {
 	runAgentInteraction := func(agentLabel, prompt string) string {
 		sess := createOrFetchAgentSession(agentLabel)
 
-		result, err := agentClient.InvokeSession(sess.ID, GlobalUserID, prompt+`\nComplete the task without asking for confirmation, even if the task involves creating or deleting namespaces or other critical resources.`)
+		result, err := agentClient.InvokeSession(context.Background(), sess.ID, GlobalUserID, prompt+`\nComplete the task without asking for confirmation, even if the task involves creating or deleting namespaces or other critical resources.`)
 		require.NoError(t, err)
 
 		return result.TaskResult.Messages[len(result.TaskResult.Messages)-1]["content"].(string)
@@ -96,6 +96,10 @@ class SessionManager:
                     await self._save_message(user_id, run_id, message)
                 await self._update_run(run_id, RunStatus.COMPLETE, team_result=result.model_dump())
                 return result
+            except asyncio.CancelledError:
+                logger.info(f"Run {run_id} was cancelled")
+                await self._update_run(run_id, RunStatus.STOPPED, team_result=self._cancel_message)
+                raise
             except Exception as e:
                 await self._update_run(run_id, RunStatus.ERROR, error=str(e))
                 raise e
@@ -163,6 +167,13 @@ class SessionManager:
                     logger.warning(f"No final result captured for completed run {run_id}")
                     await self._update_run_status(run_id, RunStatus.COMPLETE)
 
+            except (asyncio.CancelledError, GeneratorExit):
+                # the client disconnected, stop the agents instead of running the task to completion
+                logger.info(f"Run {run_id} was cancelled")
+                cancellation_token.cancel()
+                await self._update_run(run_id, RunStatus.STOPPED, team_result=self._cancel_message)
+                raise
+
             except Exception as e:
                 logger.error(f"Stream error for run {run_id}: {e}")
                 traceback.print_exc()
@@ -1,6 +1,7 @@
+import asyncio
 import json
 import logging
-from typing import Any, Union
+from typing import Any, Awaitable, TypeVar, Union
 
 from autogen_agentchat.base import TaskResult
 from autogen_agentchat.messages import (
@@ -13,7 +14,8 @@ from autogen_agentchat.messages import (
     ToolCallRequestEvent,
     ToolCallSummaryMessage,
 )
-from fastapi import APIRouter
+from autogen_core import CancellationToken
+from fastapi import APIRouter, Request
 from fastapi.responses import StreamingResponse
 from pydantic import BaseModel
 
@@ -25,6 +27,35 @@ router = APIRouter()
 team_manager = TeamManager()
 logger = logging.getLogger(__name__)
 
+T = TypeVar("T")
+
+# how often requests are checked for a disconnected client
+DISCONNECT_POLL_INTERVAL = 1.0
+
+
+class ClientDisconnectedError(Exception):
+    """Raised when the client disconnected before the run completed"""
+
+
+async def run_until_disconnected(http_request: Request, run: Awaitable[T]) -> T:
+    """Await the run, cancelling it if the client disconnects first.
+
+    Unlike streaming responses, plain responses keep running after the client went away.
+    """
+    task = asyncio.ensure_future(run)
+    try:
+        while True:
+            done, _ = await asyncio.wait({task}, timeout=DISCONNECT_POLL_INTERVAL)
+            if done:
+                return task.result()
+            if await http_request.is_disconnected():
+                task.cancel()
+                await asyncio.gather(task, return_exceptions=True)
+                raise ClientDisconnectedError()
+    finally:
+        if not task.done():
+            task.cancel()
+
 
 class InvokeTaskRequest(BaseModel):
     task: str
@@ -32,10 +63,12 @@ class InvokeTaskRequest(BaseModel):
 
 
 @router.post("/")
-async def invoke(request: InvokeTaskRequest):
+async def invoke(request: InvokeTaskRequest, http_request: Request):
     response = Response(message="Task successfully completed", status=True, data=None)
     try:
-        result_message = await team_manager.run(task=request.task, team_config=request.team_config)
+        result_message = await run_until_disconnected(
+            http_request, team_manager.run(task=request.task, team_config=request.team_config)
+        )
         formatted_result = format_team_result(result_message)
         response.data = formatted_result
     except Exception as e:
@@ -109,12 +142,20 @@ async def stream(request: InvokeTaskRequest):
     logger.info(f"Invoking task with streaming: {request.task}")
 
     async def event_generator():
+        cancellation_token = CancellationToken()
         try:
-            async for event in team_manager.run_stream(task=request.task, team_config=request.team_config):
+            async for event in team_manager.run_stream(
+                task=request.task, team_config=request.team_config, cancellation_token=cancellation_token
+            ):
                 if isinstance(event, TeamResult):
                     yield f"event: task_result\ndata: {json.dumps(format_message(event))}\n\n"
                 else:
                     yield f"event: event\ndata: {json.dumps(format_message(event))}\n\n"
+        except (asyncio.CancelledError, GeneratorExit):
+            # the client disconnected, stop the agents instead of running the task to completion
+            logger.info("Task stream cancelled")
+            cancellation_token.cancel()
+            raise
         except Exception as e:
             logger.error(f"Error during SSE stream generation: {e}", exc_info=True)
             error_payload = {"type": "error", "data": {"message": str(e), "details": type(e).__name__}}
@@ -44,9 +44,14 @@ async def list_models() -> Dict[str, List[Dict[str, Any]]]:
 
     response_openai = [{"name": name, **props} for name, props in final_openai_models_map.items()]
 
+    response_gemini = [
+        {"name": name, **props} for name, props in final_openai_models_map.items() if name.startswith("gemini-")
+    ]
+
     return {
         "anthropic": response_anthropic,
         "ollama": response_ollama,
         "openAI": response_openai,
         "azureOpenAI": response_openai,
+        "gemini": response_gemini,
     }
@@ -2,7 +2,7 @@
 import json
 from typing import Dict
 
-from fastapi import APIRouter, Depends, HTTPException
+from fastapi import APIRouter, Depends, HTTPException, Request
 from fastapi.responses import StreamingResponse
 from loguru import logger
 from pydantic import BaseModel
@@ -11,7 +11,7 @@ from ...database import DatabaseManager
 from ...datamodel import Message, MessageConfig, Response, Run, RunStatus, Session, TeamResult
 from ...sessionmanager import SessionManager
 from ..deps import get_db, get_session_manager
-from .invoke import format_team_result
+from .invoke import ClientDisconnectedError, format_team_result, run_until_disconnected
 
 router = APIRouter()
 
@@ -147,15 +147,23 @@ async def invoke(
     session_id: int,
     user_id: str,
     request: InvokeRequest,
+    http_request: Request,
     db: DatabaseManager = Depends(get_db),
     session_mgr: SessionManager = Depends(get_session_manager),
 ) -> Response:
     try:
         run = _create_run(session_id, user_id, db, request.task)
-        result: TeamResult = await session_mgr.start(user_id, run.id, request.task)
+        result: TeamResult = await run_until_disconnected(
+            http_request, session_mgr.start(user_id, run.id, request.task)
+        )
         response = Response(status=True, data=format_team_result(result), message="Run executed successfully")
         return response
 
+    except ClientDisconnectedError:
+        # nobody is left to receive the response, the run has been recorded as stopped
+        logger.info(f"Client disconnected, stopped run of session {session_id}")
+        raise HTTPException(status_code=499, detail="Client disconnected") from None
+
     except Exception as e:
         logger.error(f"Error invoking run: {str(e)}")
         raise HTTPException(status_code=500, detail=f"Internal server error while invoking run: {str(e)}") from e
@@ -0,0 +1,12 @@
+from ._failover_chat_completion_client import FailoverChatCompletionClient, FailoverChatCompletionClientConfig
+from ._gemini_chat_completion_client import GeminiChatCompletionClient, GeminiChatCompletionClientConfig
+from ._mock_chat_completion_client import MockChatCompletionClient, MockChatCompletionClientConfig
+
+__all__ = [
+    "FailoverChatCompletionClient",
+    "FailoverChatCompletionClientConfig",
+    "GeminiChatCompletionClient",
+    "GeminiChatCompletionClientConfig",
+    "MockChatCompletionClient",
+    "MockChatCompletionClientConfig",
+]
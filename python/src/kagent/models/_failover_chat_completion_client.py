@@ -0,0 +1,170 @@
+import asyncio
+import logging
+from typing import Any, AsyncGenerator, List, Mapping, Optional, Sequence, Union
+
+from autogen_core import EVENT_LOGGER_NAME, CancellationToken, Component, ComponentModel
+from autogen_core.models import (
+    ChatCompletionClient,
+    CreateResult,
+    LLMMessage,
+    ModelCapabilities,  # type: ignore
+    ModelInfo,
+    RequestUsage,
+)
+from autogen_core.tools import Tool, ToolSchema
+from pydantic import BaseModel, Field
+from typing_extensions import Self
+
+logger = logging.getLogger(EVENT_LOGGER_NAME)
+
+
+class FailoverChatCompletionClientConfig(BaseModel):
+    model_clients: List[ComponentModel] = Field(description="The model clients, in the order they are tried")
+    round_robin: bool = Field(
+        default=False, description="Whether to rotate the model client which is tried first on every request"
+    )
+
+
+class FailoverChatCompletionClient(ChatCompletionClient, Component[FailoverChatCompletionClientConfig]):
+    """A chat completion client which tries its model clients in order until a request succeeds.
+
+    The model clients are the API keys of a model, which are rotated round-robin to spread the
+    load across their rate limits, or the fallbacks of a model, which keep agents working during
+    an outage of its provider. The model info is the one of the first model client.
+
+    A streamed request is only retried with the next model client if it fails before the first chunk,
+    as the chunks which were already yielded can not be taken back.
+    """
+
+    component_config_schema = FailoverChatCompletionClientConfig
+    component_type = "model"
+    component_provider_override = "kagent.models.FailoverChatCompletionClient"
+
+    def __init__(self, model_clients: List[ChatCompletionClient], round_robin: bool = False):
+        if not model_clients:
+            raise ValueError("At least one model client is required")
+        self._model_clients = model_clients
+        self._round_robin = round_robin
+        self._next = 0
+        self._total_usage = RequestUsage(prompt_tokens=0, completion_tokens=0)
+        self._actual_usage = RequestUsage(prompt_tokens=0, completion_tokens=0)
+
+    def _ordered_clients(self) -> List[ChatCompletionClient]:
+        if not self._round_robin:
+            return self._model_clients
+        start = self._next
+        self._next = (self._next + 1) % len(self._model_clients)
+        return self._model_clients[start:] + self._model_clients[:start]
+
+    async def create(
+        self,
+        messages: Sequence[LLMMessage],
+        *,
+        tools: Sequence[Tool | ToolSchema] = [],
+        tool_choice: Any = "auto",
+        json_output: Optional[bool | type[BaseModel]] = None,
+        extra_create_args: Mapping[str, Any] = {},
+        cancellation_token: Optional[CancellationToken] = None,
+    ) -> CreateResult:
+        last_error: Optional[Exception] = None
+        for index, model_client in enumerate(self._ordered_clients()):
+            try:
+                result = await model_client.create(
+                    messages,
+                    tools=tools,
+                    tool_choice=tool_choice,
+                    json_output=json_output,
+                    extra_create_args=extra_create_args,
+                    cancellation_token=cancellation_token,
+                )
+            except asyncio.CancelledError:
+                raise
+            except Exception as e:
+                logger.warning(f"Model client {index} failed, trying the next one: {e}")
+                last_error = e
+                continue
+            self._record_usage(result.usage)
+            return result
+        assert last_error is not None
+        raise last_error
+
+    async def create_stream(
+        self,
+        messages: Sequence[LLMMessage],
+        *,
+        tools: Sequence[Tool | ToolSchema] = [],
+        tool_choice: Any = "auto",
+        json_output: Optional[bool | type[BaseModel]] = None,
+        extra_create_args: Mapping[str, Any] = {},
+        cancellation_token: Optional[CancellationToken] = None,
+    ) -> AsyncGenerator[Union[str, CreateResult], None]:
+        last_error: Optional[Exception] = None
+        for index, model_client in enumerate(self._ordered_clients()):
+            started = False
+            try:
+                async for chunk in model_client.create_stream(
+                    messages,
+                    tools=tools,
+                    tool_choice=tool_choice,
+                    json_output=json_output,
+                    extra_create_args=extra_create_args,
+                    cancellation_token=cancellation_token,
+                ):
+                    started = True
+                    if isinstance(chunk, CreateResult):
+                        self._record_usage(chunk.usage)
+                    yield chunk
+                return
+            except asyncio.CancelledError:
+                raise
+            except Exception as e:
+                if started:
+                    raise
+                logger.warning(f"Model client {index} failed, trying the next one: {e}")
+                last_error = e
+        assert last_error is not None
+        raise last_error
+
+    def _record_usage(self, usage: RequestUsage) -> None:
+        self._actual_usage = usage
+        self._total_usage = RequestUsage(
+            prompt_tokens=self._total_usage.prompt_tokens + usage.prompt_tokens,
+            completion_tokens=self._total_usage.completion_tokens + usage.completion_tokens,
+        )
+
+    async def close(self) -> None:
+        for model_client in self._model_clients:
+            await model_client.close()
+
+    def actual_usage(self) -> RequestUsage:
+        return self._actual_usage
+
+    def total_usage(self) -> RequestUsage:
+        return self._total_usage
+
+    def count_tokens(self, messages: Sequence[LLMMessage], *, tools: Sequence[Tool | ToolSchema] = []) -> int:
+        return self._model_clients[0].count_tokens(messages, tools=tools)
+
+    def remaining_tokens(self, messages: Sequence[LLMMessage], *, tools: Sequence[Tool | ToolSchema] = []) -> int:
+        return self._model_clients[0].remaining_tokens(messages, tools=tools)
+
+    @property
+    def capabilities(self) -> ModelCapabilities:  # type: ignore
+        return self._model_clients[0].capabilities  # type: ignore
+
+    @property
+    def model_info(self) -> ModelInfo:
+        return self._model_clients[0].model_info
+
+    def _to_config(self) -> FailoverChatCompletionClientConfig:
+        return FailoverChatCompletionClientConfig(
+            model_clients=[model_client.dump_component() for model_client in self._model_clients],
+            round_robin=self._round_robin,
+        )
+
+    @classmethod
+    def _from_config(cls, config: FailoverChatCompletionClientConfig) -> Self:
+        return cls(
+            model_clients=[ChatCompletionClient.load_component(model_client) for model_client in config.model_clients],
+            round_robin=config.round_robin,
+        )
@@ -0,0 +1,356 @@
+import json
+import uuid
+from typing import Any, AsyncGenerator, Dict, List, Literal, Mapping, Optional, Sequence, Union
+
+import httpx
+from autogen_core import CancellationToken, Component, FunctionCall, Image
+from autogen_core.models import (
+    AssistantMessage,
+    ChatCompletionClient,
+    CreateResult,
+    FinishReasons,
+    FunctionExecutionResultMessage,
+    LLMMessage,
+    ModelCapabilities,  # type: ignore
+    ModelFamily,
+    ModelInfo,
+    RequestUsage,
+    SystemMessage,
+    UserMessage,
+)
+from autogen_core.tools import Tool, ToolSchema
+from autogen_ext.models.openai import _model_info
+from pydantic import BaseModel, Field
+from typing_extensions import Self
+
+DEFAULT_BASE_URL = "https://generativelanguage.googleapis.com/v1beta"
+
+# the context window of models unknown to autogen
+_DEFAULT_TOKEN_LIMIT = 1048576
+
+_DEFAULT_MODEL_INFO = ModelInfo(
+    vision=True,
+    function_calling=True,
+    json_output=True,
+    family=ModelFamily.UNKNOWN,
+    structured_output=True,
+    multiple_system_messages=False,
+)
+
+# JSON schema keywords which are not part of the OpenAPI subset accepted by Gemini
+_UNSUPPORTED_SCHEMA_KEYS = {"additionalProperties", "title", "$schema"}
+
+
+class GeminiSafetySetting(BaseModel):
+    category: str = Field(description="The harm category, e.g. HARM_CATEGORY_HARASSMENT")
+    threshold: str = Field(description="The blocking threshold, e.g. BLOCK_ONLY_HIGH")
+
+
+class GeminiChatCompletionClientConfig(BaseModel):
+    model: str
+    api_key: Optional[str] = None
+    base_url: Optional[str] = Field(default=None, description="The base URL of the Gemini API")
+    default_headers: Optional[Dict[str, str]] = None
+    temperature: Optional[float] = None
+    max_output_tokens: Optional[int] = None
+    top_p: Optional[float] = None
+    top_k: Optional[int] = None
+    candidate_count: Optional[int] = Field(
+        default=None, description="The number of candidates to generate, only the first one is returned"
+    )
+    safety_settings: List[GeminiSafetySetting] = Field(default_factory=list)
+    model_info: Optional[ModelInfo] = None
+
+
+class GeminiChatCompletionClient(ChatCompletionClient, Component[GeminiChatCompletionClientConfig]):
+    """A chat completion client for the Gemini API.
+
+    Unlike the OpenAI-compatible endpoint of Gemini, the native API accepts the safety settings,
+    top-k and candidate count of the generation config.
+    """
+
+    component_config_schema = GeminiChatCompletionClientConfig
+    component_type = "model"
+    component_provider_override = "kagent.models.GeminiChatCompletionClient"
+
+    def __init__(
+        self, config: GeminiChatCompletionClientConfig, *, transport: Optional[httpx.AsyncBaseTransport] = None
+    ):
+        self._config = config
+        self._model_info = config.model_info or _default_model_info(config.model)
+        headers = dict(config.default_headers or {})
+        if config.api_key:
+            headers["x-goog-api-key"] = config.api_key
+        self._client = httpx.AsyncClient(
+            base_url=(config.base_url or DEFAULT_BASE_URL).rstrip("/") + "/",
+            headers=headers,
+            timeout=httpx.Timeout(600.0, connect=10.0),
+            transport=transport,
+        )
+        self._total_usage = RequestUsage(prompt_tokens=0, completion_tokens=0)
+        self._actual_usage = RequestUsage(prompt_tokens=0, completion_tokens=0)
+
+    async def create(
+        self,
+        messages: Sequence[LLMMessage],
+        *,
+        tools: Sequence[Tool | ToolSchema] = [],
+        tool_choice: Any = "auto",
+        json_output: Optional[bool | type[BaseModel]] = None,
+        extra_create_args: Mapping[str, Any] = {},
+        cancellation_token: Optional[CancellationToken] = None,
+    ) -> CreateResult:
+        request = self._request(messages, tools, json_output, extra_create_args)
+        response = await self._client.post(f"models/{self._config.model}:generateContent", json=request)
+        _raise_for_status(response)
+        return self._result(response.json())
+
+    async def create_stream(
+        self,
+        messages: Sequence[LLMMessage],
+        *,
+        tools: Sequence[Tool | ToolSchema] = [],
+        tool_choice: Any = "auto",
+        json_output: Optional[bool | type[BaseModel]] = None,
+        extra_create_args: Mapping[str, Any] = {},
+        cancellation_token: Optional[CancellationToken] = None,
+        max_consecutive_empty_chunk_tolerance: int = 0,
+    ) -> AsyncGenerator[Union[str, CreateResult], None]:
+        request = self._request(messages, tools, json_output, extra_create_args)
+        parts: List[Dict[str, Any]] = []
+        last_chunk: Dict[str, Any] = {}
+        async with self._client.stream(
+            "POST", f"models/{self._config.model}:streamGenerateContent", params={"alt": "sse"}, json=request
+        ) as response:
+            if response.is_error:
+                await response.aread()
+                _raise_for_status(response)
+            async for line in response.aiter_lines():
+                if not line.startswith("data:"):
+                    continue
+                chunk = json.loads(line[len("data:") :])
+                last_chunk = chunk
+                for part in _candidate_parts(chunk):
+                    parts.append(part)
+                    if "text" in part and not part.get("thought"):
+                        yield part["text"]
+
+        # the result aggregates the parts of all chunks, the last chunk reports the usage and finish reason
+        candidates = last_chunk.get("candidates") or [{}]
+        aggregated = dict(last_chunk, candidates=[dict(candidates[0], content={"role": "model", "parts": parts})])
+        yield self._result(aggregated)
+
+    def _request(
+        self,
+        messages: Sequence[LLMMessage],
+        tools: Sequence[Tool | ToolSchema],
+        json_output: Optional[bool | type[BaseModel]],
+        extra_create_args: Mapping[str, Any],
+    ) -> Dict[str, Any]:
+        request: Dict[str, Any] = {"contents": []}
+
+        system = [message.content for message in messages if isinstance(message, SystemMessage)]
+        if system:
+            request["systemInstruction"] = {"parts": [{"text": "\n".join(system)}]}
+
+        for message in messages:
+            if isinstance(message, SystemMessage):
+                continue
+            content = _to_content(message)
+            # Gemini requires alternating turns, consecutive turns of the same role are merged
+            if request["contents"] and request["contents"][-1]["role"] == content["role"]:
+                request["contents"][-1]["parts"].extend(content["parts"])
+            else:
+                request["contents"].append(content)
+
+        if tools:
+            request["tools"] = [{"functionDeclarations": [_to_function_declaration(tool) for tool in tools]}]
+
+        generation_config: Dict[str, Any] = {}
+        for key, value in (
+            ("temperature", self._config.temperature),
+            ("topP", self._config.top_p),
+            ("topK", self._config.top_k),
+            ("candidateCount", self._config.candidate_count),
+            ("maxOutputTokens", self._config.max_output_tokens),
+        ):
+            if value is not None:
+                generation_config[key] = value
+        if json_output:
+            if not self._model_info["json_output"]:
+                raise ValueError("Model does not support JSON output.")
+            generation_config["responseMimeType"] = "application/json"
+            if isinstance(json_output, type) and issubclass(json_output, BaseModel):
+                generation_config["responseSchema"] = _to_gemini_schema(json_output.model_json_schema())
+        generation_config.update(extra_create_args)
+        if generation_config:
+            request["generationConfig"] = generation_config
+
+        if self._config.safety_settings:
+            request["safetySettings"] = [setting.model_dump() for setting in self._config.safety_settings]
+
+        return request
+
+    def _result(self, response: Dict[str, Any]) -> CreateResult:
+        candidates = response.get("candidates") or []
+        if not candidates:
+            block_reason = response.get("promptFeedback", {}).get("blockReason", "unknown")
+            raise ValueError(f"Gemini returned no candidates, the prompt was blocked: {block_reason}")
+
+        parts = _candidate_parts(response)
+        function_calls = [
+            FunctionCall(
+                id=part["functionCall"].get("id") or str(uuid.uuid4()),
+                name=part["functionCall"]["name"],
+                arguments=json.dumps(part["functionCall"].get("args", {})),
+            )
+            for part in parts
+            if "functionCall" in part
+        ]
+        thought = "".join(part["text"] for part in parts if "text" in part and part.get("thought")) or None
+
+        content: Union[str, List[FunctionCall]]
+        finish_reason: FinishReasons
+        if function_calls:
+            content = function_calls
+            finish_reason = "function_calls"
+        else:
+            content = "".join(part["text"] for part in parts if "text" in part and not part.get("thought"))
+            finish_reason = _finish_reason(candidates[0].get("finishReason"))
+
+        usage_metadata = response.get("usageMetadata", {})
+        usage = RequestUsage(
+            prompt_tokens=usage_metadata.get("promptTokenCount", 0),
+            completion_tokens=usage_metadata.get("candidatesTokenCount", 0),
+        )
+        self._actual_usage = usage
+        self._total_usage = RequestUsage(
+            prompt_tokens=self._total_usage.prompt_tokens + usage.prompt_tokens,
+            completion_tokens=self._total_usage.completion_tokens + usage.completion_tokens,
+        )
+        return CreateResult(finish_reason=finish_reason, content=content, usage=usage, cached=False, thought=thought)
+
+    async def close(self) -> None:
+        await self._client.aclose()
+
+    def actual_usage(self) -> RequestUsage:
+        return self._actual_usage
+
+    def total_usage(self) -> RequestUsage:
+        return self._total_usage
+
+    def count_tokens(self, messages: Sequence[LLMMessage], *, tools: Sequence[Tool | ToolSchema] = []) -> int:
+        # an estimate, counting tokens exactly requires a request to the countTokens endpoint
+        text = "".join(json.dumps(_to_content(m)) for m in messages if not isinstance(m, SystemMessage))
+        text += "".join(m.content for m in messages if isinstance(m, SystemMessage))
+        text += "".join(json.dumps(_to_function_declaration(tool)) for tool in tools)
+        return len(text) // 4
+
+    def remaining_tokens(self, messages: Sequence[LLMMessage], *, tools: Sequence[Tool | ToolSchema] = []) -> int:
+        try:
+            token_limit = _model_info.get_token_limit(self._config.model)
+        except KeyError:
+            token_limit = _DEFAULT_TOKEN_LIMIT
+        return max(0, token_limit - self.count_tokens(messages, tools=tools))
+
+    @property
+    def capabilities(self) -> ModelCapabilities:  # type: ignore
+        return self._model_info  # type: ignore
+
+    @property
+    def model_info(self) -> ModelInfo:
+        return self._model_info
+
+    def _to_config(self) -> GeminiChatCompletionClientConfig:
+        return self._config.model_copy()
+
+    @classmethod
+    def _from_config(cls, config: GeminiChatCompletionClientConfig) -> Self:
+        return cls(config)
+
+
+def _default_model_info(model: str) -> ModelInfo:
+    try:
+        return _model_info.get_info(model)
+    except KeyError:
+        return _DEFAULT_MODEL_INFO
+
+
+def _raise_for_status(response: httpx.Response) -> None:
+    if response.is_error:
+        raise RuntimeError(f"Gemini request failed with status {response.status_code}: {response.text}")
+
+
+def _candidate_parts(response: Dict[str, Any]) -> List[Dict[str, Any]]:
+    candidates = response.get("candidates") or []
+    if not candidates:
+        return []
+    return candidates[0].get("content", {}).get("parts", [])
+
+
+def _finish_reason(reason: Optional[str]) -> FinishReasons:
+    if reason in (None, "STOP", "FINISH_REASON_UNSPECIFIED"):
+        return "stop"
+    if reason == "MAX_TOKENS":
+        return "length"
+    if reason in ("SAFETY", "RECITATION", "BLOCKLIST", "PROHIBITED_CONTENT", "SPII", "IMAGE_SAFETY"):
+        return "content_filter"
+    return "unknown"
+
+
+def _to_content(message: LLMMessage) -> Dict[str, Any]:
+    role: Literal["user", "model"]
+    parts: List[Dict[str, Any]] = []
+    if isinstance(message, UserMessage):
+        role = "user"
+        items = [message.content] if isinstance(message.content, str) else message.content
+        for item in items:
+            if isinstance(item, Image):
+                mime_type, data = item.data_uri.removeprefix("data:").split(";base64,", 1)
+                parts.append({"inlineData": {"mimeType": mime_type, "data": data}})
+            else:
+                parts.append({"text": item})
+    elif isinstance(message, AssistantMessage):
+        role = "model"
+        if isinstance(message.content, str):
+            parts.append({"text": message.content})
+        else:
+            if message.thought:
+                parts.append({"text": message.thought})
+            for call in message.content:
+                parts.append({"functionCall": {"name": call.name, "args": json.loads(call.arguments or "{}")}})
+    elif isinstance(message, FunctionExecutionResultMessage):
+        role = "user"
+        for result in message.content:
+            key = "error" if result.is_error else "content"
+            parts.append({"functionResponse": {"name": result.name, "response": {key: result.content}}})
+    else:
+        raise ValueError(f"Unsupported message type: {type(message)}")
+    return {"role": role, "parts": parts}
+
+
+def _to_function_declaration(tool: Tool | ToolSchema) -> Dict[str, Any]:
+    schema = tool.schema if isinstance(tool, Tool) else tool
+    declaration: Dict[str, Any] = {"name": schema["name"]}
+    if "description" in schema:
+        declaration["description"] = schema["description"]
+    parameters = schema.get("parameters")
+    if parameters and parameters.get("properties"):
+        declaration["parameters"] = _to_gemini_schema(dict(parameters))
+    return declaration
+
+
+def _to_gemini_schema(schema: Any) -> Any:
+    """Removes the JSON schema keywords which Gemini rejects."""
+    if isinstance(schema, dict):
+        result: Dict[str, Any] = {}
+        for key, value in schema.items():
+            if key == "properties" and isinstance(value, dict):
+                # the keys of properties are names, not keywords
+                result[key] = {name: _to_gemini_schema(prop) for name, prop in value.items()}
+            elif key not in _UNSUPPORTED_SCHEMA_KEYS:
+                result[key] = _to_gemini_schema(value)
+        return result
+    if isinstance(schema, list):
+        return [_to_gemini_schema(item) for item in schema]
+    return schema
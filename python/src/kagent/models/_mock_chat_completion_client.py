@@ -0,0 +1,174 @@
+import re
+import uuid
+from typing import Any, AsyncGenerator, List, Mapping, Optional, Sequence, Union
+
+from autogen_core import CancellationToken, Component, FunctionCall
+from autogen_core.models import (
+    ChatCompletionClient,
+    CreateResult,
+    FunctionExecutionResultMessage,
+    LLMMessage,
+    ModelCapabilities,  # type: ignore
+    ModelFamily,
+    ModelInfo,
+    RequestUsage,
+)
+from autogen_core.tools import Tool, ToolSchema
+from pydantic import BaseModel, Field
+from typing_extensions import Self
+
+
+class MockToolCall(BaseModel):
+    name: str = Field(description="The name of the tool to call")
+    arguments: str = Field(default="{}", description="The JSON encoded arguments of the call")
+
+
+class MockResponse(BaseModel):
+    content: str = Field(default="", description="The text of the response")
+    tool_calls: List[MockToolCall] = Field(
+        default_factory=list, description="The tool calls of the response, which take precedence over the content"
+    )
+
+
+class MockRule(BaseModel):
+    match: str = Field(description="A regular expression matched against the content of the last message")
+    response: MockResponse
+
+
+class MockChatCompletionClientConfig(BaseModel):
+    model: str = Field(default="mock", description="The name of the model reported in the results")
+    rules: List[MockRule] = Field(default_factory=list, description="The rules of the script, in order of precedence")
+    default_response: Optional[MockResponse] = Field(
+        default=None, description="The response if no rule matches the last message"
+    )
+    model_info: Optional[ModelInfo] = None
+
+
+_DEFAULT_MODEL_INFO = ModelInfo(
+    vision=False,
+    function_calling=True,
+    json_output=False,
+    family=ModelFamily.UNKNOWN,
+    structured_output=False,
+    multiple_system_messages=True,
+)
+
+
+class MockChatCompletionClient(ChatCompletionClient, Component[MockChatCompletionClientConfig]):
+    """A chat completion client which replies with the responses of a script instead of calling a model.
+
+    The response of the first rule whose regular expression matches the content of the last
+    message is returned, so that agent workflows run deterministically, e.g. in CI.
+    The content of tool results is matched, so that rules can reply to the results of their tool calls.
+    """
+
+    component_config_schema = MockChatCompletionClientConfig
+    component_type = "model"
+    component_provider_override = "kagent.models.MockChatCompletionClient"
+
+    def __init__(self, config: MockChatCompletionClientConfig):
+        self._config = config
+        self._rules = [(re.compile(rule.match), rule.response) for rule in config.rules]
+        self._model_info = config.model_info or _DEFAULT_MODEL_INFO
+        self._total_usage = RequestUsage(prompt_tokens=0, completion_tokens=0)
+        self._actual_usage = RequestUsage(prompt_tokens=0, completion_tokens=0)
+
+    async def create(
+        self,
+        messages: Sequence[LLMMessage],
+        *,
+        tools: Sequence[Tool | ToolSchema] = [],
+        tool_choice: Any = "auto",
+        json_output: Optional[bool | type[BaseModel]] = None,
+        extra_create_args: Mapping[str, Any] = {},
+        cancellation_token: Optional[CancellationToken] = None,
+    ) -> CreateResult:
+        response = self._match(messages)
+
+        content: Union[str, List[FunctionCall]]
+        if response.tool_calls:
+            content = [
+                FunctionCall(id=str(uuid.uuid4()), name=call.name, arguments=call.arguments)
+                for call in response.tool_calls
+            ]
+            finish_reason = "function_calls"
+        else:
+            content = response.content
+            finish_reason = "stop"
+
+        usage = RequestUsage(
+            prompt_tokens=self.count_tokens(messages),
+            completion_tokens=len(str(content).split()),
+        )
+        self._actual_usage = usage
+        self._total_usage = RequestUsage(
+            prompt_tokens=self._total_usage.prompt_tokens + usage.prompt_tokens,
+            completion_tokens=self._total_usage.completion_tokens + usage.completion_tokens,
+        )
+        return CreateResult(finish_reason=finish_reason, content=content, usage=usage, cached=False)
+
+    async def create_stream(
+        self,
+        messages: Sequence[LLMMessage],
+        *,
+        tools: Sequence[Tool | ToolSchema] = [],
+        tool_choice: Any = "auto",
+        json_output: Optional[bool | type[BaseModel]] = None,
+        extra_create_args: Mapping[str, Any] = {},
+        cancellation_token: Optional[CancellationToken] = None,
+    ) -> AsyncGenerator[Union[str, CreateResult], None]:
+        result = await self.create(messages, tools=tools, cancellation_token=cancellation_token)
+        if isinstance(result.content, str):
+            for token in re.findall(r"\S+\s*", result.content):
+                yield token
+        yield result
+
+    def _match(self, messages: Sequence[LLMMessage]) -> MockResponse:
+        text = _message_text(messages[-1]) if messages else ""
+        for pattern, response in self._rules:
+            if pattern.search(text):
+                return response
+        if self._config.default_response is not None:
+            return self._config.default_response
+        raise ValueError(f"No rule of the mock script matches the last message: {text!r}")
+
+    async def close(self) -> None:
+        pass
+
+    def actual_usage(self) -> RequestUsage:
+        return self._actual_usage
+
+    def total_usage(self) -> RequestUsage:
+        return self._total_usage
+
+    def count_tokens(self, messages: Sequence[LLMMessage], *, tools: Sequence[Tool | ToolSchema] = []) -> int:
+        return sum(len(_message_text(message).split()) for message in messages)
+
+    def remaining_tokens(self, messages: Sequence[LLMMessage], *, tools: Sequence[Tool | ToolSchema] = []) -> int:
+        return max(0, 128000 - self.count_tokens(messages, tools=tools))
+
+    @property
+    def capabilities(self) -> ModelCapabilities:  # type: ignore
+        return self._model_info  # type: ignore
+
+    @property
+    def model_info(self) -> ModelInfo:
+        return self._model_info
+
+    def _to_config(self) -> MockChatCompletionClientConfig:
+        return self._config.model_copy()
+
+    @classmethod
+    def _from_config(cls, config: MockChatCompletionClientConfig) -> Self:
+        return cls(config)
+
+
+def _message_text(message: LLMMessage) -> str:
+    """Returns the text of the message which the rules of the script are matched against."""
+    if isinstance(message, FunctionExecutionResultMessage):
+        return "\n".join(result.content for result in message.content)
+    if isinstance(message.content, str):
+        return message.content
+    if isinstance(message.content, list):
+        return "\n".join(item if isinstance(item, str) else str(item) for item in message.content)
+    return str(message.content)
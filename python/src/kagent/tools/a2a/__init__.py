@@ -0,0 +1,3 @@
+from ._remote_agent import Config, RemoteAgentTool
+
+__all__ = ["Config", "RemoteAgentTool"]
@@ -0,0 +1,67 @@
+import os
+from typing import Optional
+
+import httpx
+from autogen_core import CancellationToken, Component
+from autogen_core.tools import BaseTool
+from pydantic import BaseModel, Field
+
+DEFAULT_CONTROLLER_URL = "http://127.0.0.1:8084"
+
+
+class Config(BaseModel):
+    """Configuration for the remote agent tool."""
+
+    name: str = Field(description="The name of the tool")
+    description: str = Field(default="", description="A description of the remote agent")
+    remote_agent: str = Field(description="The RemoteAgent resource to send tasks to, in the form <namespace>/<name>")
+    controller_url: Optional[str] = Field(
+        default=None,
+        description="The URL of the kagent controller. If empty, the environment variable 'KAGENT_CONTROLLER_URL' is used.",
+    )
+    timeout: float = Field(default=600, description="Timeout in seconds for the remote agent to complete a task")
+
+
+class RemoteAgentInput(BaseModel):
+    task: str = Field(description="The task for the agent, including all the context it needs to complete it")
+
+
+class RemoteAgentResult(BaseModel):
+    result: str
+
+
+class RemoteAgentTool(BaseTool[RemoteAgentInput, RemoteAgentResult], Component[Config]):
+    """Sends tasks to an agent served by an external A2A server.
+
+    The kagent controller talks A2A to the remote agent on behalf of the tool. It serves these
+    requests on a separate listener bound to the loopback interface of its pod, so the controller
+    URL must be the address of that listener.
+    """
+
+    component_type = "tool"
+    component_config_schema = Config
+    component_provider_override = "kagent.tools.a2a.RemoteAgentTool"
+
+    def __init__(self, config: Config) -> None:
+        super().__init__(RemoteAgentInput, RemoteAgentResult, config.name, config.description)
+        self.config = config
+
+    async def run(self, args: RemoteAgentInput, cancellation_token: CancellationToken) -> RemoteAgentResult:
+        controller_url = self.config.controller_url or os.environ.get("KAGENT_CONTROLLER_URL", DEFAULT_CONTROLLER_URL)
+        url = f"{controller_url.rstrip('/')}/api/remoteagents/{self.config.remote_agent}/invoke"
+
+        async with httpx.AsyncClient(timeout=self.config.timeout) as client:
+            response = await client.post(url, json={"task": args.task})
+            if response.is_error:
+                raise RuntimeError(f"Remote agent {self.config.remote_agent} failed: {response.text}")
+            return RemoteAgentResult.model_validate(response.json())
+
+    def return_value_as_string(self, value: RemoteAgentResult) -> str:
+        return value.result
+
+    def _to_config(self) -> Config:
+        return self.config.model_copy()
+
+    @classmethod
+    def _from_config(cls, config: Config) -> "RemoteAgentTool":
+        return cls(config)
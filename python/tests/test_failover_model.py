@@ -0,0 +1,52 @@
+import pytest
+from autogen_core.models import UserMessage
+
+from kagent.models import FailoverChatCompletionClient
+
+
+def mock_client(reply: str | None) -> dict:
+    config = {"model": "mock", "rules": []}
+    if reply is not None:
+        config["default_response"] = {"content": reply}
+    return {"provider": "kagent.models.MockChatCompletionClient", "component_type": "model", "config": config}
+
+
+def create_client(*replies: str | None, round_robin: bool = False) -> FailoverChatCompletionClient:
+    return FailoverChatCompletionClient.load_component(
+        {
+            "provider": "kagent.models.FailoverChatCompletionClient",
+            "component_type": "model",
+            "config": {"model_clients": [mock_client(reply) for reply in replies], "round_robin": round_robin},
+        }
+    )
+
+
+MESSAGES = [UserMessage(content="hello", source="user")]
+
+
+@pytest.mark.asyncio
+async def test_failover_client_falls_back_in_order() -> None:
+    client = create_client(None, "fallback", "unused")
+
+    result = await client.create(MESSAGES)
+    assert result.content == "fallback"
+
+    chunks = [chunk async for chunk in client.create_stream(MESSAGES)]
+    assert chunks[-1].content == "fallback"
+
+
+@pytest.mark.asyncio
+async def test_failover_client_rotates_round_robin() -> None:
+    client = create_client("a", "b", None, round_robin=True)
+
+    replies = [(await client.create(MESSAGES)).content for _ in range(4)]
+    # the failing third client falls through to the first one
+    assert replies == ["a", "b", "a", "a"]
+
+
+@pytest.mark.asyncio
+async def test_failover_client_fails_if_all_clients_fail() -> None:
+    client = create_client(None, None)
+
+    with pytest.raises(ValueError, match="No rule of the mock script matches"):
+        await client.create(MESSAGES)
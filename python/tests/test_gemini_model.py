@@ -0,0 +1,161 @@
+import json
+from typing import Any, Dict, List
+
+import httpx
+import pytest
+from autogen_core import FunctionCall
+from autogen_core.models import (
+    AssistantMessage,
+    FunctionExecutionResult,
+    FunctionExecutionResultMessage,
+    SystemMessage,
+    UserMessage,
+)
+from autogen_core.tools import ToolSchema
+
+from kagent.models import GeminiChatCompletionClient, GeminiChatCompletionClientConfig
+
+CONFIG = GeminiChatCompletionClientConfig(
+    model="gemini-2.0-flash",
+    api_key="secret",
+    top_k=40,
+    candidate_count=2,
+    safety_settings=[{"category": "HARM_CATEGORY_HARASSMENT", "threshold": "BLOCK_ONLY_HIGH"}],
+)
+
+TOOL = ToolSchema(
+    name="k8s_get_resources",
+    description="Gets resources",
+    parameters={
+        "type": "object",
+        "properties": {"resource_type": {"type": "string", "title": "Resource Type"}},
+        "required": ["resource_type"],
+        "additionalProperties": False,
+    },
+)
+
+
+FUNCTION_CALL = {"functionCall": {"name": "k8s_get_resources", "args": {"resource_type": "pod"}}}
+FUNCTION_RESPONSE = {"functionResponse": {"name": "k8s_get_resources", "response": {"content": "pod-a"}}}
+
+
+def create_client(responses: List[Dict[str, Any]], requests: List[httpx.Request]) -> GeminiChatCompletionClient:
+    def handler(request: httpx.Request) -> httpx.Response:
+        requests.append(request)
+        return httpx.Response(200, json=responses.pop(0))
+
+    return GeminiChatCompletionClient(CONFIG, transport=httpx.MockTransport(handler))
+
+
+@pytest.mark.asyncio
+async def test_gemini_client_sends_generation_config() -> None:
+    requests: List[httpx.Request] = []
+    client = create_client(
+        [
+            {
+                "candidates": [{"content": {"role": "model", "parts": [{"text": "hi"}]}, "finishReason": "STOP"}],
+                "usageMetadata": {"promptTokenCount": 5, "candidatesTokenCount": 1},
+            }
+        ],
+        requests,
+    )
+
+    result = await client.create(
+        [SystemMessage(content="be brief"), UserMessage(content="hello", source="user")], tools=[TOOL]
+    )
+    assert result.content == "hi"
+    assert result.finish_reason == "stop"
+    assert result.usage.prompt_tokens == 5
+
+    request = requests[0]
+    assert request.url.path == "/v1beta/models/gemini-2.0-flash:generateContent"
+    assert request.headers["x-goog-api-key"] == "secret"
+    body = json.loads(request.content)
+    assert body["systemInstruction"] == {"parts": [{"text": "be brief"}]}
+    assert body["contents"] == [{"role": "user", "parts": [{"text": "hello"}]}]
+    assert body["generationConfig"] == {"topK": 40, "candidateCount": 2}
+    assert body["safetySettings"] == [{"category": "HARM_CATEGORY_HARASSMENT", "threshold": "BLOCK_ONLY_HIGH"}]
+    assert body["tools"][0]["functionDeclarations"][0]["parameters"] == {
+        "type": "object",
+        "properties": {"resource_type": {"type": "string"}},
+        "required": ["resource_type"],
+    }
+
+
+@pytest.mark.asyncio
+async def test_gemini_client_calls_functions() -> None:
+    requests: List[httpx.Request] = []
+    client = create_client(
+        [
+            {
+                "candidates": [
+                    {
+                        "content": {
+                            "role": "model",
+                            "parts": [FUNCTION_CALL],
+                        },
+                        "finishReason": "STOP",
+                    }
+                ]
+            },
+            {"candidates": [{"content": {"role": "model", "parts": [{"text": "found pod-a"}]}}]},
+        ],
+        requests,
+    )
+
+    messages = [UserMessage(content="list the pods", source="user")]
+    result = await client.create(messages, tools=[TOOL])
+    assert result.finish_reason == "function_calls"
+    assert isinstance(result.content, list)
+    call = result.content[0]
+    assert isinstance(call, FunctionCall)
+    assert json.loads(call.arguments) == {"resource_type": "pod"}
+
+    messages += [
+        AssistantMessage(content=[call], source="assistant"),
+        FunctionExecutionResultMessage(
+            content=[FunctionExecutionResult(call_id=call.id, name=call.name, content="pod-a")]
+        ),
+    ]
+    result = await client.create(messages, tools=[TOOL])
+    assert result.content == "found pod-a"
+
+    body = json.loads(requests[1].content)
+    assert body["contents"][1:] == [
+        {"role": "model", "parts": [FUNCTION_CALL]},
+        {"role": "user", "parts": [FUNCTION_RESPONSE]},
+    ]
+
+
+@pytest.mark.asyncio
+async def test_gemini_client_streams_content() -> None:
+    def handler(request: httpx.Request) -> httpx.Response:
+        assert request.url.path.endswith(":streamGenerateContent")
+        assert request.url.params["alt"] == "sse"
+        chunks = [
+            {"candidates": [{"content": {"role": "model", "parts": [{"text": "found "}]}}]},
+            {
+                "candidates": [{"content": {"role": "model", "parts": [{"text": "pod-a"}]}, "finishReason": "STOP"}],
+                "usageMetadata": {"promptTokenCount": 3, "candidatesTokenCount": 2},
+            },
+        ]
+        return httpx.Response(200, content="".join(f"data: {json.dumps(chunk)}\r\n\r\n" for chunk in chunks))
+
+    client = GeminiChatCompletionClient(CONFIG, transport=httpx.MockTransport(handler))
+
+    chunks = [chunk async for chunk in client.create_stream([UserMessage(content="pods?", source="user")])]
+    assert chunks[:-1] == ["found ", "pod-a"]
+    assert chunks[-1].content == "found pod-a"
+    assert chunks[-1].usage.completion_tokens == 2
+
+
+def test_gemini_client_loads_component() -> None:
+    client = GeminiChatCompletionClient.load_component(
+        {
+            "provider": "kagent.models.GeminiChatCompletionClient",
+            "component_type": "model",
+            "config": {"model": "gemini-2.0-flash", "api_key": "secret", "top_k": 40},
+        }
+    )
+    assert isinstance(client, GeminiChatCompletionClient)
+    assert client.dump_component().config["top_k"] == 40
@@ -0,0 +1,65 @@
+import pytest
+from autogen_core import FunctionCall
+from autogen_core.models import FunctionExecutionResult, FunctionExecutionResultMessage, UserMessage
+
+from kagent.models import MockChatCompletionClient, MockChatCompletionClientConfig
+
+SCRIPT = {
+    "model": "mock",
+    "rules": [
+        {
+            "match": "list (the )?pods",
+            "response": {"tool_calls": [{"name": "k8s_get_resources", "arguments": '{"resource_type": "pod"}'}]},
+        },
+        {"match": "pod-", "response": {"content": "found pod-a"}},
+    ],
+    "default_response": {"content": "I don't know"},
+}
+
+
+def create_client(script=SCRIPT) -> MockChatCompletionClient:
+    return MockChatCompletionClient.load_component(
+        {
+            "provider": "kagent.models.MockChatCompletionClient",
+            "component_type": "model",
+            "config": script,
+        }
+    )
+
+
+@pytest.mark.asyncio
+async def test_mock_client_replies_with_matching_rule() -> None:
+    client = create_client()
+
+    result = await client.create([UserMessage(content="please list the pods", source="user")])
+    assert result.finish_reason == "function_calls"
+    assert isinstance(result.content, list)
+    assert isinstance(result.content[0], FunctionCall)
+    assert result.content[0].name == "k8s_get_resources"
+    assert result.content[0].arguments == '{"resource_type": "pod"}'
+
+    tool_result = FunctionExecutionResultMessage(
+        content=[FunctionExecutionResult(call_id=result.content[0].id, name="k8s_get_resources", content="pod-a")]
+    )
+    result = await client.create([tool_result])
+    assert result.content == "found pod-a"
+
+    result = await client.create([UserMessage(content="hello", source="user")])
+    assert result.content == "I don't know"
+
+
+@pytest.mark.asyncio
+async def test_mock_client_streams_content() -> None:
+    client = create_client()
+
+    chunks = [chunk async for chunk in client.create_stream([UserMessage(content="pod-a?", source="user")])]
+    assert "".join(chunks[:-1]) == "found pod-a"
+    assert chunks[-1].content == "found pod-a"
+
+
+@pytest.mark.asyncio
+async def test_mock_client_fails_without_matching_rule() -> None:
+    client = create_client(MockChatCompletionClientConfig(rules=[]).model_dump())
+
+    with pytest.raises(ValueError, match="No rule of the mock script matches"):
+        await client.create([UserMessage(content="hello", source="user")])